@@ -21,17 +21,30 @@ export type SwitchDbRequest = {
 };
 
 export type ConnectionInfo = {
+    id?: string;
     host: string;
     port: number;
     user: string;
     database: string;
     version: string;
     connected?: boolean;
+    /**
+     * Writes are rejected on this connection, either because of the --read-only flag or the connection profile setting.
+     */
+    read_only?: boolean;
+    /**
+     * Set in the connection list when this connection's details could not be read; the other fields except id may then be empty.
+     */
+    error?: string;
 };
 
 export type AppInfo = {
     version?: string;
     ai_enabled?: boolean;
+    /**
+     * The server was started with --read-only, so every connection is read-only.
+     */
+    read_only?: boolean;
 };
 
 export type SchemaObject = {
@@ -68,6 +68,7 @@ export function ConnectionDialog() {
             user: info.user,
             database: info.database,
             version: info.version,
+            read_only: info.read_only,
           })
           useTabStore.getState().initFromServer()
         },
@@ -41,3 +41,22 @@ export const Connected: Story = {
     },
   ],
 }
+
+export const ReadOnly: Story = {
+  decorators: [
+    (Story) => {
+      const setConnected = useConnectionStore((s) => s.setConnected)
+      useEffect(() => {
+        setConnected({
+          host: 'db.example.com',
+          port: 5432,
+          user: 'readonly',
+          database: 'production',
+          version: '16.2',
+          read_only: true,
+        })
+      }, [])
+      return <Story />
+    },
+  ],
+}
@@ -13,6 +13,14 @@ export function StatusBar() {
             Connected: {info.database}@{info.host}:{info.port}
           </span>
           <DatabaseSwitcher />
+          {info.read_only && (
+            <span
+              className="rounded bg-amber-100 px-1.5 font-semibold uppercase tracking-wider text-amber-700 dark:bg-amber-900/40 dark:text-amber-400"
+              title="Writes and DDL are rejected on this connection"
+            >
+              Read-only
+            </span>
+          )}
           <span className="text-gray-300 dark:text-gray-600">|</span>
           <span>PG {info.version}</span>
         </>
@@ -6,6 +6,7 @@ interface ConnectionInfo {
   user: string
   database: string
   version: string
+  read_only?: boolean
 }
 
 interface ConnectionState {
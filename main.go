@@ -19,7 +19,6 @@ import (
 
 	"github.com/lmittmann/tint"
 	"github.com/macleodmac/pglet/pkg/api"
-	"github.com/macleodmac/pglet/pkg/client"
 	"github.com/macleodmac/pglet/pkg/repository"
 	"github.com/macleodmac/pglet/pkg/service"
 	"github.com/macleodmac/pglet/static"
@@ -53,6 +52,8 @@ type Config struct {
 	RepoDir     string
 	Dev         bool
 	Cors        bool
+	ReadOnly    bool
+	MaxConns    int
 }
 
 func parseConfig() Config {
@@ -133,6 +134,13 @@ func parseConfig() Config {
 			cfg.Dev = true
 		case "--cors":
 			cfg.Cors = true
+		case "--read-only":
+			cfg.ReadOnly = true
+		case "--max-conns":
+			if i+1 < len(args) {
+				fmt.Sscanf(args[i+1], "%d", &cfg.MaxConns)
+				i++
+			}
 		}
 	}
 
@@ -231,17 +239,18 @@ func main() {
 
 	// Setup service and server
 	svc := service.New(repo, getVersion())
+	svc.ReadOnly = cfg.ReadOnly
+	svc.MaxConns = cfg.MaxConns
 	server := api.NewServer(svc)
 
 	// Auto-connect if URL provided
 	connURL := buildConnectionURL(cfg)
 	if connURL != "" {
-		cl, err := client.New(connURL)
+		info, err := svc.Connect(service.DefaultConnection, connURL)
 		if err != nil {
 			slog.Warn("failed to connect", "err", err)
 		} else {
-			svc.SetClient(cl)
-			slog.Debug("connected", "database", cl.Database(), "elapsed", time.Since(start))
+			slog.Debug("connected", "database", info.Database, "elapsed", time.Since(start))
 		}
 	}
 
@@ -353,6 +362,8 @@ Connection:
   --pass <pass>     Database password
   --db <name>       Database name
   --ssl <mode>      SSL mode (default: disable)
+  --read-only       Reject writes and DDL on every connection
+  --max-conns <n>   Connections per database, shared by tabs (default: 10)
 
 Server:
   --bind <addr>     Bind address (default: localhost)
@@ -20,7 +20,7 @@ func (s *Server) AiGenerate(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	sql, explanation, err := s.svc.GenerateSQL(r.Context(), req.Prompt, history)
+	sql, explanation, err := s.svc.GenerateSQL(r.Context(), connID(r), req.Prompt, history)
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -30,7 +30,7 @@ func (s *Server) AiGenerate(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) AiSuggestions(w http.ResponseWriter, r *http.Request) {
-	suggestions, err := s.svc.AISuggestions(r.Context())
+	suggestions, err := s.svc.AISuggestions(r.Context(), connID(r))
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
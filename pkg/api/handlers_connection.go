@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/macleodmac/pglet/pkg/client"
 	"github.com/macleodmac/pglet/pkg/service"
 )
 
@@ -14,20 +15,32 @@ func (s *Server) Connect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	info, err := s.svc.Connect(req.Url)
+	id := connID(r)
+	if req.Id != nil {
+		id = *req.Id
+	}
+
+	var info *client.ConnectionInfo
+	var err error
+	switch {
+	case req.ProfileId != nil:
+		info, err = s.svc.ConnectProfile(id, *req.ProfileId)
+	case req.Url != nil:
+		info, err = s.svc.Connect(id, *req.Url)
+	default:
+		writeErrMsg(w, http.StatusBadRequest, "url or profile_id is required")
+		return
+	}
 	if err != nil {
 		writeErr(w, http.StatusBadRequest, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, ConnectionInfo{
-		Host: info.Host, Port: info.Port, User: info.User,
-		Database: info.Database, Version: info.Version,
-	})
+	writeJSON(w, http.StatusOK, toConnectionInfo(info))
 }
 
 func (s *Server) Disconnect(w http.ResponseWriter, r *http.Request) {
-	s.svc.Disconnect()
+	s.svc.Disconnect(connID(r))
 	success := true
 	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
 }
@@ -39,7 +52,7 @@ func (s *Server) SwitchDatabase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	info, err := s.svc.SwitchDatabase(req.Database)
+	info, err := s.svc.SwitchDatabase(connID(r), req.Database)
 	if errors.Is(err, service.ErrNotConnected) {
 		writeErr(w, http.StatusBadRequest, err)
 		return
@@ -49,14 +62,11 @@ func (s *Server) SwitchDatabase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, ConnectionInfo{
-		Host: info.Host, Port: info.Port, User: info.User,
-		Database: info.Database, Version: info.Version,
-	})
+	writeJSON(w, http.StatusOK, toConnectionInfo(info))
 }
 
 func (s *Server) GetConnectionInfo(w http.ResponseWriter, r *http.Request) {
-	info, connected, err := s.svc.ConnectionInfo()
+	info, connected, err := s.svc.ConnectionInfo(connID(r))
 	if !connected {
 		c := false
 		writeJSON(w, http.StatusOK, ConnectionInfo{Connected: &c})
@@ -66,14 +76,20 @@ func (s *Server) GetConnectionInfo(w http.ResponseWriter, r *http.Request) {
 		writeErr(w, http.StatusInternalServerError, err)
 		return
 	}
-	writeJSON(w, http.StatusOK, ConnectionInfo{
-		Host: info.Host, Port: info.Port, User: info.User,
-		Database: info.Database, Version: info.Version,
-	})
+	writeJSON(w, http.StatusOK, toConnectionInfo(info))
+}
+
+func (s *Server) ListConnections(w http.ResponseWriter, r *http.Request) {
+	infos := s.svc.Connections()
+	result := make([]ConnectionInfo, len(infos))
+	for i := range infos {
+		result[i] = toConnectionInfo(&infos[i])
+	}
+	writeJSON(w, http.StatusOK, result)
 }
 
 func (s *Server) ListDatabases(w http.ResponseWriter, r *http.Request) {
-	dbs, err := s.svc.Databases()
+	dbs, err := s.svc.Databases(connID(r))
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, service.ErrNotConnected) {
@@ -86,6 +102,14 @@ func (s *Server) ListDatabases(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) GetAppInfo(w http.ResponseWriter, r *http.Request) {
-	v, aiEnabled := s.svc.AppInfo()
-	writeJSON(w, http.StatusOK, AppInfo{Version: &v, AiEnabled: &aiEnabled})
+	v, aiEnabled, readOnly := s.svc.AppInfo()
+	writeJSON(w, http.StatusOK, AppInfo{Version: &v, AiEnabled: &aiEnabled, ReadOnly: &readOnly})
+}
+
+func toConnectionInfo(info *client.ConnectionInfo) ConnectionInfo {
+	return ConnectionInfo{
+		Id: &info.ID, Host: info.Host, Port: info.Port, User: info.User,
+		Database: info.Database, Version: info.Version, ReadOnly: &info.ReadOnly,
+		Error: nonEmpty(info.Error),
+	}
 }
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+func (s *Server) ListSchemaSnapshots(w http.ResponseWriter, r *http.Request) {
+	snaps, err := s.svc.ListSchemaSnapshots()
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]SchemaSnapshot, len(snaps))
+	for i, snap := range snaps {
+		result[i] = toSchemaSnapshot(snap)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) CreateSchemaSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req SchemaSnapshotInput
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &req); err != nil {
+			writeErrMsg(w, http.StatusBadRequest, "invalid request")
+			return
+		}
+	}
+	label := ""
+	if req.Label != nil {
+		label = *req.Label
+	}
+	snap, err := s.svc.TakeSchemaSnapshot(r.Context(), connID(r), label)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toSchemaSnapshot(*snap))
+}
+
+func (s *Server) DeleteSchemaSnapshot(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.svc.DeleteSchemaSnapshot(id); err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	success := true
+	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+}
+
+func (s *Server) GetSchemaDrift(w http.ResponseWriter, r *http.Request, id int, params GetSchemaDriftParams) {
+	drift, err := s.svc.CompareSchemaSnapshots(r.Context(), connID(r), id, params.To)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := SchemaDrift{
+		From:    toSchemaSnapshot(*drift.From),
+		Schemas: make([]SchemaDiff, len(drift.Diffs)),
+	}
+	if drift.To != nil {
+		to := toSchemaSnapshot(*drift.To)
+		result.To = &to
+	}
+	for i, d := range drift.Diffs {
+		result.Schemas[i] = toSchemaDiff(d)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func toSchemaSnapshot(snap repository.SchemaSnapshot) SchemaSnapshot {
+	result := SchemaSnapshot{
+		Id: snap.ID, Connection: snap.Connection, Database: snap.Database,
+		Label: snap.Label, Schemas: snap.Schemas, Changes: snap.Changes, CreatedAt: snap.CreatedAt,
+	}
+	if result.Schemas == nil {
+		result.Schemas = []string{}
+	}
+	if snap.PreviousID != 0 {
+		result.PreviousId = &snap.PreviousID
+	}
+	return result
+}
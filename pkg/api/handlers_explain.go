@@ -0,0 +1,192 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/client"
+	"github.com/macleodmac/pglet/pkg/repository"
+	"github.com/macleodmac/pglet/pkg/service"
+)
+
+func (s *Server) ExplainPlan(w http.ResponseWriter, r *http.Request) {
+	var req ExplainRequest
+	if err := readJSON(r, &req); err != nil {
+		writeErrMsg(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	opts := service.ExplainOptions{
+		Analyze:     req.Analyze != nil && *req.Analyze,
+		Params:      toParamValues(req.Params),
+		KeepChanges: req.Rollback != nil && !*req.Rollback,
+	}
+	if req.ConfirmToken != nil {
+		opts.ConfirmToken = *req.ConfirmToken
+	}
+	plan, id, err := s.svc.ExplainPlan(r.Context(), connID(r), req.TabId, req.Query, opts)
+	if err != nil {
+		var cr *service.ConfirmationRequired
+		if errors.As(err, &cr) {
+			writeJSON(w, http.StatusConflict, toConfirmation(cr))
+			return
+		}
+		var qe *service.QueryError
+		if errors.As(err, &qe) {
+			errMsg := qe.Error()
+			writeJSON(w, http.StatusOK, ExplainResult{
+				Analyzed: opts.Analyze, Settings: map[string]string{}, Slowest: []int{},
+				Error: &errMsg, ErrorDetail: toErrorDetail(qe.Err, 0),
+			})
+			return
+		}
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := toExplainResult(plan)
+	if id != 0 {
+		result.Id = &id
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func toExplainResult(p *client.Plan) ExplainResult {
+	root := toPlanNode(p.Root)
+	return ExplainResult{
+		Plan: &root, Analyzed: p.Analyzed, RolledBack: p.RolledBack,
+		PlanningMs: p.PlanningMs, ExecutionMs: p.ExecutionMs,
+		Settings: p.Settings, Slowest: p.Slowest,
+	}
+}
+
+func toPlanNode(n *client.PlanNode) PlanNode {
+	node := PlanNode{
+		Id: n.ID, NodeType: n.NodeType, Conditions: n.Conditions,
+		ParentRelationship: nonEmpty(n.ParentRelationship), Relation: nonEmpty(n.Relation),
+		Schema: nonEmpty(n.Schema), Alias: nonEmpty(n.Alias), Index: nonEmpty(n.Index),
+		JoinType: nonEmpty(n.JoinType), Strategy: nonEmpty(n.Strategy),
+		StartupCost: n.StartupCost, TotalCost: n.TotalCost,
+		PlanRows: n.PlanRows, PlanWidth: n.PlanWidth,
+		ActualStartupMs: n.ActualStartupMs, ActualTotalMs: n.ActualTotalMs,
+		ActualRows: n.ActualRows, ActualLoops: n.ActualLoops,
+		RowsRemovedByFilter: n.RowsRemovedByFilter, IoReadMs: n.IOReadMs, IoWriteMs: n.IOWriteMs,
+		InclusiveMs: n.InclusiveMs, ExclusiveMs: n.ExclusiveMs,
+		RowsRatio: n.RowsRatio, Misestimated: n.Misestimated,
+		Extra: n.Extra, Children: make([]PlanNode, len(n.Children)),
+	}
+	if len(n.Output) > 0 {
+		node.Output = &n.Output
+	}
+	if b := n.Buffers; b != nil {
+		node.Buffers = &PlanBuffers{
+			SharedHit: b.SharedHit, SharedRead: b.SharedRead,
+			SharedDirtied: b.SharedDirtied, SharedWritten: b.SharedWritten,
+			LocalHit: b.LocalHit, LocalRead: b.LocalRead,
+			LocalDirtied: b.LocalDirtied, LocalWritten: b.LocalWritten,
+			TempRead: b.TempRead, TempWritten: b.TempWritten,
+		}
+	}
+	for i, c := range n.Children {
+		node.Children[i] = toPlanNode(c)
+	}
+	return node
+}
+
+func (s *Server) ListPlans(w http.ResponseWriter, r *http.Request, params ListPlansParams) {
+	plans, err := s.svc.ListPlans(params.Pinned != nil && *params.Pinned)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]StoredPlan, len(plans))
+	for i, p := range plans {
+		result[i] = toStoredPlan(p)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) GetPlan(w http.ResponseWriter, r *http.Request, id int) {
+	sp, plan, err := s.svc.GetPlan(id)
+	if err != nil {
+		writeErr(w, planStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, StoredPlanDetail{
+		Id: sp.ID, Sql: sp.SQL, Connection: sp.Connection, Database: sp.Database,
+		Analyzed: sp.Analyzed, Pinned: sp.Pinned, Label: sp.Label, CreatedAt: sp.CreatedAt,
+		Result: toExplainResult(plan),
+	})
+}
+
+func (s *Server) UpdatePlan(w http.ResponseWriter, r *http.Request, id int) {
+	var req StoredPlanUpdate
+	if err := readJSON(r, &req); err != nil {
+		writeErrMsg(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	label := ""
+	if req.Label != nil {
+		label = *req.Label
+	}
+	sp, err := s.svc.PinPlan(id, req.Pinned, label)
+	if err != nil {
+		writeErr(w, planStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toStoredPlan(*sp))
+}
+
+func (s *Server) DeletePlan(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.svc.DeletePlan(id); err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	success := true
+	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+}
+
+func (s *Server) ComparePlans(w http.ResponseWriter, r *http.Request) {
+	var req PlanCompareRequest
+	if err := readJSON(r, &req); err != nil {
+		writeErrMsg(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	diff, err := s.svc.ComparePlans(req.BeforeId, req.AfterId)
+	if err != nil {
+		writeErr(w, planStatus(err), err)
+		return
+	}
+	result := PlanComparison{
+		Before: toExplainResult(diff.Before), After: toExplainResult(diff.After),
+		Nodes:          make([]PlanNodeDiff, len(diff.Nodes)),
+		TotalCostDelta: diff.TotalCostDelta, ExecutionMsDelta: diff.ExecutionMsDelta,
+	}
+	for i, n := range diff.Nodes {
+		if n.Details == nil {
+			n.Details = []string{}
+		}
+		result.Nodes[i] = PlanNodeDiff{
+			BeforeId: n.BeforeID, AfterId: n.AfterID,
+			Change: PlanNodeDiffChange(n.Change), Details: n.Details,
+			ScanChanged: n.ScanChanged, JoinChanged: n.JoinChanged,
+			CostDelta: n.CostDelta, TimeDeltaMs: n.TimeDelta,
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// planStatus maps a stored-plan error to an HTTP status: 404 for a plan that
+// does not exist, 500 for anything else.
+func planStatus(err error) int {
+	if errors.Is(err, repository.ErrPlanNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func toStoredPlan(p repository.StoredPlan) StoredPlan {
+	return StoredPlan{
+		Id: p.ID, Sql: p.SQL, Connection: p.Connection, Database: p.Database,
+		Analyzed: p.Analyzed, Pinned: p.Pinned, Label: p.Label, CreatedAt: p.CreatedAt,
+	}
+}
@@ -1,10 +1,15 @@
 package api
 
 import (
-	"encoding/csv"
-	"encoding/json"
-	"fmt"
+	"compress/gzip"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+
+	"github.com/macleodmac/pglet/pkg/export"
+	"github.com/macleodmac/pglet/pkg/service"
 )
 
 func (s *Server) ExportQuery(w http.ResponseWriter, r *http.Request) {
@@ -14,55 +19,87 @@ func (s *Server) ExportQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.svc.ExportQuery(r.Context(), req.Query)
-	if err != nil {
-		writeErr(w, svcStatus(err), err)
+	format, ok := export.Lookup(string(req.Format))
+	if !ok {
+		writeErrMsg(w, http.StatusBadRequest, "unsupported export format")
 		return
 	}
+	opts := export.Options{}
+	if req.Table != nil {
+		opts.Table = *req.Table
+	}
 
-	switch req.Format {
-	case Csv:
-		w.Header().Set("Content-Type", "text/csv")
-		w.Header().Set("Content-Disposition", "attachment; filename=export.csv")
-		cw := csv.NewWriter(w)
-
-		if err := cw.Write(result.Columns); err != nil {
+	// The request context is cancelled when the client disconnects, which
+	// aborts the query and stops the stream.
+	confirmToken := ""
+	if req.ConfirmToken != nil {
+		confirmToken = *req.ConfirmToken
+	}
+	rows, err := s.svc.ExportQuery(r.Context(), connID(r), req.Query, confirmToken)
+	if err != nil {
+		var cr *service.ConfirmationRequired
+		if errors.As(err, &cr) {
+			writeJSON(w, http.StatusConflict, toConfirmation(cr))
 			return
 		}
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	defer rows.Close()
 
-		for _, row := range result.Rows {
-			record := make([]string, len(row))
-			for i, v := range row {
-				if v == nil {
-					record[i] = ""
-				} else {
-					record[i] = fmt.Sprintf("%v", v)
-				}
-			}
-			if err := cw.Write(record); err != nil {
-				return
-			}
-		}
-		cw.Flush()
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+format.Filename())
+	w.Header().Add("Vary", "Accept-Encoding")
 
-	case Json:
-		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Content-Disposition", "attachment; filename=export.json")
+	var out io.Writer = w
+	var gz *gzip.Writer
+	if acceptsGzip(r) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz = gzip.NewWriter(w)
+		out = gz
+	}
 
-		records := make([]map[string]interface{}, len(result.Rows))
-		for i, row := range result.Rows {
-			record := make(map[string]interface{})
-			for j, v := range row {
-				if j < len(result.Columns) {
-					record[result.Columns[j]] = v
-				}
-			}
-			records[i] = record
+	// A write error means the client went away, so there is no one left to
+	// tell; the gzip stream is deliberately left unfinished.
+	ew := format.New(out, opts)
+	if err := ew.WriteHeader(rows.Columns, rows.ColumnTypes); err != nil {
+		return
+	}
+	for rows.Next() {
+		row, err := rows.Values()
+		if err != nil {
+			abortExport(err)
+		}
+		if err := ew.WriteRow(row); err != nil {
+			return
 		}
+	}
+	if err := rows.Err(); err != nil {
+		abortExport(err)
+	}
+	if err := ew.Close(); err != nil {
+		return
+	}
+	if gz != nil {
+		gz.Close()
+	}
+}
 
-		json.NewEncoder(w).Encode(records)
+// abortExport ends an export that failed partway through. The headers are
+// already sent, so instead of finishing the body — which would leave the
+// client with a truncated file that looks complete — the connection is
+// reset.
+func abortExport(err error) {
+	slog.Warn("export aborted", "err", err)
+	panic(http.ErrAbortHandler)
+}
 
-	default:
-		writeErrMsg(w, http.StatusBadRequest, "unsupported format, use csv or json")
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(enc), ";")
+		if name == "gzip" {
+			return true
+		}
 	}
+	return false
 }
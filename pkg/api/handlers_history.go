@@ -20,7 +20,7 @@ func (s *Server) ListHistory(w http.ResponseWriter, r *http.Request, params List
 	result := make([]HistoryEntry, len(entries))
 	for i, e := range entries {
 		result[i] = HistoryEntry{
-			Id: e.ID, Sql: e.SQL, Database: e.Database,
+			Id: e.ID, Sql: e.SQL, Connection: e.Connection, Database: e.Database,
 			DurationMs: e.DurationMs, RowCount: e.RowCount,
 			Error: e.Error, ExecutedAt: e.ExecutedAt,
 		}
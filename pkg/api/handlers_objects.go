@@ -0,0 +1,180 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/client"
+)
+
+func (s *Server) GetTableTriggers(w http.ResponseWriter, r *http.Request, table string) {
+	triggers, err := s.svc.TableTriggers(r.Context(), connID(r), table)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := make([]Trigger, len(triggers))
+	for i, t := range triggers {
+		events := make([]TriggerEvents, len(t.Events))
+		for j, e := range t.Events {
+			events[j] = TriggerEvents(e)
+		}
+		result[i] = Trigger{
+			Name: t.Name, Timing: TriggerTiming(t.Timing), Events: events,
+			Level: TriggerLevel(t.Level), Function: t.Function,
+			Enabled: TriggerEnabled(t.Enabled), Definition: t.Definition,
+			Comment: nonEmpty(t.Comment),
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) GetTablePolicies(w http.ResponseWriter, r *http.Request, table string) {
+	policies, enabled, forced, err := s.svc.TablePolicies(r.Context(), connID(r), table)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := TablePolicies{Enabled: enabled, Forced: forced, Policies: make([]Policy, len(policies))}
+	for i, p := range policies {
+		result.Policies[i] = Policy{
+			Name: p.Name, Command: PolicyCommand(p.Command), Permissive: p.Permissive,
+			Roles: p.Roles, Using: nonEmpty(p.Using), WithCheck: nonEmpty(p.WithCheck),
+		}
+		if result.Policies[i].Roles == nil {
+			result.Policies[i].Roles = []string{}
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) GetPartitionTree(w http.ResponseWriter, r *http.Request, table string) {
+	tree, err := s.svc.PartitionTree(r.Context(), connID(r), table)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toPartitionNode(tree))
+}
+
+func toPartitionNode(n *client.PartitionNode) PartitionNode {
+	node := PartitionNode{
+		Schema: n.Schema, Name: n.Name, Bound: nonEmpty(n.Bound), Key: nonEmpty(n.Key),
+		Parent: nonEmpty(n.Parent), Children: make([]PartitionNode, len(n.Children)),
+	}
+	for i, child := range n.Children {
+		node.Children[i] = toPartitionNode(child)
+	}
+	return node
+}
+
+func (s *Server) GetExtension(w http.ResponseWriter, r *http.Request, name string) {
+	e, err := s.svc.Extension(r.Context(), connID(r), name)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Extension{
+		Name: e.Name, Schema: e.Schema, Version: e.Version, DefaultVersion: e.DefaultVersion,
+		Relocatable: e.Relocatable, Comment: nonEmpty(e.Comment),
+	})
+}
+
+func (s *Server) GetDomain(w http.ResponseWriter, r *http.Request, domain string) {
+	schema, name := splitQualifiedName(domain)
+	d, err := s.svc.Domain(r.Context(), connID(r), schema, name)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	constraints := make([]TableConstraint, len(d.Constraints))
+	for i, con := range d.Constraints {
+		constraints[i] = TableConstraint{Name: con.Name, Type: con.Type, Definition: con.Definition}
+	}
+	writeJSON(w, http.StatusOK, Domain{
+		Name: d.Name, Schema: d.Schema, BaseType: d.BaseType, NotNull: d.NotNull,
+		Default: nonEmpty(d.Default), Collation: nonEmpty(d.Collation),
+		Constraints: constraints, Comment: nonEmpty(d.Comment),
+	})
+}
+
+func (s *Server) GetTypeDetail(w http.ResponseWriter, r *http.Request, pType string) {
+	schema, name := splitQualifiedName(pType)
+	td, err := s.svc.TypeDetail(r.Context(), connID(r), schema, name)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := TypeDetail{
+		Name: td.Name, Schema: td.Schema, Kind: TypeDetailKind(td.Kind),
+		Subtype: nonEmpty(td.Subtype), Multirange: nonEmpty(td.Multirange),
+		Comment: nonEmpty(td.Comment),
+	}
+	switch td.Kind {
+	case "enum":
+		labels := td.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		result.Labels = &labels
+	case "composite":
+		attrs := make([]TypeAttribute, len(td.Attributes))
+		for i, a := range td.Attributes {
+			attrs[i] = TypeAttribute{Name: a.Name, Type: a.Type}
+		}
+		result.Attributes = &attrs
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) GetForeignTable(w http.ResponseWriter, r *http.Request, table string) {
+	ft, err := s.svc.ForeignTable(r.Context(), connID(r), table)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	options := ft.Options
+	if options == nil {
+		options = []string{}
+	}
+	writeJSON(w, http.StatusOK, ForeignTable{
+		Name: ft.Name, Schema: ft.Schema, Server: ft.Server, Wrapper: ft.Wrapper,
+		Options: options, Comment: nonEmpty(ft.Comment),
+	})
+}
+
+func (s *Server) GetPublication(w http.ResponseWriter, r *http.Request, name string) {
+	p, err := s.svc.Publication(r.Context(), connID(r), name)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	tables := p.Tables
+	if tables == nil {
+		tables = []string{}
+	}
+	writeJSON(w, http.StatusOK, Publication{
+		Name: p.Name, Owner: p.Owner, AllTables: p.AllTables,
+		Insert: p.Insert, Update: p.Update, Delete: p.Delete, Truncate: p.Truncate,
+		Tables: tables,
+	})
+}
+
+func (s *Server) GetSubscription(w http.ResponseWriter, r *http.Request, name string) {
+	sub, err := s.svc.Subscription(r.Context(), connID(r), name)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	publications := sub.Publications
+	if publications == nil {
+		publications = []string{}
+	}
+	tables := make([]SubscriptionTable, len(sub.Tables))
+	for i, t := range sub.Tables {
+		tables[i] = SubscriptionTable{Table: t.Table, State: SubscriptionTableState(t.State)}
+	}
+	writeJSON(w, http.StatusOK, Subscription{
+		Name: sub.Name, Owner: sub.Owner, Enabled: sub.Enabled, Publications: publications,
+		SlotName: nonEmpty(sub.SlotName), Tables: tables,
+	})
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+func (s *Server) ListConnectionProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := s.svc.ListConnectionProfiles()
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := make([]ConnectionProfile, len(profiles))
+	for i, p := range profiles {
+		result[i] = repoToConnectionProfile(p)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) GetConnectionProfile(w http.ResponseWriter, r *http.Request, id string) {
+	p, err := s.svc.GetConnectionProfile(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			writeErrMsg(w, http.StatusNotFound, "connection profile not found")
+			return
+		}
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, repoToConnectionProfile(*p))
+}
+
+func (s *Server) CreateConnectionProfile(w http.ResponseWriter, r *http.Request) {
+	var req ConnectionProfileInput
+	if err := readJSON(r, &req); err != nil {
+		writeErrMsg(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	created, err := s.svc.CreateConnectionProfile(inputToConnectionProfile(req))
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, repoToConnectionProfile(*created))
+}
+
+func (s *Server) UpdateConnectionProfile(w http.ResponseWriter, r *http.Request, id string) {
+	var req ConnectionProfileInput
+	if err := readJSON(r, &req); err != nil {
+		writeErrMsg(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	p := inputToConnectionProfile(req)
+	p.ID = id
+
+	if err := s.svc.UpdateConnectionProfile(p, req.Password != nil); err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			writeErrMsg(w, http.StatusNotFound, "connection profile not found")
+			return
+		}
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	success := true
+	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+}
+
+func (s *Server) DeleteConnectionProfile(w http.ResponseWriter, r *http.Request, id string) {
+	if err := s.svc.DeleteConnectionProfile(id); err != nil {
+		if errors.Is(err, repository.ErrProfileNotFound) {
+			writeErrMsg(w, http.StatusNotFound, "connection profile not found")
+			return
+		}
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	success := true
+	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+}
+
+func inputToConnectionProfile(req ConnectionProfileInput) repository.ConnectionProfile {
+	p := repository.ConnectionProfile{Label: req.Label, Host: req.Host, Port: 5432, SSLMode: "disable"}
+	if req.Color != nil {
+		p.Color = *req.Color
+	}
+	if req.Port != nil {
+		p.Port = *req.Port
+	}
+	if req.User != nil {
+		p.User = *req.User
+	}
+	if req.Database != nil {
+		p.Database = *req.Database
+	}
+	if req.Sslmode != nil {
+		p.SSLMode = *req.Sslmode
+	}
+	if req.ReadOnly != nil {
+		p.ReadOnly = *req.ReadOnly
+	}
+	if req.Password != nil {
+		p.Password = *req.Password
+	}
+	return p
+}
+
+func repoToConnectionProfile(p repository.ConnectionProfile) ConnectionProfile {
+	return ConnectionProfile{
+		Id: p.ID, Label: p.Label, Color: p.Color,
+		Host: p.Host, Port: p.Port, User: p.User, Database: p.Database,
+		Sslmode: p.SSLMode, ReadOnly: p.ReadOnly, HasPassword: p.HasPassword(),
+		CreatedAt: p.CreatedAt, UpdatedAt: p.UpdatedAt,
+	}
+}
@@ -3,7 +3,9 @@ package api
 import (
 	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/macleodmac/pglet/pkg/client"
 	"github.com/macleodmac/pglet/pkg/service"
 )
 
@@ -14,28 +16,166 @@ func (s *Server) RunQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.svc.RunQuery(r.Context(), req.TabId, req.Query)
+	if strings.Contains(r.Header.Get("Accept"), ndjsonContentType) {
+		s.streamQuery(w, r, req)
+		return
+	}
+
+	result, err := s.svc.RunQuery(r.Context(), connID(r), req.TabId, req.Query, queryOptions(req))
 	if err != nil {
+		var cr *service.ConfirmationRequired
+		if errors.As(err, &cr) {
+			writeJSON(w, http.StatusConflict, toConfirmation(cr))
+			return
+		}
 		var qe *service.QueryError
 		if errors.As(err, &qe) {
 			// Query execution error — return inside 200 OK per API contract
-			errMsg := qe.Error()
-			writeJSON(w, http.StatusOK, QueryResult{
-				Columns: []string{}, ColumnTypes: []string{},
-				Rows: [][]CellValue{}, RowCount: 0, DurationMs: 0,
-				Error: &errMsg,
-			})
+			writeJSON(w, http.StatusOK, queryErrorResult(qe))
 			return
 		}
 		writeErr(w, svcStatus(err), err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, QueryResult{
-		Columns: result.Columns, ColumnTypes: result.ColumnTypes,
-		Rows: toNullableRows(result.Rows), RowCount: result.RowCount,
-		DurationMs: result.DurationMs,
-	})
+	writeJSON(w, http.StatusOK, toScriptResult(result, req.Typed != nil && *req.Typed))
+}
+
+// toScriptResult converts per-statement results to the API shape, with rows
+// as typed values if typed is set. The top-level fields repeat the last
+// successful statement, and the first error, so single-statement clients see
+// what they always have.
+func toScriptResult(results []service.StatementResult, typed bool) QueryResult {
+	out := QueryResult{
+		Columns: []string{}, ColumnTypes: []string{}, Rows: [][]CellValue{},
+		Results: &[]StatementResult{},
+	}
+	for _, sr := range results {
+		r := StatementResult{
+			Statement: sr.Statement, Columns: []string{}, ColumnTypes: []string{},
+			Rows: [][]CellValue{},
+		}
+		switch {
+		case sr.Skipped:
+			skipped := true
+			r.Skipped = &skipped
+		case sr.Err != nil:
+			msg := sr.Err.Error()
+			r.Error = &msg
+			if sr.ErrorPosition > 0 {
+				pos := sr.ErrorPosition
+				r.ErrorPosition = &pos
+			}
+			r.ErrorDetail = toErrorDetail(sr.Err, sr.ErrorPosition)
+			if out.Error == nil {
+				out.Error, out.ErrorPosition = r.Error, r.ErrorPosition
+				out.ErrorDetail = r.ErrorDetail
+			}
+		default:
+			res := sr.Result
+			r.Columns, r.ColumnTypes = res.Columns, res.ColumnTypes
+			r.Rows, r.Values = toResultRows(res.Rows, typed)
+			r.RowCount = res.RowCount
+			r.DurationMs, r.Truncated = res.DurationMs, &res.Truncated
+			r.RowsAffected, r.CommandTag = commandTag(res.RowsAffected, res.CommandTag)
+			out.Columns, out.ColumnTypes = r.Columns, r.ColumnTypes
+			out.Rows, out.Values, out.RowCount = r.Rows, r.Values, r.RowCount
+			out.Truncated = r.Truncated
+			out.RowsAffected, out.CommandTag = r.RowsAffected, r.CommandTag
+		}
+		out.DurationMs += r.DurationMs
+		*out.Results = append(*out.Results, r)
+	}
+	return out
+}
+
+// queryErrorResult is the empty result that carries a query error in a
+// 200 response.
+func queryErrorResult(qe *service.QueryError) QueryResult {
+	errMsg := qe.Error()
+	return QueryResult{
+		Columns: []string{}, ColumnTypes: []string{},
+		Rows: [][]CellValue{}, RowCount: 0, DurationMs: 0,
+		Error: &errMsg, ErrorDetail: toErrorDetail(qe.Err, 0),
+	}
+}
+
+// commandTag returns the optional API fields for a statement's command tag,
+// both nil if the server sent none.
+func commandTag(rowsAffected int64, tag string) (*int64, *string) {
+	if tag == "" {
+		return nil, nil
+	}
+	return &rowsAffected, &tag
+}
+
+// toErrorDetail converts a statement error to the API shape, with the fields
+// the server reported. position is the error's position in the whole script,
+// which replaces the statement-relative one from the server.
+func toErrorDetail(err error, position int) *QueryErrorDetail {
+	d := client.ServerError(err)
+	if d == nil {
+		return &QueryErrorDetail{Message: err.Error()}
+	}
+	detail := &QueryErrorDetail{
+		Message: d.Message, Severity: nonEmpty(d.Severity),
+		Code: nonEmpty(d.Code), Condition: nonEmpty(d.Condition),
+		Detail: nonEmpty(d.Detail), Hint: nonEmpty(d.Hint),
+		InternalQuery: nonEmpty(d.InternalQuery), Where: nonEmpty(d.Where),
+		Schema: nonEmpty(d.Schema), Table: nonEmpty(d.Table),
+		Column: nonEmpty(d.Column), DataType: nonEmpty(d.DataType),
+		Constraint: nonEmpty(d.Constraint),
+	}
+	if position > 0 {
+		detail.Position = &position
+	}
+	if d.InternalPosition > 0 {
+		detail.InternalPosition = &d.InternalPosition
+	}
+	return detail
+}
+
+func queryOptions(req QueryRequest) service.QueryOptions {
+	var opts service.QueryOptions
+	if req.MaxRows != nil {
+		opts.MaxRows = *req.MaxRows
+	}
+	if req.ConfirmToken != nil {
+		opts.ConfirmToken = *req.ConfirmToken
+	}
+	opts.ContinueOnError = req.OnError != nil && *req.OnError == Continue
+	opts.Params = toParamValues(req.Params)
+	return opts
+}
+
+func toParamValues(params *map[string]ParamValue) map[string]service.ParamValue {
+	if params == nil {
+		return nil
+	}
+	result := make(map[string]service.ParamValue, len(*params))
+	for name, p := range *params {
+		pv := service.ParamValue{Value: p.Value}
+		if p.Type != nil {
+			pv.Type = *p.Type
+		}
+		result[name] = pv
+	}
+	return result
+}
+
+func toConfirmation(cr *service.ConfirmationRequired) ConfirmationRequired {
+	stmts := make([]DestructiveStatement, len(cr.Statements))
+	for i, st := range cr.Statements {
+		objects := st.Objects
+		if objects == nil {
+			objects = []string{}
+		}
+		stmts[i] = DestructiveStatement{
+			Statement: st.Statement, Reason: st.Reason,
+			Objects: objects, EstimatedRows: st.EstimatedRows,
+		}
+	}
+	return ConfirmationRequired{Token: cr.Token, ExpiresAt: cr.ExpiresAt, Statements: stmts}
 }
 
 func (s *Server) ExplainQuery(w http.ResponseWriter, r *http.Request) {
@@ -45,8 +185,13 @@ func (s *Server) ExplainQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := s.svc.ExplainQuery(r.Context(), req.Query)
+	result, err := s.svc.ExplainQuery(r.Context(), connID(r), req.Query)
 	if err != nil {
+		var qe *service.QueryError
+		if errors.As(err, &qe) {
+			writeJSON(w, http.StatusOK, queryErrorResult(qe))
+			return
+		}
 		writeErr(w, svcStatus(err), err)
 		return
 	}
@@ -54,18 +199,37 @@ func (s *Server) ExplainQuery(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) AnalyzeQuery(w http.ResponseWriter, r *http.Request) {
-	var req QueryRequest
+	var req AnalyzeRequest
 	if err := readJSON(r, &req); err != nil {
 		writeErrMsg(w, http.StatusBadRequest, "invalid request")
 		return
 	}
 
-	result, err := s.svc.AnalyzeQuery(r.Context(), req.Query)
+	keepChanges := req.Rollback != nil && !*req.Rollback
+	confirmToken := ""
+	if req.ConfirmToken != nil {
+		confirmToken = *req.ConfirmToken
+	}
+	result, rolledBack, err := s.svc.AnalyzeQuery(r.Context(), connID(r), req.Query, keepChanges, confirmToken)
 	if err != nil {
+		var cr *service.ConfirmationRequired
+		if errors.As(err, &cr) {
+			writeJSON(w, http.StatusConflict, toConfirmation(cr))
+			return
+		}
+		var qe *service.QueryError
+		if errors.As(err, &qe) {
+			writeJSON(w, http.StatusOK, queryErrorResult(qe))
+			return
+		}
 		writeErr(w, svcStatus(err), err)
 		return
 	}
-	writeJSON(w, http.StatusOK, toQueryResult(result))
+	resp := toQueryResult(result)
+	if rolledBack {
+		resp.RolledBack = &rolledBack
+	}
+	writeJSON(w, http.StatusOK, resp)
 }
 
 func (s *Server) CancelQuery(w http.ResponseWriter, r *http.Request) {
@@ -75,7 +239,10 @@ func (s *Server) CancelQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.svc.CancelQuery(req.TabId)
-	success := true
-	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+	result := s.svc.CancelQuery(r.Context(), req.TabId)
+	resp := CancelResult{Success: true, Running: result.Running, BackendCancelled: result.BackendCancelled}
+	if result.PID != 0 {
+		resp.Pid = &result.PID
+	}
+	writeJSON(w, http.StatusOK, resp)
 }
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/macleodmac/pglet/pkg/client"
+)
+
+func (s *Server) GetRelationships(w http.ResponseWriter, r *http.Request, params GetRelationshipsParams) {
+	graph, err := s.relationshipGraph(r, params.Schema, params.Table, params.Hops)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := RelationshipGraph{
+		Tables:        make([]GraphTable, len(graph.Tables)),
+		Relationships: make([]Relationship, len(graph.Relationships)),
+	}
+	for i, t := range graph.Tables {
+		result.Tables[i] = GraphTable{Schema: t.Schema, Name: t.Name, Columns: make([]GraphColumn, len(t.Columns))}
+		for j, col := range t.Columns {
+			result.Tables[i].Columns[j] = GraphColumn{
+				Name: col.Name, Type: col.Type, Nullable: col.Nullable,
+				PrimaryKey: col.PrimaryKey, ForeignKey: col.ForeignKey,
+			}
+		}
+	}
+	for i, rel := range graph.Relationships {
+		result.Relationships[i] = Relationship{
+			Name: rel.Name, SourceSchema: rel.SourceSchema, SourceTable: rel.SourceTable,
+			SourceColumns: rel.SourceColumns, TargetSchema: rel.TargetSchema,
+			TargetTable: rel.TargetTable, TargetColumns: rel.TargetColumns,
+			OnDelete: rel.OnDelete, OnUpdate: rel.OnUpdate, Indexed: rel.Indexed, Unique: rel.Unique,
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) GetRelationshipDiagram(w http.ResponseWriter, r *http.Request, params GetRelationshipDiagramParams) {
+	graph, err := s.relationshipGraph(r, params.Schema, params.Table, params.Hops)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	diagram, err := graph.Diagram(client.DiagramFormat(params.Format))
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, RelationshipDiagram{Format: string(params.Format), Diagram: diagram})
+}
+
+// relationshipGraph resolves the graph query parameters shared by the
+// relationship endpoints. A schema-qualified table overrides schema.
+func (s *Server) relationshipGraph(r *http.Request, schemaParam, tableParam *string, hopsParam *int) (*client.RelationshipGraph, error) {
+	schema, table, hops := "public", "", 1
+	if schemaParam != nil && *schemaParam != "" {
+		schema = *schemaParam
+	}
+	if tableParam != nil && *tableParam != "" {
+		table = *tableParam
+		if strings.Contains(table, ".") {
+			schema, table = splitQualifiedName(table)
+		}
+	}
+	if hopsParam != nil {
+		hops = *hopsParam
+	}
+	return s.svc.RelationshipGraph(r.Context(), connID(r), schema, table, hops)
+}
@@ -49,6 +49,9 @@ func (s *Server) CreateSavedQuery(w http.ResponseWriter, r *http.Request) {
 	if req.Tags != nil {
 		sq.Tags = *req.Tags
 	}
+	if req.Params != nil {
+		sq.Params = fromQueryParameters(*req.Params)
+	}
 
 	created, err := s.svc.CreateSavedQuery(sq)
 	if err != nil {
@@ -74,6 +77,9 @@ func (s *Server) UpdateSavedQuery(w http.ResponseWriter, r *http.Request, id str
 	if req.Tags != nil {
 		sq.Tags = *req.Tags
 	}
+	if req.Params != nil {
+		sq.Params = fromQueryParameters(*req.Params)
+	}
 
 	if err := s.svc.UpdateSavedQuery(sq); err != nil {
 		writeErr(w, http.StatusInternalServerError, err)
@@ -93,9 +99,31 @@ func (s *Server) DeleteSavedQuery(w http.ResponseWriter, r *http.Request, id str
 }
 
 func repoToSavedQuery(q repository.SavedQuery) SavedQuery {
-	return SavedQuery{
+	sq := SavedQuery{
 		Id: q.ID, Title: q.Title, Description: q.Description,
 		Sql: q.SQL, Database: q.Database, Tags: q.Tags,
 		Shared: q.Shared, CreatedAt: q.CreatedAt, UpdatedAt: q.UpdatedAt,
 	}
+	if len(q.Params) > 0 {
+		params := make([]QueryParameter, len(q.Params))
+		for i, p := range q.Params {
+			params[i] = QueryParameter{Name: p.Name, Type: nonEmpty(p.Type), Default: nonEmpty(p.Default)}
+		}
+		sq.Params = &params
+	}
+	return sq
+}
+
+func fromQueryParameters(params []QueryParameter) []repository.QueryParam {
+	result := make([]repository.QueryParam, len(params))
+	for i, p := range params {
+		result[i].Name = p.Name
+		if p.Type != nil {
+			result[i].Type = *p.Type
+		}
+		if p.Default != nil {
+			result[i].Default = *p.Default
+		}
+	}
+	return result
 }
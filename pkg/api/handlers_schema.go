@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -11,7 +12,7 @@ import (
 )
 
 func (s *Server) ListSchemas(w http.ResponseWriter, r *http.Request) {
-	schemas, err := s.svc.Schemas()
+	schemas, err := s.svc.Schemas(connID(r))
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -20,7 +21,7 @@ func (s *Server) ListSchemas(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) ListObjects(w http.ResponseWriter, r *http.Request) {
-	objects, err := s.svc.Objects()
+	objects, err := s.svc.Objects(connID(r))
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -35,6 +36,14 @@ func (s *Server) ListObjects(w http.ResponseWriter, r *http.Request) {
 			Functions:         toSchemaObjects(group.Functions),
 			Sequences:         toSchemaObjects(group.Sequences),
 			Types:             toSchemaObjects(group.Types),
+			Domains:           toSchemaObjects(group.Domains),
+			ForeignTables:     toSchemaObjects(group.ForeignTables),
+			Partitions:        toSchemaObjects(group.Partitions),
+			Triggers:          toSchemaObjects(group.Triggers),
+			Policies:          toSchemaObjects(group.Policies),
+			Extensions:        toSchemaObjects(group.Extensions),
+			Publications:      toSchemaObjects(group.Publications),
+			Subscriptions:     toSchemaObjects(group.Subscriptions),
 		}
 	}
 	writeJSON(w, http.StatusOK, result)
@@ -43,7 +52,7 @@ func (s *Server) ListObjects(w http.ResponseWriter, r *http.Request) {
 func toSchemaObjects(objs []client.SchemaObject) []SchemaObject {
 	result := make([]SchemaObject, len(objs))
 	for i, o := range objs {
-		result[i] = SchemaObject{Name: o.Name, Schema: o.Schema, Type: o.Type}
+		result[i] = SchemaObject{Name: o.Name, Schema: o.Schema, Type: o.Type, Parent: nonEmpty(o.Parent)}
 		if o.Comment != "" {
 			result[i].Comment = &o.Comment
 		}
@@ -52,7 +61,7 @@ func toSchemaObjects(objs []client.SchemaObject) []SchemaObject {
 }
 
 func (s *Server) GetTableColumns(w http.ResponseWriter, r *http.Request, table string) {
-	cols, err := s.svc.TableColumns(table)
+	cols, err := s.svc.TableColumns(connID(r), table)
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -88,21 +97,22 @@ func (s *Server) GetTableRows(w http.ResponseWriter, r *http.Request, table stri
 		sortOrd = string(*params.SortOrder)
 	}
 
-	result, total, err := s.svc.TableRows(r.Context(), table, limit, offset, sortCol, sortOrd)
+	result, total, err := s.svc.TableRows(r.Context(), connID(r), table, limit, offset, sortCol, sortOrd)
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
 	}
 
+	rows, values := toResultRows(result.Rows, params.Typed != nil && *params.Typed)
 	writeJSON(w, http.StatusOK, TableRowsResult{
 		Columns: result.Columns, ColumnTypes: result.ColumnTypes,
-		Rows: toNullableRows(result.Rows), TotalCount: total,
+		Rows: rows, Values: values, TotalCount: total,
 		Page: offset / limit, PageSize: limit,
 	})
 }
 
 func (s *Server) GetTableInfo(w http.ResponseWriter, r *http.Request, table string) {
-	info, err := s.svc.TableInfo(table)
+	info, err := s.svc.TableInfo(connID(r), table)
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -114,7 +124,7 @@ func (s *Server) GetTableInfo(w http.ResponseWriter, r *http.Request, table stri
 }
 
 func (s *Server) GetTableIndexes(w http.ResponseWriter, r *http.Request, table string) {
-	indexes, err := s.svc.TableIndexes(table)
+	indexes, err := s.svc.TableIndexes(connID(r), table)
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -130,7 +140,7 @@ func (s *Server) GetTableIndexes(w http.ResponseWriter, r *http.Request, table s
 }
 
 func (s *Server) GetTableConstraints(w http.ResponseWriter, r *http.Request, table string) {
-	constraints, err := s.svc.TableConstraints(table)
+	constraints, err := s.svc.TableConstraints(connID(r), table)
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -146,7 +156,7 @@ func (s *Server) GetTableConstraints(w http.ResponseWriter, r *http.Request, tab
 
 func (s *Server) GetFunctionDefinition(w http.ResponseWriter, r *http.Request, function string) {
 	schema, name := splitQualifiedName(function)
-	fd, err := s.svc.FunctionDefinition(schema, name)
+	fd, err := s.svc.FunctionDefinition(connID(r), schema, name)
 	if err != nil {
 		if errors.Is(err, service.ErrNotConnected) {
 			writeErr(w, http.StatusBadRequest, err)
@@ -162,6 +172,16 @@ func (s *Server) GetFunctionDefinition(w http.ResponseWriter, r *http.Request, f
 	})
 }
 
+func (s *Server) GetObjectDDL(w http.ResponseWriter, r *http.Request, kind GetObjectDDLParamsKind, name string) {
+	schema, objName := splitQualifiedName(name)
+	ddl, err := s.svc.ObjectDDL(r.Context(), connID(r), string(kind), schema, objName)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ObjectDDL{Kind: string(kind), Schema: schema, Name: objName, Ddl: ddl})
+}
+
 func splitQualifiedName(name string) (string, string) {
 	parts := strings.SplitN(name, ".", 2)
 	if len(parts) == 2 {
@@ -170,8 +190,67 @@ func splitQualifiedName(name string) (string, string) {
 	return "public", parts[0]
 }
 
+func (s *Server) DiffSchemas(w http.ResponseWriter, r *http.Request) {
+	var req DiffSchemasJSONRequestBody
+	if err := readJSON(r, &req); err != nil {
+		writeErrMsg(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	schema := "public"
+	if req.Schema != nil && *req.Schema != "" {
+		schema = *req.Schema
+	}
+	diff, err := s.svc.DiffSchemas(r.Context(), schema, schemaSide(r, req.Source), schemaSide(r, req.Target))
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toSchemaDiff(diff))
+}
+
+// schemaSide resolves one side of a schema diff, defaulting to the
+// request's connection.
+func schemaSide(r *http.Request, side SchemaDiffSide) service.SchemaSide {
+	out := service.SchemaSide{ConnID: connID(r)}
+	if side.ConnectionId != nil {
+		out.ConnID = *side.ConnectionId
+	}
+	if side.Database != nil {
+		out.Database = *side.Database
+	}
+	return out
+}
+
+func toSchemaDiff(d *client.SchemaDiff) SchemaDiff {
+	changes := make([]SchemaChange, len(d.Changes))
+	for i, c := range d.Changes {
+		changes[i] = toSchemaChange(c)
+	}
+	return SchemaDiff{Schema: d.Schema, Changes: changes, Script: d.MigrationScript()}
+}
+
+func toSchemaChange(c client.SchemaChange) SchemaChange {
+	out := SchemaChange{
+		Object:  SchemaChangeObject(c.Object),
+		Table:   nonEmpty(c.Table),
+		Name:    c.Name,
+		Change:  SchemaChangeChange(c.Change),
+		Before:  nonEmpty(c.Before),
+		After:   nonEmpty(c.After),
+		Details: c.Details,
+		Sql:     c.SQL,
+	}
+	if out.Details == nil {
+		out.Details = []string{}
+	}
+	if out.Sql == nil {
+		out.Sql = []string{}
+	}
+	return out
+}
+
 func (s *Server) GetTablesStats(w http.ResponseWriter, r *http.Request) {
-	result, err := s.svc.TablesStats()
+	result, err := s.svc.TablesStats(connID(r))
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -179,8 +258,32 @@ func (s *Server) GetTablesStats(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, toQueryResult(result))
 }
 
+func (s *Server) GetAdvisor(w http.ResponseWriter, r *http.Request) {
+	report, err := s.svc.Advise(r.Context(), connID(r))
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := AdvisorReport{
+		Findings:   make([]AdvisorFinding, len(report.Findings)),
+		StatsReset: nonEmpty(report.StatsReset),
+	}
+	for i, f := range report.Findings {
+		columns := f.Columns
+		if columns == nil {
+			columns = []string{}
+		}
+		result.Findings[i] = AdvisorFinding{
+			Kind: AdvisorFindingKind(f.Kind), Schema: f.Schema, Table: f.Table,
+			Index: nonEmpty(f.Index), Columns: columns, Detail: f.Detail,
+			SizeBytes: f.SizeBytes, Size: f.Size, Ddl: f.DDL,
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (s *Server) GetActivity(w http.ResponseWriter, r *http.Request) {
-	activities, err := s.svc.Activity()
+	activities, err := s.svc.Activity(connID(r))
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -197,8 +300,63 @@ func (s *Server) GetActivity(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, result)
 }
 
+func (s *Server) GetLocks(w http.ResponseWriter, r *http.Request) {
+	roots, err := s.svc.BlockingTree(r.Context(), connID(r))
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := make([]BlockingSession, len(roots))
+	for i, root := range roots {
+		result[i] = toBlockingSession(root)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func toBlockingSession(bs *client.BlockingSession) BlockingSession {
+	result := BlockingSession{
+		Pid: bs.PID, Database: bs.Database, User: bs.User, Application: bs.Application,
+		State: bs.State, Query: bs.Query, WaitEventType: bs.WaitEventType, WaitEvent: bs.WaitEvent,
+		WaitingMs: bs.WaitingMs, XactMs: bs.XactMs, BlockedBy: bs.BlockedBy,
+		Locks: make([]Lock, len(bs.Locks)), Blocking: make([]BlockingSession, len(bs.Blocking)),
+	}
+	if bs.WaitingFor != nil {
+		l := toLock(*bs.WaitingFor)
+		result.WaitingFor = &l
+	}
+	for i, l := range bs.Locks {
+		result.Locks[i] = toLock(l)
+	}
+	for i, child := range bs.Blocking {
+		result.Blocking[i] = toBlockingSession(child)
+	}
+	return result
+}
+
+func toLock(l client.Lock) Lock {
+	return Lock{LockType: l.LockType, Mode: l.Mode, Relation: nonEmpty(l.Relation), Granted: l.Granted}
+}
+
+func (s *Server) CancelBackend(w http.ResponseWriter, r *http.Request, pid int) {
+	ok, err := s.svc.CancelBackend(r.Context(), connID(r), pid)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, BackendSignalResult{Pid: pid, Signalled: ok})
+}
+
+func (s *Server) TerminateBackend(w http.ResponseWriter, r *http.Request, pid int) {
+	ok, err := s.svc.TerminateBackend(r.Context(), connID(r), pid)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, BackendSignalResult{Pid: pid, Signalled: ok})
+}
+
 func (s *Server) GetServerSettings(w http.ResponseWriter, r *http.Request) {
-	result, err := s.svc.ServerSettings()
+	result, err := s.svc.ServerSettings(connID(r))
 	if err != nil {
 		writeErr(w, svcStatus(err), err)
 		return
@@ -207,33 +365,83 @@ func (s *Server) GetServerSettings(w http.ResponseWriter, r *http.Request) {
 }
 
 func toQueryResult(qr *client.QueryResult) QueryResult {
-	return QueryResult{
+	result := QueryResult{
 		Columns: qr.Columns, ColumnTypes: qr.ColumnTypes,
 		Rows: toNullableRows(qr.Rows), RowCount: qr.RowCount,
 		DurationMs: qr.DurationMs,
 	}
+	result.RowsAffected, result.CommandTag = commandTag(qr.RowsAffected, qr.CommandTag)
+	return result
 }
 
+// toResultRows converts client rows to the API's text rows or, if typed is
+// set, to typed values with the text rows left empty.
+func toResultRows(rows [][]any, typed bool) ([][]CellValue, *[][]TypedValue) {
+	if typed {
+		return [][]CellValue{}, toTypedRows(rows)
+	}
+	return toNullableRows(rows), nil
+}
+
+// toNullableRows converts client rows to text cells, rendering JSON values
+// and arrays as JSON.
 func toNullableRows(rows [][]any) [][]CellValue {
 	result := make([][]CellValue, len(rows))
 	for i, row := range rows {
 		result[i] = make([]CellValue, len(row))
 		for j, v := range row {
-			if v == nil {
-				result[i][j] = nil
-			} else {
-				str := fmt.Sprintf("%v", v)
-				result[i][j] = &str
-			}
+			result[i][j] = cellText(v)
 		}
 	}
 	return result
 }
 
+func cellText(v any) *string {
+	var str string
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case string:
+		str = t
+	case json.RawMessage:
+		str = string(t)
+	case []any:
+		b, _ := json.Marshal(t)
+		str = string(b)
+	default:
+		str = fmt.Sprintf("%v", t)
+	}
+	return &str
+}
+
+// toTypedRows converts client rows to typed API cells. Cells are already
+// decoded into JSON-friendly values by the client, so they pass through
+// unchanged.
+func toTypedRows(rows [][]any) *[][]TypedValue {
+	result := make([][]TypedValue, len(rows))
+	for i, row := range rows {
+		result[i] = make([]TypedValue, len(row))
+		copy(result[i], row)
+	}
+	return &result
+}
+
 // svcStatus maps service errors to HTTP status codes.
 func svcStatus(err error) int {
-	if errors.Is(err, service.ErrNotConnected) {
+	switch {
+	case errors.Is(err, service.ErrNotConnected), errors.Is(err, client.ErrNoStatStatements),
+		errors.Is(err, client.ErrUnknownKind), errors.Is(err, service.ErrSnapshotMismatch):
 		return http.StatusBadRequest
+	case errors.Is(err, client.ErrObjectNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrReadOnly):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrTxActive), errors.Is(err, service.ErrNoTx),
+		errors.Is(err, service.ErrTxConnection), errors.Is(err, service.ErrTxAborted),
+		errors.Is(err, service.ErrTxBusy):
+		return http.StatusConflict
+	case errors.Is(err, client.ErrTooManySessions):
+		return http.StatusServiceUnavailable
 	}
 	return http.StatusInternalServerError
 }
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/client"
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+func (s *Server) GetStatements(w http.ResponseWriter, r *http.Request, params GetStatementsParams) {
+	limit := 50
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	stats, err := s.svc.TopStatements(r.Context(), connID(r), statementOrder(params.OrderBy), limit)
+	if errors.Is(err, client.ErrNoStatStatements) {
+		writeJSON(w, http.StatusOK, StatementsReport{Available: false, Statements: []StatementStat{}})
+		return
+	}
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, StatementsReport{Available: true, Statements: toStatementStats(stats)})
+}
+
+func (s *Server) ResetStatements(w http.ResponseWriter, r *http.Request) {
+	if err := s.svc.ResetStatementStats(r.Context(), connID(r)); err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	success := true
+	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+}
+
+func (s *Server) ListStatementSnapshots(w http.ResponseWriter, r *http.Request) {
+	snaps, err := s.svc.ListStatementSnapshots()
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	result := make([]StatementSnapshot, len(snaps))
+	for i, snap := range snaps {
+		result[i] = toStatementSnapshot(snap)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) CreateStatementSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req StatementSnapshotInput
+	if r.ContentLength != 0 {
+		if err := readJSON(r, &req); err != nil {
+			writeErrMsg(w, http.StatusBadRequest, "invalid request")
+			return
+		}
+	}
+	label := ""
+	if req.Label != nil {
+		label = *req.Label
+	}
+	snap, err := s.svc.TakeStatementSnapshot(r.Context(), connID(r), label)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toStatementSnapshot(*snap))
+}
+
+func (s *Server) DeleteStatementSnapshot(w http.ResponseWriter, r *http.Request, id int) {
+	if err := s.svc.DeleteStatementSnapshot(id); err != nil {
+		writeErr(w, http.StatusInternalServerError, err)
+		return
+	}
+	success := true
+	writeJSON(w, http.StatusOK, SuccessResponse{Success: &success})
+}
+
+func (s *Server) GetStatementDelta(w http.ResponseWriter, r *http.Request, id int, params GetStatementDeltaParams) {
+	limit := 50
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	delta, err := s.svc.CompareStatementSnapshots(r.Context(), connID(r), id, params.To, statementOrder(params.OrderBy), limit)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	result := StatementDelta{
+		From:       toStatementSnapshot(*delta.From),
+		Statements: toStatementStats(delta.Statements),
+	}
+	if delta.To != nil {
+		to := toStatementSnapshot(*delta.To)
+		result.To = &to
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func statementOrder(order *StatementOrder) client.StatementOrder {
+	if order == nil {
+		return client.OrderTotalTime
+	}
+	return client.StatementOrder(*order)
+}
+
+func toStatementStats(stats []client.StatementStat) []StatementStat {
+	result := make([]StatementStat, len(stats))
+	for i, st := range stats {
+		result[i] = StatementStat{
+			Queryid: st.QueryID, User: st.User, Query: st.Query,
+			Calls: st.Calls, TotalMs: st.TotalMs, MeanMs: st.MeanMs(), Rows: st.Rows,
+			SharedBlksHit: st.SharedBlksHit, SharedBlksRead: st.SharedBlksRead,
+		}
+		if ratio, ok := st.HitRatio(); ok {
+			result[i].HitRatio = &ratio
+		}
+	}
+	return result
+}
+
+func toStatementSnapshot(snap repository.StatementSnapshot) StatementSnapshot {
+	return StatementSnapshot{
+		Id: snap.ID, Connection: snap.Connection, Database: snap.Database,
+		Label: snap.Label, CreatedAt: snap.CreatedAt,
+	}
+}
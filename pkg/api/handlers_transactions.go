@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/service"
+)
+
+func (s *Server) GetTransactionStatus(w http.ResponseWriter, r *http.Request, tabId string) {
+	info := s.svc.TransactionStatus(tabId)
+	writeJSON(w, http.StatusOK, toTransactionStatus(&info))
+}
+
+func (s *Server) BeginTransaction(w http.ResponseWriter, r *http.Request, tabId string) {
+	info, err := s.svc.BeginTransaction(r.Context(), connID(r), tabId)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTransactionStatus(info))
+}
+
+func (s *Server) CommitTransaction(w http.ResponseWriter, r *http.Request, tabId string) {
+	info, err := s.svc.CommitTransaction(r.Context(), tabId)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTransactionStatus(info))
+}
+
+func (s *Server) RollbackTransaction(w http.ResponseWriter, r *http.Request, tabId string) {
+	info, err := s.svc.RollbackTransaction(r.Context(), tabId)
+	if err != nil {
+		writeErr(w, svcStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTransactionStatus(info))
+}
+
+func toTransactionStatus(info *service.TxInfo) TransactionStatus {
+	ts := TransactionStatus{TabId: info.TabID, Status: TransactionStatusStatus(info.Status)}
+	if info.Connection != "" {
+		ts.Connection = &info.Connection
+	}
+	if !info.StartedAt.IsZero() {
+		ts.StartedAt = &info.StartedAt
+	}
+	if !info.ExpiresAt.IsZero() {
+		ts.ExpiresAt = &info.ExpiresAt
+	}
+	return ts
+}
@@ -5,6 +5,16 @@ import (
 	"net/http"
 )
 
+// connectionHeader names the request header that selects which open
+// connection a request targets.
+const connectionHeader = "X-Pglet-Connection"
+
+// connID returns the connection ID targeted by r. An empty ID means the
+// service's default connection.
+func connID(r *http.Request) string {
+	return r.Header.Get(connectionHeader)
+}
+
 // writeJSON encodes v as JSON and writes it to w with the given status code.
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -26,3 +36,12 @@ func writeErrMsg(w http.ResponseWriter, status int, msg string) {
 func readJSON(r *http.Request, v any) error {
 	return json.NewDecoder(r.Body).Decode(v)
 }
+
+// nonEmpty returns a pointer to s, or nil if s is empty, for optional string
+// fields.
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -14,15 +14,159 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for AdvisorFindingKind.
+const (
+	DuplicateIndex      AdvisorFindingKind = "duplicate_index"
+	SeqScans            AdvisorFindingKind = "seq_scans"
+	UnindexedForeignKey AdvisorFindingKind = "unindexed_foreign_key"
+	UnusedIndex         AdvisorFindingKind = "unused_index"
+)
+
 // Defines values for ExportRequestFormat.
 const (
-	Csv  ExportRequestFormat = "csv"
-	Json ExportRequestFormat = "json"
+	Csv      ExportRequestFormat = "csv"
+	Json     ExportRequestFormat = "json"
+	Markdown ExportRequestFormat = "markdown"
+	Ndjson   ExportRequestFormat = "ndjson"
+	Parquet  ExportRequestFormat = "parquet"
+	Sql      ExportRequestFormat = "sql"
+	Xlsx     ExportRequestFormat = "xlsx"
+)
+
+// Defines values for PlanNodeDiffChange.
+const (
+	PlanNodeDiffChangeAdded     PlanNodeDiffChange = "added"
+	PlanNodeDiffChangeChanged   PlanNodeDiffChange = "changed"
+	PlanNodeDiffChangeRemoved   PlanNodeDiffChange = "removed"
+	PlanNodeDiffChangeUnchanged PlanNodeDiffChange = "unchanged"
+)
+
+// Defines values for PolicyCommand.
+const (
+	PolicyCommandALL    PolicyCommand = "ALL"
+	PolicyCommandDELETE PolicyCommand = "DELETE"
+	PolicyCommandINSERT PolicyCommand = "INSERT"
+	PolicyCommandSELECT PolicyCommand = "SELECT"
+	PolicyCommandUPDATE PolicyCommand = "UPDATE"
+)
+
+// Defines values for QueryRequestOnError.
+const (
+	Continue QueryRequestOnError = "continue"
+	Stop     QueryRequestOnError = "stop"
+)
+
+// Defines values for QueryStreamMessageType.
+const (
+	QueryStreamMessageTypeColumns QueryStreamMessageType = "columns"
+	QueryStreamMessageTypeDone    QueryStreamMessageType = "done"
+	QueryStreamMessageTypeError   QueryStreamMessageType = "error"
+	QueryStreamMessageTypeRows    QueryStreamMessageType = "rows"
+	QueryStreamMessageTypeSkipped QueryStreamMessageType = "skipped"
+)
+
+// Defines values for SchemaChangeChange.
+const (
+	SchemaChangeChangeAdded   SchemaChangeChange = "added"
+	SchemaChangeChangeChanged SchemaChangeChange = "changed"
+	SchemaChangeChangeRemoved SchemaChangeChange = "removed"
+)
+
+// Defines values for SchemaChangeObject.
+const (
+	SchemaChangeObjectColumn           SchemaChangeObject = "column"
+	SchemaChangeObjectConstraint       SchemaChangeObject = "constraint"
+	SchemaChangeObjectEnum             SchemaChangeObject = "enum"
+	SchemaChangeObjectFunction         SchemaChangeObject = "function"
+	SchemaChangeObjectIndex            SchemaChangeObject = "index"
+	SchemaChangeObjectMaterializedView SchemaChangeObject = "materialized_view"
+	SchemaChangeObjectTable            SchemaChangeObject = "table"
+	SchemaChangeObjectView             SchemaChangeObject = "view"
+)
+
+// Defines values for StatementOrder.
+const (
+	StatementOrderCalls     StatementOrder = "calls"
+	StatementOrderHitRatio  StatementOrder = "hit_ratio"
+	StatementOrderMeanTime  StatementOrder = "mean_time"
+	StatementOrderRows      StatementOrder = "rows"
+	StatementOrderTotalTime StatementOrder = "total_time"
+)
+
+// Defines values for SubscriptionTableState.
+const (
+	Copied       SubscriptionTableState = "copied"
+	DataCopy     SubscriptionTableState = "data copy"
+	Init         SubscriptionTableState = "init"
+	Ready        SubscriptionTableState = "ready"
+	Synchronized SubscriptionTableState = "synchronized"
+)
+
+// Defines values for TransactionStatusStatus.
+const (
+	Failed        TransactionStatusStatus = "failed"
+	Idle          TransactionStatusStatus = "idle"
+	InTransaction TransactionStatusStatus = "in_transaction"
+)
+
+// Defines values for TriggerEnabled.
+const (
+	Always   TriggerEnabled = "always"
+	Disabled TriggerEnabled = "disabled"
+	Origin   TriggerEnabled = "origin"
+	Replica  TriggerEnabled = "replica"
+)
+
+// Defines values for TriggerEvents.
+const (
+	TriggerEventsDELETE   TriggerEvents = "DELETE"
+	TriggerEventsINSERT   TriggerEvents = "INSERT"
+	TriggerEventsTRUNCATE TriggerEvents = "TRUNCATE"
+	TriggerEventsUPDATE   TriggerEvents = "UPDATE"
+)
+
+// Defines values for TriggerLevel.
+const (
+	ROW       TriggerLevel = "ROW"
+	STATEMENT TriggerLevel = "STATEMENT"
+)
+
+// Defines values for TriggerTiming.
+const (
+	AFTER     TriggerTiming = "AFTER"
+	BEFORE    TriggerTiming = "BEFORE"
+	INSTEADOF TriggerTiming = "INSTEAD OF"
+)
+
+// Defines values for TypeDetailKind.
+const (
+	TypeDetailKindComposite TypeDetailKind = "composite"
+	TypeDetailKindEnum      TypeDetailKind = "enum"
+	TypeDetailKindRange     TypeDetailKind = "range"
+)
+
+// Defines values for GetObjectDDLParamsKind.
+const (
+	GetObjectDDLParamsKindEnum             GetObjectDDLParamsKind = "enum"
+	GetObjectDDLParamsKindFunction         GetObjectDDLParamsKind = "function"
+	GetObjectDDLParamsKindMaterializedView GetObjectDDLParamsKind = "materialized_view"
+	GetObjectDDLParamsKindSequence         GetObjectDDLParamsKind = "sequence"
+	GetObjectDDLParamsKindTable            GetObjectDDLParamsKind = "table"
+	GetObjectDDLParamsKindTrigger          GetObjectDDLParamsKind = "trigger"
+	GetObjectDDLParamsKindView             GetObjectDDLParamsKind = "view"
+)
+
+// Defines values for GetRelationshipDiagramParamsFormat.
+const (
+	Dot      GetRelationshipDiagramParamsFormat = "dot"
+	Mermaid  GetRelationshipDiagramParamsFormat = "mermaid"
+	Plantuml GetRelationshipDiagramParamsFormat = "plantuml"
 )
 
 // Defines values for GetTableRowsParamsSortOrder.
@@ -45,6 +189,37 @@ type Activity struct {
 	WaitEventType string `json:"wait_event_type"`
 }
 
+// AdvisorFinding defines model for AdvisorFinding.
+type AdvisorFinding struct {
+	// Columns The index or foreign key columns, where there are any
+	Columns []string `json:"columns"`
+
+	// Ddl Suggested fix; commented out where it needs adapting
+	Ddl    string `json:"ddl"`
+	Detail string `json:"detail"`
+
+	// Index The index concerned, for unused_index and duplicate_index
+	Index  *string            `json:"index,omitempty"`
+	Kind   AdvisorFindingKind `json:"kind"`
+	Schema string             `json:"schema"`
+	Size   string             `json:"size"`
+
+	// SizeBytes Size of the index for index findings, otherwise of the table
+	SizeBytes int64  `json:"size_bytes"`
+	Table     string `json:"table"`
+}
+
+// AdvisorFindingKind defines model for AdvisorFinding.Kind.
+type AdvisorFindingKind string
+
+// AdvisorReport defines model for AdvisorReport.
+type AdvisorReport struct {
+	Findings []AdvisorFinding `json:"findings"`
+
+	// StatsReset When the database's statistics were last reset; scan counts cover the time since
+	StatsReset *string `json:"stats_reset,omitempty"`
+}
+
 // AiGenerateRequest defines model for AiGenerateRequest.
 type AiGenerateRequest struct {
 	Database string       `json:"database"`
@@ -79,10 +254,60 @@ type AiTabNameResponse struct {
 	Name string `json:"name"`
 }
 
+// AnalyzeRequest defines model for AnalyzeRequest.
+type AnalyzeRequest struct {
+	// ConfirmToken Token from a previous confirmation response for this exact query
+	ConfirmToken *string `json:"confirm_token,omitempty"`
+	Query        string  `json:"query"`
+
+	// Rollback Run the query in a transaction that is rolled back afterwards. Set to false to keep any changes it makes; destructive statements then need confirm_token.
+	Rollback *bool  `json:"rollback,omitempty"`
+	TabId    string `json:"tab_id"`
+}
+
 // AppInfo defines model for AppInfo.
 type AppInfo struct {
-	AiEnabled *bool   `json:"ai_enabled,omitempty"`
-	Version   *string `json:"version,omitempty"`
+	AiEnabled *bool `json:"ai_enabled,omitempty"`
+
+	// ReadOnly The server was started with --read-only, so every connection is read-only.
+	ReadOnly *bool   `json:"read_only,omitempty"`
+	Version  *string `json:"version,omitempty"`
+}
+
+// BackendSignalResult defines model for BackendSignalResult.
+type BackendSignalResult struct {
+	Pid int `json:"pid"`
+
+	// Signalled The server found the backend and delivered the signal
+	Signalled bool `json:"signalled"`
+}
+
+// BlockingSession defines model for BlockingSession.
+type BlockingSession struct {
+	Application string `json:"application"`
+
+	// BlockedBy PIDs blocking this session, from pg_blocking_pids()
+	BlockedBy []int `json:"blocked_by"`
+
+	// Blocking Sessions waiting on this one; a session blocked by several appears under each
+	Blocking []BlockingSession `json:"blocking"`
+	Database string            `json:"database"`
+
+	// Locks Relation locks the session holds
+	Locks         []Lock `json:"locks"`
+	Pid           int    `json:"pid"`
+	Query         string `json:"query"`
+	State         string `json:"state"`
+	User          string `json:"user"`
+	WaitEvent     string `json:"wait_event"`
+	WaitEventType string `json:"wait_event_type"`
+	WaitingFor    *Lock  `json:"waiting_for,omitempty"`
+
+	// WaitingMs How long the session has waited for its lock; absent if it is not waiting. Before PostgreSQL 14, measured from the start of the statement.
+	WaitingMs *float64 `json:"waiting_ms,omitempty"`
+
+	// XactMs Age of the session's open transaction
+	XactMs *float64 `json:"xact_ms,omitempty"`
 }
 
 // CancelRequest defines model for CancelRequest.
@@ -90,6 +315,19 @@ type CancelRequest struct {
 	TabId string `json:"tab_id"`
 }
 
+// CancelResult defines model for CancelResult.
+type CancelResult struct {
+	// BackendCancelled The server accepted pg_cancel_backend for the query's backend
+	BackendCancelled bool `json:"backend_cancelled"`
+
+	// Pid Backend that was running the query
+	Pid *int `json:"pid,omitempty"`
+
+	// Running A query was running in the tab
+	Running bool `json:"running"`
+	Success bool `json:"success"`
+}
+
 // CellValue defines model for CellValue.
 type CellValue = *string
 
@@ -104,19 +342,94 @@ type Column struct {
 	Type         string  `json:"type"`
 }
 
+// ConfirmationRequired The query was not run because it contains destructive statements. Resubmit the same query with `confirm_token` set to `token` to run it.
+type ConfirmationRequired struct {
+	ExpiresAt  time.Time              `json:"expires_at"`
+	Statements []DestructiveStatement `json:"statements"`
+	Token      string                 `json:"token"`
+}
+
 // ConnectRequest defines model for ConnectRequest.
 type ConnectRequest struct {
-	Url string `json:"url"`
+	// Id Connection ID to register under, defaults to the X-Pglet-Connection header or "default"
+	Id *string `json:"id,omitempty"`
+
+	// ProfileId Connect using a saved connection profile instead of a URL
+	ProfileId *string `json:"profile_id,omitempty"`
+	Url       *string `json:"url,omitempty"`
 }
 
 // ConnectionInfo defines model for ConnectionInfo.
 type ConnectionInfo struct {
 	Connected *bool  `json:"connected,omitempty"`
 	Database  string `json:"database"`
-	Host      string `json:"host"`
-	Port      int    `json:"port"`
-	User      string `json:"user"`
-	Version   string `json:"version"`
+
+	// Error Set in the connection list when this connection's details could not be read; the other fields except id may then be empty.
+	Error *string `json:"error,omitempty"`
+	Host  string  `json:"host"`
+	Id    *string `json:"id,omitempty"`
+	Port  int     `json:"port"`
+
+	// ReadOnly Writes are rejected on this connection, either because of the --read-only flag or the connection profile setting.
+	ReadOnly *bool  `json:"read_only,omitempty"`
+	User     string `json:"user"`
+	Version  string `json:"version"`
+}
+
+// ConnectionProfile defines model for ConnectionProfile.
+type ConnectionProfile struct {
+	Color       string `json:"color"`
+	CreatedAt   string `json:"created_at"`
+	Database    string `json:"database"`
+	HasPassword bool   `json:"has_password"`
+	Host        string `json:"host"`
+	Id          string `json:"id"`
+	Label       string `json:"label"`
+	Port        int    `json:"port"`
+	ReadOnly    bool   `json:"read_only"`
+	Sslmode     string `json:"sslmode"`
+	UpdatedAt   string `json:"updated_at"`
+	User        string `json:"user"`
+}
+
+// ConnectionProfileInput defines model for ConnectionProfileInput.
+type ConnectionProfileInput struct {
+	Color    *string `json:"color,omitempty"`
+	Database *string `json:"database,omitempty"`
+	Host     string  `json:"host"`
+	Label    string  `json:"label"`
+
+	// Password Omit to keep the stored password on update, send an empty string to clear it
+	Password *string `json:"password,omitempty"`
+	Port     *int    `json:"port,omitempty"`
+	ReadOnly *bool   `json:"read_only,omitempty"`
+	Sslmode  *string `json:"sslmode,omitempty"`
+	User     *string `json:"user,omitempty"`
+}
+
+// DestructiveStatement defines model for DestructiveStatement.
+type DestructiveStatement struct {
+	// EstimatedRows Planner or catalog estimate of the rows affected, when available
+	EstimatedRows *int64 `json:"estimated_rows,omitempty"`
+
+	// Objects Objects the statement drops or modifies
+	Objects []string `json:"objects"`
+
+	// Reason e.g. "DROP TABLE" or "DELETE without WHERE"
+	Reason    string `json:"reason"`
+	Statement string `json:"statement"`
+}
+
+// Domain defines model for Domain.
+type Domain struct {
+	BaseType    string            `json:"base_type"`
+	Collation   *string           `json:"collation,omitempty"`
+	Comment     *string           `json:"comment,omitempty"`
+	Constraints []TableConstraint `json:"constraints"`
+	Default     *string           `json:"default,omitempty"`
+	Name        string            `json:"name"`
+	NotNull     bool              `json:"not_null"`
+	Schema      string            `json:"schema"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -124,15 +437,91 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// ExplainRequest defines model for ExplainRequest.
+type ExplainRequest struct {
+	// Analyze Execute the statement to collect actual rows, timing and buffers
+	Analyze *bool `json:"analyze,omitempty"`
+
+	// ConfirmToken Token from a previous confirmation response for this exact query
+	ConfirmToken *string `json:"confirm_token,omitempty"`
+
+	// Params Bind parameters, as for QueryRequest
+	Params *map[string]ParamValue `json:"params,omitempty"`
+
+	// Query A single statement, without EXPLAIN
+	Query string `json:"query"`
+
+	// Rollback With analyze, run the statement in a transaction that is rolled back afterwards. Set to false to keep any changes it makes; destructive statements then need confirm_token.
+	Rollback *bool  `json:"rollback,omitempty"`
+	TabId    string `json:"tab_id"`
+}
+
+// ExplainResult defines model for ExplainResult.
+type ExplainResult struct {
+	Analyzed bool    `json:"analyzed"`
+	Error    *string `json:"error,omitempty"`
+
+	// ErrorDetail A statement error. Fields other than message are present when the server reported them (see the PostgreSQL ErrorResponse fields).
+	ErrorDetail *QueryErrorDetail `json:"error_detail,omitempty"`
+	ExecutionMs *float64          `json:"execution_ms,omitempty"`
+
+	// Id ID of the stored plan, for comparison with /api/plans/compare
+	Id         *int      `json:"id,omitempty"`
+	Plan       *PlanNode `json:"plan,omitempty"`
+	PlanningMs *float64  `json:"planning_ms,omitempty"`
+
+	// RolledBack The statement ran in a transaction that was rolled back, so its changes were not kept
+	RolledBack bool `json:"rolled_back"`
+
+	// Settings Planner settings that differ from the built-in defaults
+	Settings map[string]string `json:"settings"`
+
+	// Slowest IDs of the nodes with the highest exclusive time, slowest first
+	Slowest []int `json:"slowest"`
+}
+
 // ExportRequest defines model for ExportRequest.
 type ExportRequest struct {
-	Format ExportRequestFormat `json:"format"`
-	Query  string              `json:"query"`
+	// ConfirmToken Token from a previous confirmation response for this exact query
+	ConfirmToken *string             `json:"confirm_token,omitempty"`
+	Format       ExportRequestFormat `json:"format"`
+	Query        string              `json:"query"`
+
+	// Table Target table name for the sql format's INSERT statements
+	Table *string `json:"table,omitempty"`
 }
 
 // ExportRequestFormat defines model for ExportRequest.Format.
 type ExportRequestFormat string
 
+// Extension defines model for Extension.
+type Extension struct {
+	Comment *string `json:"comment,omitempty"`
+
+	// DefaultVersion Version the server's package provides; an upgrade is available when it differs
+	DefaultVersion string `json:"default_version"`
+	Name           string `json:"name"`
+	Relocatable    bool   `json:"relocatable"`
+	Schema         string `json:"schema"`
+
+	// Version Installed version
+	Version string `json:"version"`
+}
+
+// ForeignTable defines model for ForeignTable.
+type ForeignTable struct {
+	Comment *string `json:"comment,omitempty"`
+	Name    string  `json:"name"`
+
+	// Options Table options as key=value
+	Options []string `json:"options"`
+	Schema  string   `json:"schema"`
+	Server  string   `json:"server"`
+
+	// Wrapper Foreign-data wrapper of the server
+	Wrapper string `json:"wrapper"`
+}
+
 // FunctionDefinition defines model for FunctionDefinition.
 type FunctionDefinition struct {
 	Arguments  string `json:"arguments"`
@@ -145,8 +534,25 @@ type FunctionDefinition struct {
 	Volatility string `json:"volatility"`
 }
 
+// GraphColumn defines model for GraphColumn.
+type GraphColumn struct {
+	ForeignKey bool   `json:"foreign_key"`
+	Name       string `json:"name"`
+	Nullable   bool   `json:"nullable"`
+	PrimaryKey bool   `json:"primary_key"`
+	Type       string `json:"type"`
+}
+
+// GraphTable defines model for GraphTable.
+type GraphTable struct {
+	Columns []GraphColumn `json:"columns"`
+	Name    string        `json:"name"`
+	Schema  string        `json:"schema"`
+}
+
 // HistoryEntry defines model for HistoryEntry.
 type HistoryEntry struct {
+	Connection string `json:"connection"`
 	Database   string `json:"database"`
 	DurationMs int64  `json:"duration_ms"`
 	Error      string `json:"error"`
@@ -162,181 +568,950 @@ type HistoryResponse struct {
 	Total   int            `json:"total"`
 }
 
-// QueryRequest defines model for QueryRequest.
-type QueryRequest struct {
-	Query string `json:"query"`
-	TabId string `json:"tab_id"`
-}
+// Lock defines model for Lock.
+type Lock struct {
+	Granted bool `json:"granted"`
 
-// QueryResult defines model for QueryResult.
-type QueryResult struct {
-	ColumnTypes []string      `json:"column_types"`
-	Columns     []string      `json:"columns"`
-	DurationMs  int64         `json:"duration_ms"`
-	Error       *string       `json:"error,omitempty"`
-	RowCount    int           `json:"row_count"`
-	Rows        [][]CellValue `json:"rows"`
-}
+	// LockType e.g. "relation", "tuple" or "transactionid"
+	LockType string `json:"lock_type"`
 
-// SavedQuery defines model for SavedQuery.
-type SavedQuery struct {
-	CreatedAt   string `json:"created_at"`
-	Database    string `json:"database"`
-	Description string `json:"description"`
-	Id          string `json:"id"`
-	Shared      bool   `json:"shared"`
-	Sql         string `json:"sql"`
-	Tags        string `json:"tags"`
-	Title       string `json:"title"`
-	UpdatedAt   string `json:"updated_at"`
+	// Mode e.g. "RowExclusiveLock"
+	Mode     string  `json:"mode"`
+	Relation *string `json:"relation,omitempty"`
 }
 
-// SavedQueryInput defines model for SavedQueryInput.
-type SavedQueryInput struct {
-	Database    *string `json:"database,omitempty"`
-	Description *string `json:"description,omitempty"`
-	Sql         string  `json:"sql"`
-	Tags        *string `json:"tags,omitempty"`
-	Title       string  `json:"title"`
+// ObjectDDL defines model for ObjectDDL.
+type ObjectDDL struct {
+	// Ddl Statements separated by blank lines
+	Ddl    string `json:"ddl"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
 }
 
-// SchemaGroup defines model for SchemaGroup.
-type SchemaGroup struct {
-	Functions         []SchemaObject `json:"functions"`
-	MaterializedViews []SchemaObject `json:"materialized_views"`
-	Sequences         []SchemaObject `json:"sequences"`
-	Tables            []SchemaObject `json:"tables"`
-	Types             []SchemaObject `json:"types"`
-	Views             []SchemaObject `json:"views"`
-}
+// ParamValue defines model for ParamValue.
+type ParamValue struct {
+	// Type PostgreSQL type the placeholder is cast to, e.g. int, timestamptz or text[]. Without it the server infers the type from context.
+	Type *string `json:"type,omitempty"`
 
-// SchemaObject defines model for SchemaObject.
-type SchemaObject struct {
-	Comment *string `json:"comment,omitempty"`
-	Name    string  `json:"name"`
-	Schema  string  `json:"schema"`
-	Type    string  `json:"type"`
+	// Value Parameter value; objects and arrays are sent as JSON text
+	Value interface{} `json:"value"`
 }
 
-// SuccessResponse defines model for SuccessResponse.
-type SuccessResponse struct {
-	Success *bool `json:"success,omitempty"`
-}
+// PartitionNode defines model for PartitionNode.
+type PartitionNode struct {
+	// Bound Partition bound, e.g. "FOR VALUES IN (1, 2)"
+	Bound    *string         `json:"bound,omitempty"`
+	Children []PartitionNode `json:"children"`
 
-// SwitchDBRequest defines model for SwitchDBRequest.
-type SwitchDBRequest struct {
-	Database string `json:"database"`
-}
+	// Key Partition key of a partitioned table, e.g. "RANGE (created_at)"
+	Key  *string `json:"key,omitempty"`
+	Name string  `json:"name"`
 
-// TabState defines model for TabState.
-type TabState struct {
-	// Data Opaque JSON string containing tab layout
-	Data *string `json:"data,omitempty"`
+	// Parent On the root, the schema-qualified table it is a partition of
+	Parent *string `json:"parent,omitempty"`
+	Schema string  `json:"schema"`
 }
 
-// TableConstraint defines model for TableConstraint.
-type TableConstraint struct {
-	Columns    *[]string `json:"columns,omitempty"`
-	Definition string    `json:"definition"`
-	Name       string    `json:"name"`
-	Type       string    `json:"type"`
+// PlanBuffers defines model for PlanBuffers.
+type PlanBuffers struct {
+	LocalDirtied  int64 `json:"local_dirtied"`
+	LocalHit      int64 `json:"local_hit"`
+	LocalRead     int64 `json:"local_read"`
+	LocalWritten  int64 `json:"local_written"`
+	SharedDirtied int64 `json:"shared_dirtied"`
+	SharedHit     int64 `json:"shared_hit"`
+	SharedRead    int64 `json:"shared_read"`
+	SharedWritten int64 `json:"shared_written"`
+	TempRead      int64 `json:"temp_read"`
+	TempWritten   int64 `json:"temp_written"`
 }
 
-// TableIndex defines model for TableIndex.
-type TableIndex struct {
-	Columns    *[]string `json:"columns,omitempty"`
-	Definition string    `json:"definition"`
-	IsPrimary  bool      `json:"is_primary"`
-	IsUnique   bool      `json:"is_unique"`
-	Name       string    `json:"name"`
+// PlanCompareRequest defines model for PlanCompareRequest.
+type PlanCompareRequest struct {
+	AfterId  int `json:"after_id"`
+	BeforeId int `json:"before_id"`
 }
 
-// TableInfo defines model for TableInfo.
-type TableInfo struct {
-	IndexSize   string `json:"index_size"`
-	RowEstimate int64  `json:"row_estimate"`
-	TableSize   string `json:"table_size"`
-	TotalSize   string `json:"total_size"`
-}
+// PlanComparison defines model for PlanComparison.
+type PlanComparison struct {
+	After  ExplainResult `json:"after"`
+	Before ExplainResult `json:"before"`
 
-// TableRowsResult defines model for TableRowsResult.
-type TableRowsResult struct {
-	ColumnTypes []string      `json:"column_types"`
-	Columns     []string      `json:"columns"`
-	Page        int           `json:"page"`
-	PageSize    int           `json:"page_size"`
-	Rows        [][]CellValue `json:"rows"`
-	TotalCount  int           `json:"total_count"`
-}
+	// ExecutionMsDelta Change in execution time, when both plans were analyzed
+	ExecutionMsDelta *float64 `json:"execution_ms_delta,omitempty"`
 
-// ListHistoryParams defines parameters for ListHistory.
-type ListHistoryParams struct {
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
-}
+	// Nodes Matched nodes in before-plan order, followed by nodes only in the after plan
+	Nodes []PlanNodeDiff `json:"nodes"`
 
-// ListSavedQueriesParams defines parameters for ListSavedQueries.
-type ListSavedQueriesParams struct {
-	Database *string `form:"database,omitempty" json:"database,omitempty"`
+	// TotalCostDelta Change in the root node's estimated total cost
+	TotalCostDelta float64 `json:"total_cost_delta"`
 }
 
-// GetTableRowsParams defines parameters for GetTableRows.
-type GetTableRowsParams struct {
-	Limit      *int                         `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset     *int                         `form:"offset,omitempty" json:"offset,omitempty"`
-	SortColumn *string                      `form:"sort_column,omitempty" json:"sort_column,omitempty"`
-	SortOrder  *GetTableRowsParamsSortOrder `form:"sort_order,omitempty" json:"sort_order,omitempty"`
-}
+// PlanNode defines model for PlanNode.
+type PlanNode struct {
+	ActualLoops *float64 `json:"actual_loops,omitempty"`
 
-// GetTableRowsParamsSortOrder defines parameters for GetTableRows.
-type GetTableRowsParamsSortOrder string
+	// ActualRows Actual rows per loop
+	ActualRows      *float64 `json:"actual_rows,omitempty"`
+	ActualStartupMs *float64 `json:"actual_startup_ms,omitempty"`
 
-// AiGenerateJSONRequestBody defines body for AiGenerate for application/json ContentType.
-type AiGenerateJSONRequestBody = AiGenerateRequest
+	// ActualTotalMs Time per loop
+	ActualTotalMs *float64     `json:"actual_total_ms,omitempty"`
+	Alias         *string      `json:"alias,omitempty"`
+	Buffers       *PlanBuffers `json:"buffers,omitempty"`
+	Children      []PlanNode   `json:"children"`
 
-// AiTabNameJSONRequestBody defines body for AiTabName for application/json ContentType.
-type AiTabNameJSONRequestBody = AiTabNameRequest
+	// Conditions Filter and join conditions keyed by kind, e.g. "Filter" or "Hash Cond"
+	Conditions map[string]string `json:"conditions"`
 
-// AnalyzeQueryJSONRequestBody defines body for AnalyzeQuery for application/json ContentType.
-type AnalyzeQueryJSONRequestBody = QueryRequest
+	// ExclusiveMs inclusive_ms less the children's inclusive time
+	ExclusiveMs *float64 `json:"exclusive_ms,omitempty"`
 
-// ConnectJSONRequestBody defines body for Connect for application/json ContentType.
-type ConnectJSONRequestBody = ConnectRequest
+	// Extra Remaining properties EXPLAIN reported for the node
+	Extra map[string]interface{} `json:"extra"`
 
-// ExplainQueryJSONRequestBody defines body for ExplainQuery for application/json ContentType.
-type ExplainQueryJSONRequestBody = QueryRequest
+	// Id Pre-order index of the node in the tree, starting at 0
+	Id int `json:"id"`
 
-// ExportQueryJSONRequestBody defines body for ExportQuery for application/json ContentType.
-type ExportQueryJSONRequestBody = ExportRequest
+	// InclusiveMs Total time in the node and its children across all loops
+	InclusiveMs *float64 `json:"inclusive_ms,omitempty"`
+	Index       *string  `json:"index,omitempty"`
+	IoReadMs    *float64 `json:"io_read_ms,omitempty"`
+	IoWriteMs   *float64 `json:"io_write_ms,omitempty"`
+	JoinType    *string  `json:"join_type,omitempty"`
 
-// RunQueryJSONRequestBody defines body for RunQuery for application/json ContentType.
-type RunQueryJSONRequestBody = QueryRequest
+	// Misestimated rows_ratio is off by a factor of 10 or more
+	Misestimated bool `json:"misestimated"`
 
-// CancelQueryJSONRequestBody defines body for CancelQuery for application/json ContentType.
-type CancelQueryJSONRequestBody = CancelRequest
+	// NodeType e.g. "Seq Scan", "Hash Join"
+	NodeType           string    `json:"node_type"`
+	Output             *[]string `json:"output,omitempty"`
+	ParentRelationship *string   `json:"parent_relationship,omitempty"`
 
-// CreateSavedQueryJSONRequestBody defines body for CreateSavedQuery for application/json ContentType.
-type CreateSavedQueryJSONRequestBody = SavedQueryInput
+	// PlanRows Estimated rows per loop
+	PlanRows  float64 `json:"plan_rows"`
+	PlanWidth int     `json:"plan_width"`
+	Relation  *string `json:"relation,omitempty"`
 
-// UpdateSavedQueryJSONRequestBody defines body for UpdateSavedQuery for application/json ContentType.
-type UpdateSavedQueryJSONRequestBody = SavedQueryInput
+	// RowsRatio actual_rows / plan_rows; above 1 the planner underestimated
+	RowsRatio           *float64 `json:"rows_ratio,omitempty"`
+	RowsRemovedByFilter *float64 `json:"rows_removed_by_filter,omitempty"`
+	Schema              *string  `json:"schema,omitempty"`
+	StartupCost         float64  `json:"startup_cost"`
+	Strategy            *string  `json:"strategy,omitempty"`
+	TotalCost           float64  `json:"total_cost"`
+}
 
-// SwitchDatabaseJSONRequestBody defines body for SwitchDatabase for application/json ContentType.
-type SwitchDatabaseJSONRequestBody = SwitchDBRequest
+// PlanNodeDiff defines model for PlanNodeDiff.
+type PlanNodeDiff struct {
+	// AfterId Node ID in the after plan; absent for removed nodes
+	AfterId *int `json:"after_id,omitempty"`
 
-// SaveTabStateJSONRequestBody defines body for SaveTabState for application/json ContentType.
-type SaveTabStateJSONRequestBody = TabState
+	// BeforeId Node ID in the before plan; absent for added nodes
+	BeforeId *int               `json:"before_id,omitempty"`
+	Change   PlanNodeDiffChange `json:"change"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Running queries from pg_stat_activity
-	// (GET /api/activity)
-	GetActivity(w http.ResponseWriter, r *http.Request)
-	// Generate SQL from natural language
-	// (POST /api/ai/generate)
-	AiGenerate(w http.ResponseWriter, r *http.Request)
-	// Generate contextual query suggestions based on connected database
-	// (GET /api/ai/suggestions)
+	// CostDelta Change in the node's estimated total cost
+	CostDelta *float64 `json:"cost_delta,omitempty"`
+
+	// Details e.g. "Seq Scan → Index Scan on orders", "join strategy Hash Join → Nested Loop"
+	Details []string `json:"details"`
+
+	// JoinChanged The node joins by a different strategy
+	JoinChanged bool `json:"join_changed"`
+
+	// ScanChanged The node scans the same relation by a different method or index
+	ScanChanged bool `json:"scan_changed"`
+
+	// TimeDeltaMs Change in the node's inclusive time, when both plans were analyzed
+	TimeDeltaMs *float64 `json:"time_delta_ms,omitempty"`
+}
+
+// PlanNodeDiffChange defines model for PlanNodeDiff.Change.
+type PlanNodeDiffChange string
+
+// Policy defines model for Policy.
+type Policy struct {
+	Command PolicyCommand `json:"command"`
+	Name    string        `json:"name"`
+
+	// Permissive False for restrictive policies
+	Permissive bool     `json:"permissive"`
+	Roles      []string `json:"roles"`
+	Using      *string  `json:"using,omitempty"`
+	WithCheck  *string  `json:"with_check,omitempty"`
+}
+
+// PolicyCommand defines model for Policy.Command.
+type PolicyCommand string
+
+// Publication defines model for Publication.
+type Publication struct {
+	AllTables bool   `json:"all_tables"`
+	Delete    bool   `json:"delete"`
+	Insert    bool   `json:"insert"`
+	Name      string `json:"name"`
+	Owner     string `json:"owner"`
+
+	// Tables Published tables, schema-qualified
+	Tables   []string `json:"tables"`
+	Truncate bool     `json:"truncate"`
+	Update   bool     `json:"update"`
+}
+
+// QueryErrorDetail A statement error. Fields other than message are present when the server reported them (see the PostgreSQL ErrorResponse fields).
+type QueryErrorDetail struct {
+	// Code SQLSTATE code, e.g. "23505"
+	Code   *string `json:"code,omitempty"`
+	Column *string `json:"column,omitempty"`
+
+	// Condition Condition name for the code, e.g. "unique_violation"
+	Condition  *string `json:"condition,omitempty"`
+	Constraint *string `json:"constraint,omitempty"`
+	DataType   *string `json:"data_type,omitempty"`
+	Detail     *string `json:"detail,omitempty"`
+	Hint       *string `json:"hint,omitempty"`
+
+	// InternalPosition 1-based character position of the error in internal_query
+	InternalPosition *int `json:"internal_position,omitempty"`
+
+	// InternalQuery Internally generated command that failed, e.g. one run by a PL/pgSQL function
+	InternalQuery *string `json:"internal_query,omitempty"`
+	Message       string  `json:"message"`
+
+	// Position 1-based character position of the error in the query text
+	Position *int    `json:"position,omitempty"`
+	Schema   *string `json:"schema,omitempty"`
+
+	// Severity e.g. ERROR or FATAL
+	Severity *string `json:"severity,omitempty"`
+	Table    *string `json:"table,omitempty"`
+
+	// Where Context in which the error occurred, e.g. a PL/pgSQL call stack
+	Where *string `json:"where,omitempty"`
+}
+
+// QueryParameter A bind parameter declared by a saved query
+type QueryParameter struct {
+	// Default Default value as SQL text, for the editor to prefill
+	Default *string `json:"default,omitempty"`
+	Name    string  `json:"name"`
+	Type    *string `json:"type,omitempty"`
+}
+
+// QueryRequest defines model for QueryRequest.
+type QueryRequest struct {
+	// ConfirmToken Token from a previous confirmation response for this exact query
+	ConfirmToken *string `json:"confirm_token,omitempty"`
+
+	// MaxRows Maximum rows to return, capped by the server
+	MaxRows *int `json:"max_rows,omitempty"`
+
+	// OnError Whether a script stops at the first failing statement
+	OnError *QueryRequestOnError `json:"on_error,omitempty"`
+
+	// Params Bind parameters keyed by placeholder: "1" for $1, or "name" for :name. Values are sent as real bind parameters, not spliced into the SQL text.
+	Params *map[string]ParamValue `json:"params,omitempty"`
+	Query  string                 `json:"query"`
+	TabId  string                 `json:"tab_id"`
+
+	// Typed Return rows as typed `values` instead of text `rows`
+	Typed *bool `json:"typed,omitempty"`
+}
+
+// QueryRequestOnError Whether a script stops at the first failing statement
+type QueryRequestOnError string
+
+// QueryResult defines model for QueryResult.
+type QueryResult struct {
+	ColumnTypes []string `json:"column_types"`
+	Columns     []string `json:"columns"`
+
+	// CommandTag The server's completion tag, e.g. "UPDATE 50000" or "CREATE TABLE"
+	CommandTag *string `json:"command_tag,omitempty"`
+	DurationMs int64   `json:"duration_ms"`
+
+	// Error The first statement error in the script
+	Error *string `json:"error,omitempty"`
+
+	// ErrorDetail A statement error. Fields other than message are present when the server reported them (see the PostgreSQL ErrorResponse fields).
+	ErrorDetail *QueryErrorDetail `json:"error_detail,omitempty"`
+
+	// ErrorPosition 1-based character position of the error in the query text
+	ErrorPosition *int `json:"error_position,omitempty"`
+
+	// Results One result per statement, in script order. The top-level columns and rows repeat the last statement that succeeded.
+	Results *[]StatementResult `json:"results,omitempty"`
+
+	// RolledBack The statement was run by EXPLAIN ANALYZE in a transaction that was rolled back, so its changes were not kept
+	RolledBack *bool         `json:"rolled_back,omitempty"`
+	RowCount   int           `json:"row_count"`
+	Rows       [][]CellValue `json:"rows"`
+
+	// RowsAffected Row count from the command tag: rows inserted, updated, deleted or copied, or rows selected. Absent, like command_tag, when a truncated read was cancelled on the server instead of read to the end.
+	RowsAffected *int64 `json:"rows_affected,omitempty"`
+
+	// Truncated The result hit the server-side row cap and more rows were available
+	Truncated *bool `json:"truncated,omitempty"`
+
+	// Values The rows with each cell typed (see TypedValue). Sent instead of `rows`, which is then empty, when the request sets `typed`.
+	Values *[][]TypedValue `json:"values,omitempty"`
+}
+
+// QueryStreamMessage defines model for QueryStreamMessage.
+type QueryStreamMessage struct {
+	ColumnTypes *[]string `json:"column_types,omitempty"`
+	Columns     *[]string `json:"columns,omitempty"`
+
+	// CommandTag The server's completion tag, e.g. "UPDATE 50000" or "CREATE TABLE"
+	CommandTag *string `json:"command_tag,omitempty"`
+	DurationMs *int64  `json:"duration_ms,omitempty"`
+	Error      *string `json:"error,omitempty"`
+
+	// ErrorDetail A statement error. Fields other than message are present when the server reported them (see the PostgreSQL ErrorResponse fields).
+	ErrorDetail *QueryErrorDetail `json:"error_detail,omitempty"`
+
+	// ErrorPosition 1-based character position of the error in the query text
+	ErrorPosition *int           `json:"error_position,omitempty"`
+	RowCount      *int           `json:"row_count,omitempty"`
+	Rows          *[][]CellValue `json:"rows,omitempty"`
+
+	// RowsAffected Row count from the command tag: rows inserted, updated, deleted or copied, or rows selected. Absent, like command_tag, when a truncated read was cancelled on the server instead of read to the end.
+	RowsAffected *int64 `json:"rows_affected,omitempty"`
+
+	// Statement 0-based index of the statement in the script
+	Statement *int                   `json:"statement,omitempty"`
+	Truncated *bool                  `json:"truncated,omitempty"`
+	Type      QueryStreamMessageType `json:"type"`
+
+	// Values The rows with each cell typed (see TypedValue). Sent instead of `rows`, which is then empty, when the request sets `typed`.
+	Values *[][]TypedValue `json:"values,omitempty"`
+}
+
+// QueryStreamMessageType defines model for QueryStreamMessage.Type.
+type QueryStreamMessageType string
+
+// Relationship defines model for Relationship.
+type Relationship struct {
+	// Indexed An index on the source table starts with the source columns
+	Indexed bool `json:"indexed"`
+
+	// Name Foreign key constraint name
+	Name string `json:"name"`
+
+	// OnDelete NO ACTION, RESTRICT, CASCADE, SET NULL or SET DEFAULT
+	OnDelete      string   `json:"on_delete"`
+	OnUpdate      string   `json:"on_update"`
+	SourceColumns []string `json:"source_columns"`
+	SourceSchema  string   `json:"source_schema"`
+
+	// SourceTable The referencing table
+	SourceTable   string   `json:"source_table"`
+	TargetColumns []string `json:"target_columns"`
+	TargetSchema  string   `json:"target_schema"`
+
+	// TargetTable The referenced table
+	TargetTable string `json:"target_table"`
+
+	// Unique The source columns are unique, so the relationship is one-to-one
+	Unique bool `json:"unique"`
+}
+
+// RelationshipDiagram defines model for RelationshipDiagram.
+type RelationshipDiagram struct {
+	Diagram string `json:"diagram"`
+	Format  string `json:"format"`
+}
+
+// RelationshipGraph defines model for RelationshipGraph.
+type RelationshipGraph struct {
+	Relationships []Relationship `json:"relationships"`
+	Tables        []GraphTable   `json:"tables"`
+}
+
+// SavedQuery defines model for SavedQuery.
+type SavedQuery struct {
+	CreatedAt   string            `json:"created_at"`
+	Database    string            `json:"database"`
+	Description string            `json:"description"`
+	Id          string            `json:"id"`
+	Params      *[]QueryParameter `json:"params,omitempty"`
+	Shared      bool              `json:"shared"`
+	Sql         string            `json:"sql"`
+	Tags        string            `json:"tags"`
+	Title       string            `json:"title"`
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+// SavedQueryInput defines model for SavedQueryInput.
+type SavedQueryInput struct {
+	Database    *string           `json:"database,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Params      *[]QueryParameter `json:"params,omitempty"`
+	Sql         string            `json:"sql"`
+	Tags        *string           `json:"tags,omitempty"`
+	Title       string            `json:"title"`
+}
+
+// SchemaChange defines model for SchemaChange.
+type SchemaChange struct {
+	// After Definition in the target, absent for removed objects
+	After *string `json:"after,omitempty"`
+
+	// Before Definition in the source, absent for added objects
+	Before *string            `json:"before,omitempty"`
+	Change SchemaChangeChange `json:"change"`
+
+	// Details e.g. "type: integer -> bigint", "nullable: yes -> no"
+	Details []string `json:"details"`
+
+	// Name Object name; for a function, its signature
+	Name   string             `json:"name"`
+	Object SchemaChangeObject `json:"object"`
+
+	// Sql Statements applying this change
+	Sql []string `json:"sql"`
+
+	// Table Table of a column, index or constraint
+	Table *string `json:"table,omitempty"`
+}
+
+// SchemaChangeChange defines model for SchemaChange.Change.
+type SchemaChangeChange string
+
+// SchemaChangeObject defines model for SchemaChange.Object.
+type SchemaChangeObject string
+
+// SchemaDiff defines model for SchemaDiff.
+type SchemaDiff struct {
+	Changes []SchemaChange `json:"changes"`
+	Schema  string         `json:"schema"`
+
+	// Script Statements that turn the source into the target, ordered so dependencies exist when needed
+	Script string `json:"script"`
+}
+
+// SchemaDiffRequest defines model for SchemaDiffRequest.
+type SchemaDiffRequest struct {
+	// Schema Defaults to public
+	Schema *string        `json:"schema,omitempty"`
+	Source SchemaDiffSide `json:"source"`
+	Target SchemaDiffSide `json:"target"`
+}
+
+// SchemaDiffSide defines model for SchemaDiffSide.
+type SchemaDiffSide struct {
+	// ConnectionId Defaults to the connection of the request
+	ConnectionId *string `json:"connection_id,omitempty"`
+
+	// Database Another database on the same server; defaults to the connection's current database
+	Database *string `json:"database,omitempty"`
+}
+
+// SchemaDrift defines model for SchemaDrift.
+type SchemaDrift struct {
+	From SchemaSnapshot `json:"from"`
+
+	// Schemas Changes of each schema that changed, with a script that applies them
+	Schemas []SchemaDiff    `json:"schemas"`
+	To      *SchemaSnapshot `json:"to,omitempty"`
+}
+
+// SchemaGroup defines model for SchemaGroup.
+type SchemaGroup struct {
+	Domains []SchemaObject `json:"domains"`
+
+	// Extensions Extensions installed in this schema
+	Extensions        []SchemaObject `json:"extensions"`
+	ForeignTables     []SchemaObject `json:"foreign_tables"`
+	Functions         []SchemaObject `json:"functions"`
+	MaterializedViews []SchemaObject `json:"materialized_views"`
+
+	// Partitions Partitions, with their parent table
+	Partitions []SchemaObject `json:"partitions"`
+
+	// Policies Row-level security policies, with the table they are on
+	Policies []SchemaObject `json:"policies"`
+
+	// Publications Publications of the database that publish tables of this schema
+	Publications []SchemaObject `json:"publications"`
+	Sequences    []SchemaObject `json:"sequences"`
+
+	// Subscriptions Subscriptions of the database that replicate into tables of this schema
+	Subscriptions []SchemaObject `json:"subscriptions"`
+	Tables        []SchemaObject `json:"tables"`
+
+	// Triggers Triggers, with the table they are on
+	Triggers []SchemaObject `json:"triggers"`
+
+	// Types Enum, composite and range types; see the type field
+	Types []SchemaObject `json:"types"`
+	Views []SchemaObject `json:"views"`
+}
+
+// SchemaObject defines model for SchemaObject.
+type SchemaObject struct {
+	Comment *string `json:"comment,omitempty"`
+	Name    string  `json:"name"`
+
+	// Parent Table a trigger, policy or partition belongs to, schema-qualified if in another schema
+	Parent *string `json:"parent,omitempty"`
+	Schema string  `json:"schema"`
+	Type   string  `json:"type"`
+}
+
+// SchemaSnapshot defines model for SchemaSnapshot.
+type SchemaSnapshot struct {
+	// Changes Number of changes since the previous snapshot
+	Changes    int    `json:"changes"`
+	Connection string `json:"connection"`
+	CreatedAt  string `json:"created_at"`
+	Database   string `json:"database"`
+	Id         int    `json:"id"`
+	Label      string `json:"label"`
+
+	// PreviousId The snapshot of the same database taken before this one
+	PreviousId *int     `json:"previous_id,omitempty"`
+	Schemas    []string `json:"schemas"`
+}
+
+// SchemaSnapshotInput defines model for SchemaSnapshotInput.
+type SchemaSnapshotInput struct {
+	Label *string `json:"label,omitempty"`
+}
+
+// StatementDelta defines model for StatementDelta.
+type StatementDelta struct {
+	From StatementSnapshot `json:"from"`
+
+	// Statements Counter changes of the statements called in between
+	Statements []StatementStat    `json:"statements"`
+	To         *StatementSnapshot `json:"to,omitempty"`
+}
+
+// StatementOrder defines model for StatementOrder.
+type StatementOrder string
+
+// StatementResult defines model for StatementResult.
+type StatementResult struct {
+	ColumnTypes []string `json:"column_types"`
+	Columns     []string `json:"columns"`
+
+	// CommandTag The server's completion tag, e.g. "UPDATE 50000" or "CREATE TABLE"
+	CommandTag *string `json:"command_tag,omitempty"`
+	DurationMs int64   `json:"duration_ms"`
+	Error      *string `json:"error,omitempty"`
+
+	// ErrorDetail A statement error. Fields other than message are present when the server reported them (see the PostgreSQL ErrorResponse fields).
+	ErrorDetail *QueryErrorDetail `json:"error_detail,omitempty"`
+
+	// ErrorPosition 1-based character position of the error in the query text
+	ErrorPosition *int          `json:"error_position,omitempty"`
+	RowCount      int           `json:"row_count"`
+	Rows          [][]CellValue `json:"rows"`
+
+	// RowsAffected Row count from the command tag: rows inserted, updated, deleted or copied, or rows selected. Absent, like command_tag, when a truncated read was cancelled on the server instead of read to the end.
+	RowsAffected *int64 `json:"rows_affected,omitempty"`
+
+	// Skipped Not run because an earlier statement failed
+	Skipped   *bool  `json:"skipped,omitempty"`
+	Statement string `json:"statement"`
+	Truncated *bool  `json:"truncated,omitempty"`
+
+	// Values The rows with each cell typed (see TypedValue). Sent instead of `rows`, which is then empty, when the request sets `typed`.
+	Values *[][]TypedValue `json:"values,omitempty"`
+}
+
+// StatementSnapshot defines model for StatementSnapshot.
+type StatementSnapshot struct {
+	Connection string `json:"connection"`
+	CreatedAt  string `json:"created_at"`
+	Database   string `json:"database"`
+	Id         int    `json:"id"`
+	Label      string `json:"label"`
+}
+
+// StatementSnapshotInput defines model for StatementSnapshotInput.
+type StatementSnapshotInput struct {
+	Label *string `json:"label,omitempty"`
+}
+
+// StatementStat defines model for StatementStat.
+type StatementStat struct {
+	Calls int64 `json:"calls"`
+
+	// HitRatio Fraction of shared blocks found in the buffer cache; absent if none were read
+	HitRatio *float64 `json:"hit_ratio,omitempty"`
+	MeanMs   float64  `json:"mean_ms"`
+
+	// Query Normalized query text, with constants replaced by placeholders
+	Query          string  `json:"query"`
+	Queryid        int64   `json:"queryid"`
+	Rows           int64   `json:"rows"`
+	SharedBlksHit  int64   `json:"shared_blks_hit"`
+	SharedBlksRead int64   `json:"shared_blks_read"`
+	TotalMs        float64 `json:"total_ms"`
+	User           string  `json:"user"`
+}
+
+// StatementsReport defines model for StatementsReport.
+type StatementsReport struct {
+	// Available pg_stat_statements is installed in the current database
+	Available  bool            `json:"available"`
+	Statements []StatementStat `json:"statements"`
+}
+
+// StoredPlan defines model for StoredPlan.
+type StoredPlan struct {
+	Analyzed   bool   `json:"analyzed"`
+	Connection string `json:"connection"`
+	CreatedAt  string `json:"created_at"`
+	Database   string `json:"database"`
+	Id         int    `json:"id"`
+	Label      string `json:"label"`
+	Pinned     bool   `json:"pinned"`
+	Sql        string `json:"sql"`
+}
+
+// StoredPlanDetail defines model for StoredPlanDetail.
+type StoredPlanDetail struct {
+	Analyzed   bool          `json:"analyzed"`
+	Connection string        `json:"connection"`
+	CreatedAt  string        `json:"created_at"`
+	Database   string        `json:"database"`
+	Id         int           `json:"id"`
+	Label      string        `json:"label"`
+	Pinned     bool          `json:"pinned"`
+	Result     ExplainResult `json:"result"`
+	Sql        string        `json:"sql"`
+}
+
+// StoredPlanUpdate defines model for StoredPlanUpdate.
+type StoredPlanUpdate struct {
+	Label  *string `json:"label,omitempty"`
+	Pinned bool    `json:"pinned"`
+}
+
+// Subscription defines model for Subscription.
+type Subscription struct {
+	Enabled      bool                `json:"enabled"`
+	Name         string              `json:"name"`
+	Owner        string              `json:"owner"`
+	Publications []string            `json:"publications"`
+	SlotName     *string             `json:"slot_name,omitempty"`
+	Tables       []SubscriptionTable `json:"tables"`
+}
+
+// SubscriptionTable defines model for SubscriptionTable.
+type SubscriptionTable struct {
+	State SubscriptionTableState `json:"state"`
+	Table string                 `json:"table"`
+}
+
+// SubscriptionTableState defines model for SubscriptionTable.State.
+type SubscriptionTableState string
+
+// SuccessResponse defines model for SuccessResponse.
+type SuccessResponse struct {
+	Success *bool `json:"success,omitempty"`
+}
+
+// SwitchDBRequest defines model for SwitchDBRequest.
+type SwitchDBRequest struct {
+	Database string `json:"database"`
+}
+
+// TabState defines model for TabState.
+type TabState struct {
+	// Data Opaque JSON string containing tab layout
+	Data *string `json:"data,omitempty"`
+}
+
+// TableConstraint defines model for TableConstraint.
+type TableConstraint struct {
+	Columns    *[]string `json:"columns,omitempty"`
+	Definition string    `json:"definition"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+}
+
+// TableIndex defines model for TableIndex.
+type TableIndex struct {
+	Columns    *[]string `json:"columns,omitempty"`
+	Definition string    `json:"definition"`
+	IsPrimary  bool      `json:"is_primary"`
+	IsUnique   bool      `json:"is_unique"`
+	Name       string    `json:"name"`
+}
+
+// TableInfo defines model for TableInfo.
+type TableInfo struct {
+	IndexSize   string `json:"index_size"`
+	RowEstimate int64  `json:"row_estimate"`
+	TableSize   string `json:"table_size"`
+	TotalSize   string `json:"total_size"`
+}
+
+// TablePolicies defines model for TablePolicies.
+type TablePolicies struct {
+	// Enabled Row-level security is enabled on the table
+	Enabled bool `json:"enabled"`
+
+	// Forced Row-level security also applies to the table owner
+	Forced   bool     `json:"forced"`
+	Policies []Policy `json:"policies"`
+}
+
+// TableRowsResult defines model for TableRowsResult.
+type TableRowsResult struct {
+	ColumnTypes []string      `json:"column_types"`
+	Columns     []string      `json:"columns"`
+	Page        int           `json:"page"`
+	PageSize    int           `json:"page_size"`
+	Rows        [][]CellValue `json:"rows"`
+	TotalCount  int           `json:"total_count"`
+
+	// Values The rows with each cell typed (see TypedValue). Sent instead of `rows`, which is then empty, when the request sets `typed`.
+	Values *[][]TypedValue `json:"values,omitempty"`
+}
+
+// TransactionStatus defines model for TransactionStatus.
+type TransactionStatus struct {
+	// Connection Connection the transaction's session is pinned to
+	Connection *string `json:"connection,omitempty"`
+
+	// ExpiresAt When the transaction will be rolled back if no further query runs in the tab
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// Status failed means an error aborted the transaction and it must be rolled back
+	Status TransactionStatusStatus `json:"status"`
+	TabId  string                  `json:"tab_id"`
+}
+
+// TransactionStatusStatus failed means an error aborted the transaction and it must be rolled back
+type TransactionStatusStatus string
+
+// Trigger defines model for Trigger.
+type Trigger struct {
+	Comment    *string `json:"comment,omitempty"`
+	Definition string  `json:"definition"`
+
+	// Enabled Whether the trigger fires, following session_replication_role
+	Enabled  TriggerEnabled  `json:"enabled"`
+	Events   []TriggerEvents `json:"events"`
+	Function string          `json:"function"`
+	Level    TriggerLevel    `json:"level"`
+	Name     string          `json:"name"`
+	Timing   TriggerTiming   `json:"timing"`
+}
+
+// TriggerEnabled Whether the trigger fires, following session_replication_role
+type TriggerEnabled string
+
+// TriggerEvents defines model for Trigger.Events.
+type TriggerEvents string
+
+// TriggerLevel defines model for Trigger.Level.
+type TriggerLevel string
+
+// TriggerTiming defines model for Trigger.Timing.
+type TriggerTiming string
+
+// TypeAttribute defines model for TypeAttribute.
+type TypeAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypeDetail defines model for TypeDetail.
+type TypeDetail struct {
+	// Attributes Composite type attributes in order
+	Attributes *[]TypeAttribute `json:"attributes,omitempty"`
+	Comment    *string          `json:"comment,omitempty"`
+	Kind       TypeDetailKind   `json:"kind"`
+
+	// Labels Enum labels in order
+	Labels *[]string `json:"labels,omitempty"`
+
+	// Multirange Multirange type of a range (PostgreSQL 14+)
+	Multirange *string `json:"multirange,omitempty"`
+	Name       string  `json:"name"`
+	Schema     string  `json:"schema"`
+
+	// Subtype Element type of a range
+	Subtype *string `json:"subtype,omitempty"`
+}
+
+// TypeDetailKind defines model for TypeDetail.Kind.
+type TypeDetailKind string
+
+// TypedValue A typed result cell, decoded according to its column type: numbers and
+// booleans as JSON scalars, json/jsonb as JSON values, arrays as JSON
+// arrays, numeric as an exact decimal string, bytea as \x-prefixed hex,
+// date/time types as ISO-8601 strings, and anything else as text.
+type TypedValue = interface{}
+
+// GetObjectDDLParamsKind defines parameters for GetObjectDDL.
+type GetObjectDDLParamsKind string
+
+// ListHistoryParams defines parameters for ListHistory.
+type ListHistoryParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListPlansParams defines parameters for ListPlans.
+type ListPlansParams struct {
+	// Pinned Only list pinned plans
+	Pinned *bool `form:"pinned,omitempty" json:"pinned,omitempty"`
+}
+
+// GetRelationshipsParams defines parameters for GetRelationships.
+type GetRelationshipsParams struct {
+	// Schema Schema to draw; defaults to public
+	Schema *string `form:"schema,omitempty" json:"schema,omitempty"`
+
+	// Table Draw only the neighbourhood of this table, optionally schema-qualified
+	Table *string `form:"table,omitempty" json:"table,omitempty"`
+
+	// Hops With table, how many foreign keys away from it to follow
+	Hops *int `form:"hops,omitempty" json:"hops,omitempty"`
+}
+
+// GetRelationshipDiagramParams defines parameters for GetRelationshipDiagram.
+type GetRelationshipDiagramParams struct {
+	// Schema Schema to draw; defaults to public
+	Schema *string `form:"schema,omitempty" json:"schema,omitempty"`
+
+	// Table Draw only the neighbourhood of this table, optionally schema-qualified
+	Table *string `form:"table,omitempty" json:"table,omitempty"`
+
+	// Hops With table, how many foreign keys away from it to follow
+	Hops   *int                               `form:"hops,omitempty" json:"hops,omitempty"`
+	Format GetRelationshipDiagramParamsFormat `form:"format" json:"format"`
+}
+
+// GetRelationshipDiagramParamsFormat defines parameters for GetRelationshipDiagram.
+type GetRelationshipDiagramParamsFormat string
+
+// ListSavedQueriesParams defines parameters for ListSavedQueries.
+type ListSavedQueriesParams struct {
+	Database *string `form:"database,omitempty" json:"database,omitempty"`
+}
+
+// GetSchemaDriftParams defines parameters for GetSchemaDrift.
+type GetSchemaDriftParams struct {
+	To *int `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetStatementsParams defines parameters for GetStatements.
+type GetStatementsParams struct {
+	OrderBy *StatementOrder `form:"order_by,omitempty" json:"order_by,omitempty"`
+	Limit   *int            `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetStatementDeltaParams defines parameters for GetStatementDelta.
+type GetStatementDeltaParams struct {
+	To      *int            `form:"to,omitempty" json:"to,omitempty"`
+	OrderBy *StatementOrder `form:"order_by,omitempty" json:"order_by,omitempty"`
+	Limit   *int            `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetTableRowsParams defines parameters for GetTableRows.
+type GetTableRowsParams struct {
+	Limit      *int                         `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset     *int                         `form:"offset,omitempty" json:"offset,omitempty"`
+	SortColumn *string                      `form:"sort_column,omitempty" json:"sort_column,omitempty"`
+	SortOrder  *GetTableRowsParamsSortOrder `form:"sort_order,omitempty" json:"sort_order,omitempty"`
+
+	// Typed Return rows as typed `values` instead of text `rows`
+	Typed *bool `form:"typed,omitempty" json:"typed,omitempty"`
+}
+
+// GetTableRowsParamsSortOrder defines parameters for GetTableRows.
+type GetTableRowsParamsSortOrder string
+
+// AiGenerateJSONRequestBody defines body for AiGenerate for application/json ContentType.
+type AiGenerateJSONRequestBody = AiGenerateRequest
+
+// AiTabNameJSONRequestBody defines body for AiTabName for application/json ContentType.
+type AiTabNameJSONRequestBody = AiTabNameRequest
+
+// AnalyzeQueryJSONRequestBody defines body for AnalyzeQuery for application/json ContentType.
+type AnalyzeQueryJSONRequestBody = AnalyzeRequest
+
+// ConnectJSONRequestBody defines body for Connect for application/json ContentType.
+type ConnectJSONRequestBody = ConnectRequest
+
+// CreateConnectionProfileJSONRequestBody defines body for CreateConnectionProfile for application/json ContentType.
+type CreateConnectionProfileJSONRequestBody = ConnectionProfileInput
+
+// UpdateConnectionProfileJSONRequestBody defines body for UpdateConnectionProfile for application/json ContentType.
+type UpdateConnectionProfileJSONRequestBody = ConnectionProfileInput
+
+// ExplainQueryJSONRequestBody defines body for ExplainQuery for application/json ContentType.
+type ExplainQueryJSONRequestBody = QueryRequest
+
+// ExplainPlanJSONRequestBody defines body for ExplainPlan for application/json ContentType.
+type ExplainPlanJSONRequestBody = ExplainRequest
+
+// ExportQueryJSONRequestBody defines body for ExportQuery for application/json ContentType.
+type ExportQueryJSONRequestBody = ExportRequest
+
+// ComparePlansJSONRequestBody defines body for ComparePlans for application/json ContentType.
+type ComparePlansJSONRequestBody = PlanCompareRequest
+
+// UpdatePlanJSONRequestBody defines body for UpdatePlan for application/json ContentType.
+type UpdatePlanJSONRequestBody = StoredPlanUpdate
+
+// RunQueryJSONRequestBody defines body for RunQuery for application/json ContentType.
+type RunQueryJSONRequestBody = QueryRequest
+
+// CancelQueryJSONRequestBody defines body for CancelQuery for application/json ContentType.
+type CancelQueryJSONRequestBody = CancelRequest
+
+// CreateSavedQueryJSONRequestBody defines body for CreateSavedQuery for application/json ContentType.
+type CreateSavedQueryJSONRequestBody = SavedQueryInput
+
+// UpdateSavedQueryJSONRequestBody defines body for UpdateSavedQuery for application/json ContentType.
+type UpdateSavedQueryJSONRequestBody = SavedQueryInput
+
+// DiffSchemasJSONRequestBody defines body for DiffSchemas for application/json ContentType.
+type DiffSchemasJSONRequestBody = SchemaDiffRequest
+
+// CreateSchemaSnapshotJSONRequestBody defines body for CreateSchemaSnapshot for application/json ContentType.
+type CreateSchemaSnapshotJSONRequestBody = SchemaSnapshotInput
+
+// CreateStatementSnapshotJSONRequestBody defines body for CreateStatementSnapshot for application/json ContentType.
+type CreateStatementSnapshotJSONRequestBody = StatementSnapshotInput
+
+// SwitchDatabaseJSONRequestBody defines body for SwitchDatabase for application/json ContentType.
+type SwitchDatabaseJSONRequestBody = SwitchDBRequest
+
+// SaveTabStateJSONRequestBody defines body for SaveTabState for application/json ContentType.
+type SaveTabStateJSONRequestBody = TabState
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Running queries from pg_stat_activity
+	// (GET /api/activity)
+	GetActivity(w http.ResponseWriter, r *http.Request)
+	// Cancel the current query of a backend (pg_cancel_backend)
+	// (POST /api/activity/{pid}/cancel)
+	CancelBackend(w http.ResponseWriter, r *http.Request, pid int)
+	// Terminate a backend's session (pg_terminate_backend)
+	// (POST /api/activity/{pid}/terminate)
+	TerminateBackend(w http.ResponseWriter, r *http.Request, pid int)
+	// Index recommendations for the current database
+	// (GET /api/advisor)
+	GetAdvisor(w http.ResponseWriter, r *http.Request)
+	// Generate SQL from natural language
+	// (POST /api/ai/generate)
+	AiGenerate(w http.ResponseWriter, r *http.Request)
+	// Generate contextual query suggestions based on connected database
+	// (GET /api/ai/suggestions)
 	AiSuggestions(w http.ResponseWriter, r *http.Request)
 	// Generate a short tab name from SQL
 	// (POST /api/ai/tab-name)
@@ -350,18 +1525,51 @@ type ServerInterface interface {
 	// Get current connection info
 	// (GET /api/connection)
 	GetConnectionInfo(w http.ResponseWriter, r *http.Request)
+	// List saved connection profiles
+	// (GET /api/connection-profiles)
+	ListConnectionProfiles(w http.ResponseWriter, r *http.Request)
+	// Create a connection profile
+	// (POST /api/connection-profiles)
+	CreateConnectionProfile(w http.ResponseWriter, r *http.Request)
+	// Delete a connection profile
+	// (DELETE /api/connection-profiles/{id})
+	DeleteConnectionProfile(w http.ResponseWriter, r *http.Request, id string)
+	// Get a connection profile by ID
+	// (GET /api/connection-profiles/{id})
+	GetConnectionProfile(w http.ResponseWriter, r *http.Request, id string)
+	// Update a connection profile
+	// (PUT /api/connection-profiles/{id})
+	UpdateConnectionProfile(w http.ResponseWriter, r *http.Request, id string)
+	// List open connections
+	// (GET /api/connections)
+	ListConnections(w http.ResponseWriter, r *http.Request)
 	// List all databases on the server
 	// (GET /api/databases)
 	ListDatabases(w http.ResponseWriter, r *http.Request)
+	// Reconstructed DDL of a schema object
+	// (GET /api/ddl/{kind}/{name})
+	GetObjectDDL(w http.ResponseWriter, r *http.Request, kind GetObjectDDLParamsKind, name string)
 	// Disconnect from the database
 	// (POST /api/disconnect)
 	Disconnect(w http.ResponseWriter, r *http.Request)
+	// Domain definition
+	// (GET /api/domains/{domain})
+	GetDomain(w http.ResponseWriter, r *http.Request, domain string)
 	// EXPLAIN a SQL query
 	// (POST /api/explain)
 	ExplainQuery(w http.ResponseWriter, r *http.Request)
-	// Export query results as CSV or JSON
+	// EXPLAIN a statement as a typed plan tree
+	// (POST /api/explain/plan)
+	ExplainPlan(w http.ResponseWriter, r *http.Request)
+	// Export query results to a file
 	// (POST /api/export)
 	ExportQuery(w http.ResponseWriter, r *http.Request)
+	// Installed extension
+	// (GET /api/extensions/{name})
+	GetExtension(w http.ResponseWriter, r *http.Request, name string)
+	// Foreign table server and options
+	// (GET /api/foreign_tables/{table})
+	GetForeignTable(w http.ResponseWriter, r *http.Request, table string)
 	// Get function or procedure source code
 	// (GET /api/functions/{function})
 	GetFunctionDefinition(w http.ResponseWriter, r *http.Request, function string)
@@ -374,15 +1582,42 @@ type ServerInterface interface {
 	// Get app version and feature flags
 	// (GET /api/info)
 	GetAppInfo(w http.ResponseWriter, r *http.Request)
+	// Blocking tree of sessions waiting for locks
+	// (GET /api/locks)
+	GetLocks(w http.ResponseWriter, r *http.Request)
 	// All objects grouped by schema and type
 	// (GET /api/objects)
 	ListObjects(w http.ResponseWriter, r *http.Request)
+	// List stored plans
+	// (GET /api/plans)
+	ListPlans(w http.ResponseWriter, r *http.Request, params ListPlansParams)
+	// Compare two stored plans node by node
+	// (POST /api/plans/compare)
+	ComparePlans(w http.ResponseWriter, r *http.Request)
+	// Delete a stored plan
+	// (DELETE /api/plans/{id})
+	DeletePlan(w http.ResponseWriter, r *http.Request, id int)
+	// Get a stored plan with its plan tree
+	// (GET /api/plans/{id})
+	GetPlan(w http.ResponseWriter, r *http.Request, id int)
+	// Pin or unpin a stored plan and set its label
+	// (PUT /api/plans/{id})
+	UpdatePlan(w http.ResponseWriter, r *http.Request, id int)
+	// Publication and its tables
+	// (GET /api/publications/{name})
+	GetPublication(w http.ResponseWriter, r *http.Request, name string)
 	// Execute SQL query
 	// (POST /api/query)
 	RunQuery(w http.ResponseWriter, r *http.Request)
 	// Cancel a running query
 	// (POST /api/query/cancel)
 	CancelQuery(w http.ResponseWriter, r *http.Request)
+	// Foreign key graph
+	// (GET /api/relationships)
+	GetRelationships(w http.ResponseWriter, r *http.Request, params GetRelationshipsParams)
+	// Foreign key graph as an ER diagram
+	// (GET /api/relationships/diagram)
+	GetRelationshipDiagram(w http.ResponseWriter, r *http.Request, params GetRelationshipDiagramParams)
 	// List saved queries
 	// (GET /api/saved-queries)
 	ListSavedQueries(w http.ResponseWriter, r *http.Request, params ListSavedQueriesParams)
@@ -398,12 +1633,48 @@ type ServerInterface interface {
 	// Update a saved query
 	// (PUT /api/saved-queries/{id})
 	UpdateSavedQuery(w http.ResponseWriter, r *http.Request, id string)
+	// Compare a schema between two databases
+	// (POST /api/schema/diff)
+	DiffSchemas(w http.ResponseWriter, r *http.Request)
+	// List stored schema snapshots
+	// (GET /api/schema/snapshots)
+	ListSchemaSnapshots(w http.ResponseWriter, r *http.Request)
+	// Store the current structure of every schema
+	// (POST /api/schema/snapshots)
+	CreateSchemaSnapshot(w http.ResponseWriter, r *http.Request)
+	// Delete a schema snapshot
+	// (DELETE /api/schema/snapshots/{id})
+	DeleteSchemaSnapshot(w http.ResponseWriter, r *http.Request, id int)
+	// Schema changes since a snapshot
+	// (GET /api/schema/snapshots/{id}/drift)
+	GetSchemaDrift(w http.ResponseWriter, r *http.Request, id int, params GetSchemaDriftParams)
 	// List database schemas
 	// (GET /api/schemas)
 	ListSchemas(w http.ResponseWriter, r *http.Request)
 	// PostgreSQL server settings
 	// (GET /api/server_settings)
 	GetServerSettings(w http.ResponseWriter, r *http.Request)
+	// Top queries from pg_stat_statements
+	// (GET /api/statements)
+	GetStatements(w http.ResponseWriter, r *http.Request, params GetStatementsParams)
+	// Reset pg_stat_statements counters
+	// (POST /api/statements/reset)
+	ResetStatements(w http.ResponseWriter, r *http.Request)
+	// List stored pg_stat_statements snapshots
+	// (GET /api/statements/snapshots)
+	ListStatementSnapshots(w http.ResponseWriter, r *http.Request)
+	// Store the current pg_stat_statements counters
+	// (POST /api/statements/snapshots)
+	CreateStatementSnapshot(w http.ResponseWriter, r *http.Request)
+	// Delete a pg_stat_statements snapshot
+	// (DELETE /api/statements/snapshots/{id})
+	DeleteStatementSnapshot(w http.ResponseWriter, r *http.Request, id int)
+	// Statement activity since a snapshot
+	// (GET /api/statements/snapshots/{id}/delta)
+	GetStatementDelta(w http.ResponseWriter, r *http.Request, id int, params GetStatementDeltaParams)
+	// Subscription of the current database
+	// (GET /api/subscriptions/{name})
+	GetSubscription(w http.ResponseWriter, r *http.Request, name string)
 	// Switch to a different database
 	// (POST /api/switchdb)
 	SwitchDatabase(w http.ResponseWriter, r *http.Request)
@@ -419,9 +1690,18 @@ type ServerInterface interface {
 	// Table size and row estimates
 	// (GET /api/tables/{table}/info)
 	GetTableInfo(w http.ResponseWriter, r *http.Request, table string)
+	// Partition hierarchy of a table
+	// (GET /api/tables/{table}/partitions)
+	GetPartitionTree(w http.ResponseWriter, r *http.Request, table string)
+	// Row-level security policies of a table
+	// (GET /api/tables/{table}/policies)
+	GetTablePolicies(w http.ResponseWriter, r *http.Request, table string)
 	// Paginated table data
 	// (GET /api/tables/{table}/rows)
 	GetTableRows(w http.ResponseWriter, r *http.Request, table string, params GetTableRowsParams)
+	// Table triggers
+	// (GET /api/tables/{table}/triggers)
+	GetTableTriggers(w http.ResponseWriter, r *http.Request, table string)
 	// All tables size and row stats
 	// (GET /api/tables_stats)
 	GetTablesStats(w http.ResponseWriter, r *http.Request)
@@ -431,22 +1711,638 @@ type ServerInterface interface {
 	// Save tab state
 	// (PUT /api/tabs)
 	SaveTabState(w http.ResponseWriter, r *http.Request)
+	// Get a tab's transaction status
+	// (GET /api/transactions/{tab_id})
+	GetTransactionStatus(w http.ResponseWriter, r *http.Request, tabId string)
+	// Open a transaction in a tab
+	// (POST /api/transactions/{tab_id}/begin)
+	BeginTransaction(w http.ResponseWriter, r *http.Request, tabId string)
+	// Commit a tab's transaction
+	// (POST /api/transactions/{tab_id}/commit)
+	CommitTransaction(w http.ResponseWriter, r *http.Request, tabId string)
+	// Roll back a tab's transaction
+	// (POST /api/transactions/{tab_id}/rollback)
+	RollbackTransaction(w http.ResponseWriter, r *http.Request, tabId string)
+	// Enum, composite or range type
+	// (GET /api/types/{type})
+	GetTypeDetail(w http.ResponseWriter, r *http.Request, pType string)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetActivity operation middleware
+func (siw *ServerInterfaceWrapper) GetActivity(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetActivity(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelBackend operation middleware
+func (siw *ServerInterfaceWrapper) CancelBackend(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "pid" -------------
+	var pid int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pid", r.PathValue("pid"), &pid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelBackend(w, r, pid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TerminateBackend operation middleware
+func (siw *ServerInterfaceWrapper) TerminateBackend(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "pid" -------------
+	var pid int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "pid", r.PathValue("pid"), &pid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pid", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TerminateBackend(w, r, pid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdvisor operation middleware
+func (siw *ServerInterfaceWrapper) GetAdvisor(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdvisor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AiGenerate operation middleware
+func (siw *ServerInterfaceWrapper) AiGenerate(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AiGenerate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AiSuggestions operation middleware
+func (siw *ServerInterfaceWrapper) AiSuggestions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AiSuggestions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AiTabName operation middleware
+func (siw *ServerInterfaceWrapper) AiTabName(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AiTabName(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AnalyzeQuery operation middleware
+func (siw *ServerInterfaceWrapper) AnalyzeQuery(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AnalyzeQuery(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Connect operation middleware
+func (siw *ServerInterfaceWrapper) Connect(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Connect(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetConnectionInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetConnectionInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConnectionInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListConnectionProfiles operation middleware
+func (siw *ServerInterfaceWrapper) ListConnectionProfiles(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListConnectionProfiles(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateConnectionProfile operation middleware
+func (siw *ServerInterfaceWrapper) CreateConnectionProfile(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateConnectionProfile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteConnectionProfile operation middleware
+func (siw *ServerInterfaceWrapper) DeleteConnectionProfile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteConnectionProfile(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetConnectionProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetConnectionProfile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetConnectionProfile(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateConnectionProfile operation middleware
+func (siw *ServerInterfaceWrapper) UpdateConnectionProfile(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateConnectionProfile(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListConnections operation middleware
+func (siw *ServerInterfaceWrapper) ListConnections(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListConnections(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListDatabases operation middleware
+func (siw *ServerInterfaceWrapper) ListDatabases(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDatabases(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetObjectDDL operation middleware
+func (siw *ServerInterfaceWrapper) GetObjectDDL(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "kind" -------------
+	var kind GetObjectDDLParamsKind
+
+	err = runtime.BindStyledParameterWithOptions("simple", "kind", r.PathValue("kind"), &kind, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetObjectDDL(w, r, kind, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// Disconnect operation middleware
+func (siw *ServerInterfaceWrapper) Disconnect(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Disconnect(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// GetDomain operation middleware
+func (siw *ServerInterfaceWrapper) GetDomain(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "domain" -------------
+	var domain string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "domain", r.PathValue("domain"), &domain, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "domain", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDomain(w, r, domain)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExplainQuery operation middleware
+func (siw *ServerInterfaceWrapper) ExplainQuery(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExplainQuery(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExplainPlan operation middleware
+func (siw *ServerInterfaceWrapper) ExplainPlan(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExplainPlan(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportQuery operation middleware
+func (siw *ServerInterfaceWrapper) ExportQuery(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportQuery(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExtension operation middleware
+func (siw *ServerInterfaceWrapper) GetExtension(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExtension(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetForeignTable operation middleware
+func (siw *ServerInterfaceWrapper) GetForeignTable(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", r.PathValue("table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetForeignTable(w, r, table)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFunctionDefinition operation middleware
+func (siw *ServerInterfaceWrapper) GetFunctionDefinition(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "function" -------------
+	var function string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "function", r.PathValue("function"), &function, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "function", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFunctionDefinition(w, r, function)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearHistory operation middleware
+func (siw *ServerInterfaceWrapper) ClearHistory(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearHistory(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListHistory operation middleware
+func (siw *ServerInterfaceWrapper) ListHistory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListHistoryParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListHistory(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAppInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetAppInfo(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAppInfo(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetLocks operation middleware
+func (siw *ServerInterfaceWrapper) GetLocks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLocks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListObjects operation middleware
+func (siw *ServerInterfaceWrapper) ListObjects(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListObjects(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPlans operation middleware
+func (siw *ServerInterfaceWrapper) ListPlans(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPlansParams
+
+	// ------------- Optional query parameter "pinned" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pinned", r.URL.Query(), &params.Pinned)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pinned", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPlans(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
 
-// GetActivity operation middleware
-func (siw *ServerInterfaceWrapper) GetActivity(w http.ResponseWriter, r *http.Request) {
+// ComparePlans operation middleware
+func (siw *ServerInterfaceWrapper) ComparePlans(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetActivity(w, r)
+		siw.Handler.ComparePlans(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -456,11 +2352,22 @@ func (siw *ServerInterfaceWrapper) GetActivity(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// AiGenerate operation middleware
-func (siw *ServerInterfaceWrapper) AiGenerate(w http.ResponseWriter, r *http.Request) {
+// DeletePlan operation middleware
+func (siw *ServerInterfaceWrapper) DeletePlan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AiGenerate(w, r)
+		siw.Handler.DeletePlan(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -470,11 +2377,22 @@ func (siw *ServerInterfaceWrapper) AiGenerate(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// AiSuggestions operation middleware
-func (siw *ServerInterfaceWrapper) AiSuggestions(w http.ResponseWriter, r *http.Request) {
+// GetPlan operation middleware
+func (siw *ServerInterfaceWrapper) GetPlan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AiSuggestions(w, r)
+		siw.Handler.GetPlan(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -484,11 +2402,22 @@ func (siw *ServerInterfaceWrapper) AiSuggestions(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// AiTabName operation middleware
-func (siw *ServerInterfaceWrapper) AiTabName(w http.ResponseWriter, r *http.Request) {
+// UpdatePlan operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePlan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AiTabName(w, r)
+		siw.Handler.UpdatePlan(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -498,11 +2427,22 @@ func (siw *ServerInterfaceWrapper) AiTabName(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// AnalyzeQuery operation middleware
-func (siw *ServerInterfaceWrapper) AnalyzeQuery(w http.ResponseWriter, r *http.Request) {
+// GetPublication operation middleware
+func (siw *ServerInterfaceWrapper) GetPublication(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AnalyzeQuery(w, r)
+		siw.Handler.GetPublication(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -512,11 +2452,11 @@ func (siw *ServerInterfaceWrapper) AnalyzeQuery(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// Connect operation middleware
-func (siw *ServerInterfaceWrapper) Connect(w http.ResponseWriter, r *http.Request) {
+// RunQuery operation middleware
+func (siw *ServerInterfaceWrapper) RunQuery(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Connect(w, r)
+		siw.Handler.RunQuery(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -526,11 +2466,11 @@ func (siw *ServerInterfaceWrapper) Connect(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// GetConnectionInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetConnectionInfo(w http.ResponseWriter, r *http.Request) {
+// CancelQuery operation middleware
+func (siw *ServerInterfaceWrapper) CancelQuery(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetConnectionInfo(w, r)
+		siw.Handler.CancelQuery(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -540,11 +2480,40 @@ func (siw *ServerInterfaceWrapper) GetConnectionInfo(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// ListDatabases operation middleware
-func (siw *ServerInterfaceWrapper) ListDatabases(w http.ResponseWriter, r *http.Request) {
+// GetRelationships operation middleware
+func (siw *ServerInterfaceWrapper) GetRelationships(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRelationshipsParams
+
+	// ------------- Optional query parameter "schema" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "schema", r.URL.Query(), &params.Schema)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schema", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", r.URL.Query(), &params.Table)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "hops" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hops", r.URL.Query(), &params.Hops)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hops", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListDatabases(w, r)
+		siw.Handler.GetRelationships(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -554,11 +2523,55 @@ func (siw *ServerInterfaceWrapper) ListDatabases(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// Disconnect operation middleware
-func (siw *ServerInterfaceWrapper) Disconnect(w http.ResponseWriter, r *http.Request) {
+// GetRelationshipDiagram operation middleware
+func (siw *ServerInterfaceWrapper) GetRelationshipDiagram(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRelationshipDiagramParams
+
+	// ------------- Optional query parameter "schema" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "schema", r.URL.Query(), &params.Schema)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "schema", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "table" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "table", r.URL.Query(), &params.Table)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "hops" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hops", r.URL.Query(), &params.Hops)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hops", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Disconnect(w, r)
+		siw.Handler.GetRelationshipDiagram(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -568,11 +2581,24 @@ func (siw *ServerInterfaceWrapper) Disconnect(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ExplainQuery operation middleware
-func (siw *ServerInterfaceWrapper) ExplainQuery(w http.ResponseWriter, r *http.Request) {
+// ListSavedQueries operation middleware
+func (siw *ServerInterfaceWrapper) ListSavedQueries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSavedQueriesParams
+
+	// ------------- Optional query parameter "database" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "database", r.URL.Query(), &params.Database)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "database", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ExplainQuery(w, r)
+		siw.Handler.ListSavedQueries(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -582,11 +2608,11 @@ func (siw *ServerInterfaceWrapper) ExplainQuery(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// ExportQuery operation middleware
-func (siw *ServerInterfaceWrapper) ExportQuery(w http.ResponseWriter, r *http.Request) {
+// CreateSavedQuery operation middleware
+func (siw *ServerInterfaceWrapper) CreateSavedQuery(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ExportQuery(w, r)
+		siw.Handler.CreateSavedQuery(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -596,22 +2622,22 @@ func (siw *ServerInterfaceWrapper) ExportQuery(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetFunctionDefinition operation middleware
-func (siw *ServerInterfaceWrapper) GetFunctionDefinition(w http.ResponseWriter, r *http.Request) {
+// DeleteSavedQuery operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSavedQuery(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "function" -------------
-	var function string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "function", r.PathValue("function"), &function, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "function", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetFunctionDefinition(w, r, function)
+		siw.Handler.DeleteSavedQuery(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -621,11 +2647,22 @@ func (siw *ServerInterfaceWrapper) GetFunctionDefinition(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// ClearHistory operation middleware
-func (siw *ServerInterfaceWrapper) ClearHistory(w http.ResponseWriter, r *http.Request) {
+// GetSavedQuery operation middleware
+func (siw *ServerInterfaceWrapper) GetSavedQuery(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ClearHistory(w, r)
+		siw.Handler.GetSavedQuery(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -635,32 +2672,36 @@ func (siw *ServerInterfaceWrapper) ClearHistory(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// ListHistory operation middleware
-func (siw *ServerInterfaceWrapper) ListHistory(w http.ResponseWriter, r *http.Request) {
+// UpdateSavedQuery operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSavedQuery(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListHistoryParams
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateSavedQuery(w, r, id)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// DiffSchemas operation middleware
+func (siw *ServerInterfaceWrapper) DiffSchemas(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListHistory(w, r, params)
+		siw.Handler.DiffSchemas(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -670,11 +2711,11 @@ func (siw *ServerInterfaceWrapper) ListHistory(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetAppInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetAppInfo(w http.ResponseWriter, r *http.Request) {
+// ListSchemaSnapshots operation middleware
+func (siw *ServerInterfaceWrapper) ListSchemaSnapshots(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAppInfo(w, r)
+		siw.Handler.ListSchemaSnapshots(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -684,11 +2725,72 @@ func (siw *ServerInterfaceWrapper) GetAppInfo(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ListObjects operation middleware
-func (siw *ServerInterfaceWrapper) ListObjects(w http.ResponseWriter, r *http.Request) {
+// CreateSchemaSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchemaSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSchemaSnapshot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSchemaSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchemaSnapshot(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSchemaSnapshot(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSchemaDrift operation middleware
+func (siw *ServerInterfaceWrapper) GetSchemaDrift(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSchemaDriftParams
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListObjects(w, r)
+		siw.Handler.GetSchemaDrift(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -698,11 +2800,11 @@ func (siw *ServerInterfaceWrapper) ListObjects(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// RunQuery operation middleware
-func (siw *ServerInterfaceWrapper) RunQuery(w http.ResponseWriter, r *http.Request) {
+// ListSchemas operation middleware
+func (siw *ServerInterfaceWrapper) ListSchemas(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RunQuery(w, r)
+		siw.Handler.ListSchemas(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -712,11 +2814,11 @@ func (siw *ServerInterfaceWrapper) RunQuery(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// CancelQuery operation middleware
-func (siw *ServerInterfaceWrapper) CancelQuery(w http.ResponseWriter, r *http.Request) {
+// GetServerSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetServerSettings(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CancelQuery(w, r)
+		siw.Handler.GetServerSettings(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -726,24 +2828,32 @@ func (siw *ServerInterfaceWrapper) CancelQuery(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// ListSavedQueries operation middleware
-func (siw *ServerInterfaceWrapper) ListSavedQueries(w http.ResponseWriter, r *http.Request) {
+// GetStatements operation middleware
+func (siw *ServerInterfaceWrapper) GetStatements(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListSavedQueriesParams
+	var params GetStatementsParams
 
-	// ------------- Optional query parameter "database" -------------
+	// ------------- Optional query parameter "order_by" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "database", r.URL.Query(), &params.Database)
+	err = runtime.BindQueryParameter("form", true, false, "order_by", r.URL.Query(), &params.OrderBy)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "database", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListSavedQueries(w, r, params)
+		siw.Handler.GetStatements(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -753,11 +2863,11 @@ func (siw *ServerInterfaceWrapper) ListSavedQueries(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// CreateSavedQuery operation middleware
-func (siw *ServerInterfaceWrapper) CreateSavedQuery(w http.ResponseWriter, r *http.Request) {
+// ResetStatements operation middleware
+func (siw *ServerInterfaceWrapper) ResetStatements(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateSavedQuery(w, r)
+		siw.Handler.ResetStatements(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -767,22 +2877,25 @@ func (siw *ServerInterfaceWrapper) CreateSavedQuery(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteSavedQuery operation middleware
-func (siw *ServerInterfaceWrapper) DeleteSavedQuery(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// ListStatementSnapshots operation middleware
+func (siw *ServerInterfaceWrapper) ListStatementSnapshots(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListStatementSnapshots(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// CreateStatementSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) CreateStatementSnapshot(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteSavedQuery(w, r, id)
+		siw.Handler.CreateStatementSnapshot(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -792,13 +2905,13 @@ func (siw *ServerInterfaceWrapper) DeleteSavedQuery(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetSavedQuery operation middleware
-func (siw *ServerInterfaceWrapper) GetSavedQuery(w http.ResponseWriter, r *http.Request) {
+// DeleteStatementSnapshot operation middleware
+func (siw *ServerInterfaceWrapper) DeleteStatementSnapshot(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id int
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -807,7 +2920,7 @@ func (siw *ServerInterfaceWrapper) GetSavedQuery(w http.ResponseWriter, r *http.
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSavedQuery(w, r, id)
+		siw.Handler.DeleteStatementSnapshot(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -817,13 +2930,13 @@ func (siw *ServerInterfaceWrapper) GetSavedQuery(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateSavedQuery operation middleware
-func (siw *ServerInterfaceWrapper) UpdateSavedQuery(w http.ResponseWriter, r *http.Request) {
+// GetStatementDelta operation middleware
+func (siw *ServerInterfaceWrapper) GetStatementDelta(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id int
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -831,22 +2944,35 @@ func (siw *ServerInterfaceWrapper) UpdateSavedQuery(w http.ResponseWriter, r *ht
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateSavedQuery(w, r, id)
-	}))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatementDeltaParams
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Optional query parameter "order_by" -------------
 
-// ListSchemas operation middleware
-func (siw *ServerInterfaceWrapper) ListSchemas(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindQueryParameter("form", true, false, "order_by", r.URL.Query(), &params.OrderBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order_by", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListSchemas(w, r)
+		siw.Handler.GetStatementDelta(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -856,11 +2982,22 @@ func (siw *ServerInterfaceWrapper) ListSchemas(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetServerSettings operation middleware
-func (siw *ServerInterfaceWrapper) GetServerSettings(w http.ResponseWriter, r *http.Request) {
+// GetSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetServerSettings(w, r)
+		siw.Handler.GetSubscription(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -984,6 +3121,56 @@ func (siw *ServerInterfaceWrapper) GetTableInfo(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetPartitionTree operation middleware
+func (siw *ServerInterfaceWrapper) GetPartitionTree(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", r.PathValue("table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPartitionTree(w, r, table)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTablePolicies operation middleware
+func (siw *ServerInterfaceWrapper) GetTablePolicies(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", r.PathValue("table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTablePolicies(w, r, table)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetTableRows operation middleware
 func (siw *ServerInterfaceWrapper) GetTableRows(w http.ResponseWriter, r *http.Request) {
 
@@ -1033,6 +3220,14 @@ func (siw *ServerInterfaceWrapper) GetTableRows(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	// ------------- Optional query parameter "typed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "typed", r.URL.Query(), &params.Typed)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "typed", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetTableRows(w, r, table, params)
 	}))
@@ -1044,6 +3239,31 @@ func (siw *ServerInterfaceWrapper) GetTableRows(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetTableTriggers operation middleware
+func (siw *ServerInterfaceWrapper) GetTableTriggers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "table" -------------
+	var table string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "table", r.PathValue("table"), &table, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "table", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTableTriggers(w, r, table)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetTablesStats operation middleware
 func (siw *ServerInterfaceWrapper) GetTablesStats(w http.ResponseWriter, r *http.Request) {
 
@@ -1086,6 +3306,131 @@ func (siw *ServerInterfaceWrapper) SaveTabState(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetTransactionStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetTransactionStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tab_id" -------------
+	var tabId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tab_id", r.PathValue("tab_id"), &tabId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tab_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTransactionStatus(w, r, tabId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BeginTransaction operation middleware
+func (siw *ServerInterfaceWrapper) BeginTransaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tab_id" -------------
+	var tabId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tab_id", r.PathValue("tab_id"), &tabId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tab_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BeginTransaction(w, r, tabId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CommitTransaction operation middleware
+func (siw *ServerInterfaceWrapper) CommitTransaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tab_id" -------------
+	var tabId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tab_id", r.PathValue("tab_id"), &tabId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tab_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CommitTransaction(w, r, tabId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RollbackTransaction operation middleware
+func (siw *ServerInterfaceWrapper) RollbackTransaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tab_id" -------------
+	var tabId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tab_id", r.PathValue("tab_id"), &tabId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tab_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RollbackTransaction(w, r, tabId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTypeDetail operation middleware
+func (siw *ServerInterfaceWrapper) GetTypeDetail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "type" -------------
+	var pType string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "type", r.PathValue("type"), &pType, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTypeDetail(w, r, pType)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -1207,39 +3552,82 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 	}
 
 	m.HandleFunc("GET "+options.BaseURL+"/api/activity", wrapper.GetActivity)
+	m.HandleFunc("POST "+options.BaseURL+"/api/activity/{pid}/cancel", wrapper.CancelBackend)
+	m.HandleFunc("POST "+options.BaseURL+"/api/activity/{pid}/terminate", wrapper.TerminateBackend)
+	m.HandleFunc("GET "+options.BaseURL+"/api/advisor", wrapper.GetAdvisor)
 	m.HandleFunc("POST "+options.BaseURL+"/api/ai/generate", wrapper.AiGenerate)
 	m.HandleFunc("GET "+options.BaseURL+"/api/ai/suggestions", wrapper.AiSuggestions)
 	m.HandleFunc("POST "+options.BaseURL+"/api/ai/tab-name", wrapper.AiTabName)
 	m.HandleFunc("POST "+options.BaseURL+"/api/analyze", wrapper.AnalyzeQuery)
 	m.HandleFunc("POST "+options.BaseURL+"/api/connect", wrapper.Connect)
 	m.HandleFunc("GET "+options.BaseURL+"/api/connection", wrapper.GetConnectionInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/api/connection-profiles", wrapper.ListConnectionProfiles)
+	m.HandleFunc("POST "+options.BaseURL+"/api/connection-profiles", wrapper.CreateConnectionProfile)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/connection-profiles/{id}", wrapper.DeleteConnectionProfile)
+	m.HandleFunc("GET "+options.BaseURL+"/api/connection-profiles/{id}", wrapper.GetConnectionProfile)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/connection-profiles/{id}", wrapper.UpdateConnectionProfile)
+	m.HandleFunc("GET "+options.BaseURL+"/api/connections", wrapper.ListConnections)
 	m.HandleFunc("GET "+options.BaseURL+"/api/databases", wrapper.ListDatabases)
+	m.HandleFunc("GET "+options.BaseURL+"/api/ddl/{kind}/{name}", wrapper.GetObjectDDL)
 	m.HandleFunc("POST "+options.BaseURL+"/api/disconnect", wrapper.Disconnect)
+	m.HandleFunc("GET "+options.BaseURL+"/api/domains/{domain}", wrapper.GetDomain)
 	m.HandleFunc("POST "+options.BaseURL+"/api/explain", wrapper.ExplainQuery)
+	m.HandleFunc("POST "+options.BaseURL+"/api/explain/plan", wrapper.ExplainPlan)
 	m.HandleFunc("POST "+options.BaseURL+"/api/export", wrapper.ExportQuery)
+	m.HandleFunc("GET "+options.BaseURL+"/api/extensions/{name}", wrapper.GetExtension)
+	m.HandleFunc("GET "+options.BaseURL+"/api/foreign_tables/{table}", wrapper.GetForeignTable)
 	m.HandleFunc("GET "+options.BaseURL+"/api/functions/{function}", wrapper.GetFunctionDefinition)
 	m.HandleFunc("DELETE "+options.BaseURL+"/api/history", wrapper.ClearHistory)
 	m.HandleFunc("GET "+options.BaseURL+"/api/history", wrapper.ListHistory)
 	m.HandleFunc("GET "+options.BaseURL+"/api/info", wrapper.GetAppInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/api/locks", wrapper.GetLocks)
 	m.HandleFunc("GET "+options.BaseURL+"/api/objects", wrapper.ListObjects)
+	m.HandleFunc("GET "+options.BaseURL+"/api/plans", wrapper.ListPlans)
+	m.HandleFunc("POST "+options.BaseURL+"/api/plans/compare", wrapper.ComparePlans)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/plans/{id}", wrapper.DeletePlan)
+	m.HandleFunc("GET "+options.BaseURL+"/api/plans/{id}", wrapper.GetPlan)
+	m.HandleFunc("PUT "+options.BaseURL+"/api/plans/{id}", wrapper.UpdatePlan)
+	m.HandleFunc("GET "+options.BaseURL+"/api/publications/{name}", wrapper.GetPublication)
 	m.HandleFunc("POST "+options.BaseURL+"/api/query", wrapper.RunQuery)
 	m.HandleFunc("POST "+options.BaseURL+"/api/query/cancel", wrapper.CancelQuery)
+	m.HandleFunc("GET "+options.BaseURL+"/api/relationships", wrapper.GetRelationships)
+	m.HandleFunc("GET "+options.BaseURL+"/api/relationships/diagram", wrapper.GetRelationshipDiagram)
 	m.HandleFunc("GET "+options.BaseURL+"/api/saved-queries", wrapper.ListSavedQueries)
 	m.HandleFunc("POST "+options.BaseURL+"/api/saved-queries", wrapper.CreateSavedQuery)
 	m.HandleFunc("DELETE "+options.BaseURL+"/api/saved-queries/{id}", wrapper.DeleteSavedQuery)
 	m.HandleFunc("GET "+options.BaseURL+"/api/saved-queries/{id}", wrapper.GetSavedQuery)
 	m.HandleFunc("PUT "+options.BaseURL+"/api/saved-queries/{id}", wrapper.UpdateSavedQuery)
+	m.HandleFunc("POST "+options.BaseURL+"/api/schema/diff", wrapper.DiffSchemas)
+	m.HandleFunc("GET "+options.BaseURL+"/api/schema/snapshots", wrapper.ListSchemaSnapshots)
+	m.HandleFunc("POST "+options.BaseURL+"/api/schema/snapshots", wrapper.CreateSchemaSnapshot)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/schema/snapshots/{id}", wrapper.DeleteSchemaSnapshot)
+	m.HandleFunc("GET "+options.BaseURL+"/api/schema/snapshots/{id}/drift", wrapper.GetSchemaDrift)
 	m.HandleFunc("GET "+options.BaseURL+"/api/schemas", wrapper.ListSchemas)
 	m.HandleFunc("GET "+options.BaseURL+"/api/server_settings", wrapper.GetServerSettings)
+	m.HandleFunc("GET "+options.BaseURL+"/api/statements", wrapper.GetStatements)
+	m.HandleFunc("POST "+options.BaseURL+"/api/statements/reset", wrapper.ResetStatements)
+	m.HandleFunc("GET "+options.BaseURL+"/api/statements/snapshots", wrapper.ListStatementSnapshots)
+	m.HandleFunc("POST "+options.BaseURL+"/api/statements/snapshots", wrapper.CreateStatementSnapshot)
+	m.HandleFunc("DELETE "+options.BaseURL+"/api/statements/snapshots/{id}", wrapper.DeleteStatementSnapshot)
+	m.HandleFunc("GET "+options.BaseURL+"/api/statements/snapshots/{id}/delta", wrapper.GetStatementDelta)
+	m.HandleFunc("GET "+options.BaseURL+"/api/subscriptions/{name}", wrapper.GetSubscription)
 	m.HandleFunc("POST "+options.BaseURL+"/api/switchdb", wrapper.SwitchDatabase)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}", wrapper.GetTableColumns)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/constraints", wrapper.GetTableConstraints)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/indexes", wrapper.GetTableIndexes)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/info", wrapper.GetTableInfo)
+	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/partitions", wrapper.GetPartitionTree)
+	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/policies", wrapper.GetTablePolicies)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/rows", wrapper.GetTableRows)
+	m.HandleFunc("GET "+options.BaseURL+"/api/tables/{table}/triggers", wrapper.GetTableTriggers)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tables_stats", wrapper.GetTablesStats)
 	m.HandleFunc("GET "+options.BaseURL+"/api/tabs", wrapper.GetTabState)
 	m.HandleFunc("PUT "+options.BaseURL+"/api/tabs", wrapper.SaveTabState)
+	m.HandleFunc("GET "+options.BaseURL+"/api/transactions/{tab_id}", wrapper.GetTransactionStatus)
+	m.HandleFunc("POST "+options.BaseURL+"/api/transactions/{tab_id}/begin", wrapper.BeginTransaction)
+	m.HandleFunc("POST "+options.BaseURL+"/api/transactions/{tab_id}/commit", wrapper.CommitTransaction)
+	m.HandleFunc("POST "+options.BaseURL+"/api/transactions/{tab_id}/rollback", wrapper.RollbackTransaction)
+	m.HandleFunc("GET "+options.BaseURL+"/api/types/{type}", wrapper.GetTypeDetail)
 
 	return m
 }
@@ -1247,54 +3635,246 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+Rb33PbuPH/VzD8fp86TOT07vrgN8d2U9+kSRylN21vMhqIXMm4owAaAG0rHv3vHfwi",
-	"QRCg6MTyOXNPlsUlsPvBYveDxeo+K9imZhSoFNnxfSaKK9hg/fGkkOSGyK36XHNWA5cE9BNc1xUpsCSM",
-	"qn/ltobsOBOSE7rOdnlWVASoXOCy5NHnJZZ4iQXEHzY8PXJNSu97QiWsgasH1w3wbfQVIbGMz9QIiOt3",
-	"i4lcwA1QuefxwjwbyOzyjMN1QziU2fGvWmvPajtz3sOxj5pT2xnmwdJTb6jM59wpw5a/QSGVwifkDVDg",
-	"WMJHuG5AyOGSji7JBoTAayNIJGz0h//nsMqOs/+bdQ40s94zOyH/NK+ot+1wmHO81WvI2aaWE1Azch5w",
-	"+2wTNaPGhL5xcFdXmKa9SlxX+9VRQnlvqLg6zvKBFgWjMuVSnFUT/EhL5e1A8fnnzXoNQiko0oiITqi3",
-	"qAPF+osXQuKNElfmE16+w5u0201Gfs/wKTsp3kzAVUtFZ6jrC7pikQhIFkDxsgI/HC0ZqwBT9eINcBH3",
-	"tl1kmlNMC6iSIEm8XPTiXsIMKxcz5BSq6hdcNRoM2lSV0j07lryBPBg1z+5erNkL++VfOlc4ZVWzoTG/",
-	"3mxSfl3CCjeVXNxMm3uXZ0Qsak42mG8Xv8M2jm5iVXNv9NhrNRMkiAFeBpkWyvXUVtibzxt8YEJ0PRil",
-	"UMjkkjd8wr5QQiODE0bjzluY5ynfHU0FV0zEV7pmXMaBTabZ0U3iG6ontVO0ydNLp26gGBjnnDM+khrU",
-	"4/0aGLHo+HdKreRCrhjfYP090GajhirETZZnv4mevh0oKSITKORogR0/ptnfG6qd4AxWhLaOH8Qxvm42",
-	"jvvFdq/35uDx74SW0QcVpuvG5sDhHk1tXg6y4TRFqXJLTeOexCosSWXJ6qQdbEfrGelpnnvQ9FXrzWZB",
-	"iMH/DyIk49tzKvn2gXzLcb3FpudDalP97ccsj+yxlBsrwgJFI6Fc4Pi+TRFqzm4XBWtoYlNPStya9hre",
-	"5O1W3zp/HmdFX+cRaEd2NZWcPICv9tYqQlklk7iKARGGCTuveyOm/KXaucl4kT7HTGUBLjKMsAGrgmgq",
-	"GcsOKs1rX38QN8ztmw986TF9fY/PcnbbV26Sb3TUKeYYY/TY4ZH3MbWK9F3fhyG2ZHN8A+Wlc45gxTjg",
-	"kR0+HmpAFJzUyRhP4hFeXGGe4g/x0KAccp1wCSKrxAm9LtOWxYKNGapvViQEaVVaK3Ifwd6k4ytxQetm",
-	"z1Ha8t/sOMvyveCPC++DdfztFMbhEcLilzpyzfWmeMNZU0e4jiUc0/eWGe59O3y4vTZYAie4Il+gXNwQ",
-	"uH28oYWKwLSAxxtRqpPAIw43CMDfMtpjgjc8dlY6rJk5oquWe87hY+/MTPuaVeJBp84kxRyhkA86/rXk",
-	"MVlymzdFAWK0AqMFYiE0ViKY3xJZXJ29/poCXmDDaDntE17OXcF0OIGJM17Qyt7X+LoB9PP8/Ttk5kMF",
-	"oxITqj5KvEQV3rJGDmPSLj57BaeMCskxoUly8lCKMX6MSXrL11QEvLk+pwy8oCXcPY1tXSkinquJWDSU",
-	"XDfwoEJLHIHeQaobuKfECCaxSgVRSC0E+QJJvgdCko112AnkUQer9Iiatqceh3Gvk+2Nm/tqB0om7f/I",
-	"bsUzYeR1/+TugaeehOA8Jb9265Pk+A8k4P5o1m7fyOFiqQmI9dR+HPzAhFxzmF++RS6+oqWaBjg6+XDR",
-	"UtPjrF5XIO13bQUsO3r56uWRspDVQHFNsuPsh5dHL3/QCskrjeIM12SGvVu5NWgUlK/ow8NFmR1nb0C2",
-	"N3cKDpOA9AB/PToKLiK8G6iZLkm1V4DT73rcZEOeEFLc9koRVURIvVyi2ZjolH1sqM4Z6vxKQKAVZxtU",
-	"rxdCYrlorVbvGBzIbG0vfvSOsXXJPhbd5VBmPAOEfM3K7YNQGL/oCm/Wdn0nlLyB3Tcuw1QFLNWI4O5k",
-	"SjS/fBvg7p6pRwZ0imXDcYXaYpgPenBzFHXB3iVUdlDrY7ddEQCsGJTI3CqKFAharTvZ4Eo74hZ59iK1",
-	"q0vEKGor6O1m72Ek8fKFS50px7RXVwfzy+Dm7cndMryaG/VKxRM1YIlVwUhcMS5bOeOnzpcN7BRX2y9j",
-	"kBuBS1seOwTqvdLeEyPu1/RikddYj7iT8HE+//eHtycX79DJu5O3//nvOcI6FphCYguw9fk0wPba6UDY",
-	"BjdmT4xucKUWAbiTQJoh7PLsx0dUoH+NNT7/CpMKymCN7XMkGcIowlYGC22PFCmOESDynLBHjKOSiC5I",
-	"m16aMLZIVDScA5WoCNfOQeHASae6t0TIs1bqsfjWvjaMAQJKDcRWHfdUUTJMcloIV1UrJVQuk1eABPAb",
-	"y5+N3S166e1+1skccPHDOko0t5tKSt/YTj2TLJSZQ1/X7TyEpq08NwJ/0qxhrR/PGtFsAXeuISCJK+Py",
-	"kLD2L+YPgGt4NswzxRxnhbiJyrVVhBjKjDsyibBAp/NfVAz7ef7+3ZPnkde4RNyBlmc/PeXc2h2Quf1N",
-	"JjKDlmXnxjHFADTnhm3VeXbvPu7GslqkZ0IdwDnegAQusuNf7zMVLvShPHMFsra4nYU+NlJy3n0+4L6O",
-	"2BHB20mh0hcLs6QzTqFbc1ZA2XBAgjW8UOel0gunV+Ye3ZRHKjBH84AlVoC5vW9/lolDK6jTpHExZ9Mu",
-	"TzOAzp6Yr7gbeessFdkQmfme0V7d/XSUR2pa8WHYaiUgMU5smEO6W9iQEQHbiiDbIoFuibxCugCHTAEu",
-	"wlWCBXBe5ipwyfKX7do85PnWThE7adV1xyL7OwnXNbIlP4RpiVaApdpLqwqvRWefySjjnPO9lflGG3FZ",
-	"6m2Pqw+9kvP+y0FzB7yLlkiDbablkbUKbXAdQHNSVe3TtRoWSrTcIjOfBkrP0eLT9sjEqcXH5s9K1y69",
-	"nDjImrqlKkbV9L+zQvcgj5zu9fNDAtvvgn5iZL8+XWitEUbcq1976Ap8A+ULW9Qe3dJtY4npIJuQSbyO",
-	"lsPRjGkNA1170oTTqpa20T1yE6Bjv2hlFBymezvmlrpzx5v+ML4ZNv1M8s5XB5g+Wv4w3UseZNvQSbUE",
-	"wgORoYvO7km5GyNwZ/r7HuL7CbJuznoe1PjrKwra8BDEPElDvmOIRt1tnnQzzXF8eFQavzjTu7eJgPQv",
-	"3Wn3BDg9k4DwDJzYQJ6MBN2vP9Npysr84bVOyw+Tlc62Fuqs6szU5c6FACkJXYuxs8Rci86d5B/H7Ywi",
-	"yOmMsOiVQAIAvAK/6L/ooaBbysplmvPZprOOZRxkJwWdbc/9ZgetGEcUbvvV5A56Y4+5ZynJagX6imFY",
-	"ejZtk7N7/Xe0KGW74lwvy/4QKe1v0p4um0zr9DE/IJywxY1kjBuqFKPNQ6alxytdiRS2s6LtKBQTcO5k",
-	"v2Osw07KSaA76RjwnyzoHToptHX3G+xH+sLKfe8om3bOCQBrwTS2Drc0ruNlr66P8nkiOgHIeAB28Axq",
-	"auaBIF9AV4k4u0Wu0zINo+tSHIXxo2kQPASM+YOrwq+ODlgWTgwjGJcLE2Kzr7BGv854qX+p273tfgN7",
-	"Mj/N8uzsfH4a+Q3s4R3Na7aNuNsHvCZUH6i1qwTEqn1ospDuig9cTbcs7vcxMddiz6NcaOvxZkNpvYY1",
-	"WmNdf791sg6AfYbPpWvHPNwSz23DRyyUmG4Q84uFZcWWEYJRAxdEuMY02z2SOMOqg2DPqsdnyH2DvoND",
-	"psLEh2632/0vAAD//wDZEy1oSAAA",
+	"H4sIAAAAAAAC/+y97XLctrIo+iqoubsqyb3Uh9dKdt1t1/0hW3KWdzm2Iykra9+t1AhDYmYQcQAaACUr",
+	"Kf89D3Ae8TzJKXQDIEiCHI4jyfJJfqwVeYiPRnej0egv/D7L5aaSggmjZ09/nymmKyk0g3+8kGLJ1YYa",
+	"LsUpe19zxQr7ey6FYcLYP2lVlTyHFge/ainsbzpfsw21f/2bYsvZ09n/ddBMcoBf9UFy8I8fP2azgulc",
+	"8cp+mD2dna8ZeV8zdUvstJQLTQqmjapzw68Z0YYatrEjEyoKckM1EdIQVYun5Pj07Tv49fz0pzcvjs5P",
+	"otYZfPjp3bH9WSpyfPL65PyE3HCzlrUhlBhZ7ZXsmpXk53+cnJ6QvKS1ZvvklOl6seGGmDUjmm4YUex9",
+	"zbTJoHPzMwJt2If4i2KmVoIVxMgrJgjV5DJHRMzhl0tipAWecLNPTq7tCEwUleTCTkgNyakg7APLa8OI",
+	"rvN1jICvD2jFD2DejMDf7ENVUi4OqpIK9xMVtLz9jcHyfRupzDcESV9oYtZckxt6m5EFW0rFLDyCixWh",
+	"4tasuVjtX4iZJZQjpaX0kaUGN7f270rJiinDme5wiP2nua3Y7OlMG8XFavYxm+UlZ8LMaVGo5PeCGrqg",
+	"mqU/1mp45IoX0e9cGLZiyn4ADCW7ADKTX2rN0vDdUG7m7Nrth5HPc/zWa/Mxm6mwuf4boI5W7WbOWnhs",
+	"Y82D7RcWoaUFXh+YXzIPjFz8ynJjAT4qrrmW6iUXhQWvR89clvVGwJ/9jcpFwT7Y7WT5hq8EuWK3xPXI",
+	"yM2aKWa3gWKE2v8JCyw3bKOTuHM/UKXoLVC7KPvTntWrFdOGFWTJPzwjudzYzcAKYrcxzsgNEYwVmtCC",
+	"VsYOniVYiRnKyyQcsKqxBedS5Mxu68wunNSi1qyY4ze7z4oaacfwt9T0V1wAtzJRbywbxGMARbsjaPZ+",
+	"rnMqtGURAb+yYu7wPr9it7NfEtM0srn/if/GBj/MF7eGJYh+xn9jRC5BtuF6LQLcX8hCOiPS0vyG69DU",
+	"0EXJZtlsKe0JgLvz37+dZYnNik23bhvAX1if75YFdg0Ebq0H/zFD1hrZDafMysj+ZvBLtH8HPh479Tq7",
+	"K8Hkdi/ruWKamT66f14zAQj08uErDQcA14bnmtwwxUhJtSHQ/xmxDEJyWdvTIZfXTCH2+YYRzUXOZtkW",
+	"tIYFJpHDv2eCKWrYKR6BfQSNSu8N05qu2A7Y4z9glxTiKiU3lZkgYLFdJGO3rQ1Vov7i4HQVwweQfl9u",
+	"B8c2ylpDpcHxK08I5KCN9SBQcsreUdJtFRwoPb+Ts1wKPYwR3TRqEXWLaO+iJBolDcw5Xbyhm2G2m4z5",
+	"LcMPrVPQzQS8QqvkDKiDDYLfUgkTB4/9mSyV3BBKKsWuuaw1cZ2Ah4jX40Ecg0LHPtDcEK8h9AgyrBMp",
+	"WZYLml8hHEtal2b21KiadTX10xplEwxFuLBKtKJC0xxAAvWVa2LHYwWxQxK6NEzdUFXofXLGjFV+l7TU",
+	"zP5xxVhlVQSSr6lYMW2P8Q29YvrZ0AXAWOkoGCtIC4FWXQ0rXkhZMircyTLnRWLNHTIGnGH7JEGr6pVY",
+	"yoT2y+dM2KMonieCQTFazKUob9PqhWbKCm17q9GGKqvWwFVib8923LMdM6IlYdfugiQYItvi2bfYT67+",
+	"mimdFlwfEwt8TvMrJoozvhK0tFegMsG2gwq3hm4OCYOrXMpaFMBBC5wNdSdW8mumGH7BkRILSmrRzbwp",
+	"oj0vZX7FxeqMaY+J3a4uCzsAK+aLBPXevTrWZOFmwB2ocZ4Md261mvvP84oX+utvErpwrAd1jjvfOaGT",
+	"4Tz2Dsettktg83FNpGDPCPVwEAc+WdwSbRmIloRWFaNKk1oUTBFG8/Usm3Y0d5GZUt/HdAHbPaFfnrIS",
+	"BRp8RxZw4K9lWeip4L2W+VVSafgy7ojYxrLKUqqpa/VdNgm8/kPekFKKVRujFHmGFajFGw1of0boQjNh",
+	"CF9aGczRwuJG3yfP0UjwTmqzUuzsx9fkybcZ2TCqa7trgdlhFivA/AUgSO39+BZQyBq1drd8UW8WSA57",
+	"eCUXcrQKlwq3jK80kRUT8dkzZY5PuoZ3L95dSnbu38jkLcERbeSUjHpBRc7KQUVh6hE2cnT5GdIi3Uni",
+	"eQ6ttklwmuessuxTrVyPuRflqIc45eAr7UV88miqeGIadwKhGmEPRG+VCqMmr4+uVYJxsFNrKC787TQJ",
+	"l67znGmdOsl7Giy2bObPEqhMkoOV5T9pWYMUEHVZ4vUX9a02kbPZh72V3HM//t+NrHgBd96UWgm2kbQl",
+	"DTW7+fW0uT9mM67nleIbqm7B2pBUbwb05CwaPdWtkpp3Tt3eSThJ+XaNo/miwXtLSNJjwAY+ZKSODNBk",
+	"wXJaa7A/bbFdp8zKbjyr7nVtxBpV5cueyRg03d4llSum5xTo3ohBatie4RuWugw0gE2+mh836zrzvVMH",
+	"brjSbBFY0CyLoW+BNUAqwXIzKCxTUuVFozK/OgZEshXXhilUgTLi9oW23yxp/rX3blUysxd1XDNqtSWp",
+	"yIXfRhezFForJZe8ZPMRQEitwdJONL3Ge4yfxXUmXGjDaGEPPUp+On2dmqhW5US9vllG+v7iABi6voyq",
+	"dEwpqfortfc8J2ij5ZVcg7nWqarNl680Qcud/bEuC9hdCwbXm2cwClgXyZKzsrDXXHsEEV6QDb3FG+GC",
+	"EbapTHwPaoBcS50WiMljNZt5O2DipBm+yv2suGEabN6K/Qr4JLK30owwDkvxcsPpNdFljyxLuiLuKE0w",
+	"h2YGlLLk8TWono5eBeNdCchyOAgaUaQj+YF+GeW0dwhs0rUg0xDmilHDCifEdvMRrameV1TrG6kGuHhX",
+	"HijpgpV/hDv6IGhdbmQxcK2oirHFD5C1QzpQZxHwzCE620pPD1UMfgehLdq0YJ3EBK9EVZsRTggGp6RA",
+	"jek+3nKQxCO0jHimvZ3fwjntLFR4m5H2quN72L2NiMiIRjsGSiCCo9u+ecmoItwkzwnHRWFJ3337979l",
+	"20WOaw0WtGycyULjWcE1bV2I+pw1htoOm3kOA3ynOCCpJ/Toz7ThG2AkJW8St753JRUCz9ycGlrKFfFd",
+	"vNS0HQldLkHaZni00GvKy+muJ4Q6Mf1b/NC+yZJCyUpbkDay4EvOWsaJrc5NxaiLn2jPxfZX++RiBrEM",
+	"50fPX59czFDV6EQsQJRCWu/QMaLH6dc0DRA1eEjSU24oF6mLo2bD1oxcluVISMDIPSWXQhtF+S666bkl",
+	"+YvQMWmb8iyemHL4EiPN3F4sBkT6kKs1fVEJnssGc9EM7YWnCHGilPVTDvqqvDI2Dgw2S46PoSSD2rWL",
+	"K0lJojZDn7jolfbmsSLROgZyQ2hualrCBs6srxJDTwqyqJdLpnRSt/kMHpOKKoq8R4sCbpS0fNdCyRhP",
+	"vrO98aLfC3l6zoU9TBTdMMOUzgjVANWPFhRPgASJgs2ya+mwd4oywnYWpMbJv969Pnr1JrW+yX6fn+0V",
+	"1ZE/g5tom7R/Kk9Q2CZpe5pD04AiOrRJ3Zd5E6MyxlrAJiAOjrG97Q+bjkvhjKgTTK6pW+qr48aAiyoP",
+	"BJZZ5rSAUMW1FGizgNgy+1kf4CeWPGNti617paTijSyYby8aq/aEdSCXzRtW7hkvA6cqKga4FayEDbuC",
+	"040bHTgSIi/szfSKVSZtPMSr2ajASFjlUlqPHwpBK7gVi42dfVHz0uxxEUwXKVGhS3njxHiXwtqTWMiC",
+	"aSSm/eear9ZMG3vDLmttN5zhG5YRNxRZcgViabIPq7O5wtZokyzCXAP2wM6Tyjwmn7pnziaqK9fXs2z2",
+	"K6pVonB/bKi6KuSN/ROjQT6U2oZ4VVS9r5lJBnIN+6dCwFSjtmOI56wXWkvVihkMxiJWDQlWev2+JAj9",
+	"V5q8enN2cnoeidettwCPEYeANLUME2m36yQzdWOyaK/pn/iBmOCV+EqTiuZXdMWsmeSaF/bcoPaGtlK0",
+	"YPYgCvcCvCZwv6l0iqqD6qBipcxpQP8OGmE2G1zPK6ENuLCJb5LtqE82/brIa4OcotJLDCc892uaTqhB",
+	"LMkqhAd1udESwH22Ks8Vu/3/0B2xyz1qBMnID8lPN4pWFUtYKx0G9qypgbhWjcMRxtuVIKGbn7RBSpIG",
+	"tYCD6JgtuQhukY5aoVZ1sNenNk3UczD0NGEbEavaxZztsg1MrcTw3W9sF0h7KyxdHPluWI0WGUGeRahp",
+	"g9aazSEhhf7vFa3WQx61ONz2Th1g2xxrf9QHFo2fzdJBwx0UDMqAEA4+6S4eozOxewfxNfU2HfjBrd3D",
+	"l1rXP7g2Ut2eCKNuB10fQ7tmUl5CV0sdtjWNqP54Xx60/w5FsCh5M4fQ3/TnSSGSvAg6SYSOlo04Xmo8",
+	"qV9SewEjZBixXQij+A5hwi26Jn2QhpYprHRW7+f1PVLAQ7BND+KVomLQaWbjPYJ4TBr7lIt6uphl5GJm",
+	"6qpk3uoX3Ux4kTb3NTbexNCn8ubEK/AW9vQQHoDtHNIsxk2chcWn0IWW0+Pj132cpbM7mju9ZtYkYjBg",
+	"bVFScUVKLpgey6aYfmpNlS/dPAMnZobyByIzT2/BaRaI4qhsA1A0qpLmzMa7MWXV1ZxqQ4zMCNCUCwNm",
+	"MqYN3VTmN3APsg/mv3/ZJz87M4+PK8AwHS6sbgu/wBRw64Ho7w+mZSKJTma/hA6w3khFoMEzIp1l3Nrr",
+	"YL+h1xPCx6gm/3n29g0AFx9HYFRKeqffUWXgSH8jiwQCFzZgNAkU9iLQwKHpYvby7Sn559Hrn07OyKs3",
+	"5OsnGfnbN2n2z9e8LBQTkyVOG9CEyHEH+RCgNk0KHPqV/4kVeC8L0J8evfn+hHzdeNsGYB/k8Ioqp6x3",
+	"nBnC+UukyZBLYFF772ta8iX3gLjQvwhEIpez7A5Pao/05D4qqXju7L89PrB3mHJecPtDMfG8xT5rbnZq",
+	"rxjdbYIbxY1hYmIfvaaKFTuuxHWavhTXYYe1uB67LcawTbXLJNB+lym6/NTgob3GHl57C4rZoUXqrMNb",
+	"XbLGq+ysYIiLX6AxdNiPsjRMzYeUOszIHfjcQUjTNmtGHQeL6+Tt0nbeJgLbhu8A6s7dYkv1vGCloYlg",
+	"KbC6Eu5TocHgA8ZIsN8spFmDZdrZZSPL4gR7MZg9+3P+QE2+ZoWzinLhkqP37DxEKggWW8rSGihBQ8F2",
+	"ELTjop0AjQDX1Kh1b/g+5svloCI7z6U22zHlZTwA9pUO/nKbim5oSXKMBdk1TtpR2XHYzKMvAdsQ56XP",
+	"dnQCzkspq6mGftclHTFw1DgVScUUsQPPsh2GhfD1uprud3D9EA+p0PVzvmG7wlJymrbxLJrDcRtH+XP0",
+	"kxSdyBXT5cZcCnRr/BEfx0te2l1i9cdfJRekGdQqSbi1rA7eaHXQwV+P/kH1mryQonU1avgt+C6SBOGi",
+	"+UpKplFF9jj6SpPQgLjI2QkkYx+MosMISSbSsQ3lEJDe7AnvqSUKUpFZE1EvZBFN3Kw15b97p9geyCqf",
+	"qd94ekLwu2Isw2QNCwE15DB5XMfISrlTrEyxWPLjwhyWrOg2Q5wSmiupNaFlSXCrT8JpSMjvG0MkHMY7",
+	"eDklnNhseg/LlsPWzQ3XQbL20WLFzxzsJVaXlsulZWdKljQ3EszKTw4xgCj2lsbmRFmwcdPBGXtPznLq",
+	"TAewHf5TcpG+K8jauPC76fZ1vEbMvYVAr3mV7GfPuQFZfBKOnk8QxzDuDS/MeijQciSyqMF/H6ro+CAH",
+	"JIBvs5/kNSNP/E1cCB8d3hA6m+aItpOzjbyG1J/5EiTXRK4bc2u4kyl3MY5TRjOKGrYacCCGk3vSaCmT",
+	"YcOnHfBao8dM0iJs6yzp7CkvUSdcGIPqNKpkt7nA9rGpAD2dLWTBWbnrqEiCsjOuqI9OgS37c9CiGJ0B",
+	"Qw/aNUPwt2KWzZq/YJxZNnMwJ73J01XIP6g9+jIceqsAI//rf/xP8gpOKfindFq2RskGyoFnYxLkHPR6",
+	"g/VgXktZgdybLtxAtnvUJYNF4BizzTSKbnQUW5qFPZWM/sjplIFtMx1XlnLZr52pNsysZUF8pZXkjPbs",
+	"RZImD+gkVdv6zR3cpjqywbFswwQdxHQIkNzXsuT5bdoXTdsldI5e2+SYs5PXJy/OZ9kMwxhm2QyLfs0y",
+	"F0Gb3BDDVjSmNlxbHCV8xRCwhvLB9sCwtMpCzFk6blHJkundDmDIEEo7sblZz/M1y68Sn9OuQY+21sI8",
+	"WEn814s4J74jVstyDsZCPZAuxEpmBlyeXGimTPrbIDXkjRhw6DdgdDRgC79ee6umznrmzp0EhlG1yOnQ",
+	"mjAJYEK+qKMGLieL8RgQE0YLaIxmD+tNkawXD5iKEA0xcOC12ycvMZ0Kc6vMmgriqvWAPb9SDM6pG1+P",
+	"yHkWwuXErNmGfK0Z+i8in0YrTNklbX2TylvMk16ssx9fn53bkn32c7gD/u3v3x1+N2DKD577xCenYySz",
+	"8fBTOyyqNWkt+Puaza+59M669Pwh7HzIizx8mRipSbYeGpELw5Sg5TxOom2v7sme9d0WNnhR0RwUHNfW",
+	"XwiBCezhEEYbzrDuNElEMOH38pasXEmlgji5gxGMS8pL5m/0UjBMnrVH3rvXB9XKss3SBcEk/Z1NZSTT",
+	"T6W5AyQ0RW2c66qPhNGoo2umXEhLQuM5OT19e2qP8pdH50fJbM6hCmjZDMrbJbnXAmpBv1nzfB2tRuZ5",
+	"rVRAdoTh3N7BtcGQy/GzwyN8UNgEt2BK1Cxa0e2kYHlJFRp2fNqrZ7aOg7jJ0WiPeYwf0Adp3Yx2PVh4",
+	"0+9cVnB7wzbSiq4lL8udXGc7RNsM4uQxBaZu6IeBq/kP9APf1Bu8mEM6tKmVyEhOqwpplAp8i3OmxDzK",
+	"+3UEm2kD1/teMTs4XSjBX4ltpa3Fyc4B4cQgGqwZKs5L8vqdGzSXwnBRs6Qe92BJGo11MnLWPyUXsycX",
+	"M6DLvz3J0ERp2cT99tT+vU9ghrajXDFadnaKziDAXFclz1lhJTNmpHtmb3nuE0khKbkyH8hwtT8U21N5",
+	"ToE7XKqdhmCCglzCNtSXcaY6iKNL2+5ye+2mCVkWbkelcyzwyIdTdUfVOhXMNqETHGVzQ1djxVG+0pAi",
+	"UTL0VtFVUCRcEeLvDg8PD70d+8Xpif3NJfwlc18/PcSsDyJuto4S6I8+bJyC4Q9npUD/hzqjFfBLKp1T",
+	"MIIfwRYZpUlx4WUT2B72iUVWUxna8QuYtWETKFYxJ7+gDGeDUVB0oCwMK1iBe3WSxyVEQDUu0i4H7pDh",
+	"4qrdWDnlHQpHb45e/9f/f3IXiS8D6VVbIhH9SRTQMQkvTaGc1LWsh6EbPffJwIkqY/IGq6Q2WTRBQaWr",
+	"p0hbvIdZ1ckluWcEL2JgiMllxe1PUmFrzUqYbJ8cgUkvIyW/YiQSFj4nmfhbXAE1LQDjoUYQaaUyxCIV",
+	"2rojgAnHUVPCLPxsaVZx+2Ddihjb07yAjGqrBwC3b6AuuLxxDBAnVidKDcKBMDCfvHE5Rozma2JX7c4R",
+	"uD2e2z+BzN/YvEBhYhTggZI5JZe7tD/ItM+aq6krz040M5pcwtiXnf03LYs4gLKd47p2r1CEuHU2OdZv",
+	"R83Gcn3w6DszitHNSEnYv07AHYOsv6yT7C+B+ngEaqu6QhsNh47qLWd7KzE6pV8NCevhLJCQXhjkjBMs",
+	"hRQsisTXV9xe45I3pT+9kB58EeG04+puy1pXeT9h6RCe7o7Msla5K32P8RVRcq372BBw2AiezE9zDy14",
+	"WyOJM39aBnMxb4zwHbfkW3L04vzV2zcZOT05Oz999eI8Iy+Ozl4cHZ9k5OzknLz56fVrux/t38cnL49+",
+	"en0+MEfP8N18xZXOP+mgcX3HjG3YIsqB7Ss44ETLoQ7PUOUbA5mxnwak6zsCpGsxAUhWDMOI9ud0/zY7",
+	"gWUBm4P+jhuuYWqCtYH3jNyTIppri7eiTY0O7nuE7iKmg4YezmNejXkqCzsuoGDbpj3mdKXoJpFy0nwY",
+	"Sd7e8kICtsvCYNuggQy4PiwxQabLu3jgNDMuSrZjdh5m+m0Vl94/1QY8tfoza9T90duhOqrqH6js1uL7",
+	"HUr4BZvgJJx0LNopsQTR5AM53+/LARmwGhAn3JSfUAcuFYWDQ7Xx5NP5ogw+ACWsYreCbg1pByq5TS/R",
+	"1qHmeOO7p+EWOo2DM0S07p5xBBl6eeIMwH0RAnsGwvB73g+X+NzUL7aSNEsFLOFcyZy5Jl5/2/go2bN+",
+	"sNLI6P1opW5cUhOz9Mvg80zDYUO2w1PiVGeyd1EfHv6dkQVfcWEwYsjnmz0lt0yHFkLuGCKUVsMwrxHU",
+	"rmeIkOCyzMBeBk8RmFqltTLoHSOn/XyRP/RaZcBsBjtnN1A/xDDFacl/Y8Xc/RY5TGHMFE4dzw9mXNpa",
+	"57fh+YIQvLOLKpTWcChUerD5bri+rHk4rLW+8d3ksBYlkPWji0Y3Wjo2EIeZLltam3bHghSAlFEagBEW",
+	"vCzRNSH4fvxOB+s0K6xyV7CKiYKJnDPrBwxldQUYnrciNWhnHg0BynE8Dj/FE5afdNqCl7GCoKLUzsAF",
+	"TyOBBeOMu9wEQMyu/bq4wMnDaOMYgCFGqggkY0GPO+Wlm+ahjmW33ttQ+dP23RMDd3yLcAelG2/6eEaK",
+	"wbmtpa5WEGoY6wnby0k7bCi+TD2WpuRmGkXOBK30WppZ+5HJVAQjVKsCqwQ2xA3jThL38GZwMMM3K9Xs",
+	"3jBrtpnshGnkRTINa9dlde8QFjPNUof57Hsl64QNooAinLuKrLdh8O6CmC/OlAreD98ID7WJuCtmHaTH",
+	"nYDh65LseInZOqw7Gu9uxN4BfHdDh2xrPZI/rpsXZrkimKERDAd3A4YPYk2Zep03VLO8VtzchojXBioE",
+	"xv51C8YIKe4MsCYedSja0331AjXIRJAGFcaDIoCuzZ0zsrYyXOR3yMO6XoR1pp7ljD+nF66Ye1HUaRP3",
+	"uf473sBG8dWKqcS6z92Xh+C84F3ryEdRbzJwdEnNDaa8KYi1hw7PiA+KxdIbnJXFXUF0l5JnyNqDcyRF",
+	"XixZY573qMrCOdUT7S05FxE4EjytU6mz77v7YfgAfRtuWndQym6oogbebyhxy8hQIN7a603V1CZh9v0t",
+	"DXVcehU3+BLCMZwW11hMd6iltlOFsPYMw+gLGszYranjVoBUECtYXBN8eRaz6Xw4o/bjJhOdxgtx/REL",
+	"4lCZhZFXARzISW0eTO9uKcHdZ1XuRvTSK+ZLB4RH+UYiiv/IM6pgAxwq2+UL9ftp4gtfhNHtrDBg9xvC",
+	"YPLW4K+7xz4D7RMuDn6M1t2h9Y5RN0i6FoapwJVd76yGiGjUbRfM3DC2e8iW/eMTLwz95QzcGcbfRArj",
+	"vFVF9zEHzMR0mezB8BT/uGFU+L8tNiLP8pobl0ibsix1g9b+ilD5K0LlrwiVzxah4sI+EpnI7YfqqCCM",
+	"qpLHQbAuOyed1TrysMjW4JU/fcBJnNNwlxGC/bNj19qmD6lSfYrSsk1B6SLgLnUU+0cCoXA6TpPxzdHZ",
+	"j+ZRNBh/0R+LrzRr9zi2LxkAFXxITvM1i1/nFVIwDMh1Fckm5OPDGT+5+MlAjt8b2xPugtHB4C7h4NGh",
+	"wkB0fEnzXpaMHnyPnk+tHefPk+m17Bblld69Zh702qWmXVT8aQJ2pz3l5lHj2jevD3sVLczaUDcIku7y",
+	"E0sb3VL6lPnnyTre6RAC3uOOajW3sm4e6da8Zz1mIy6H1JGzg6FjXB3vYDcOZd+qXEvFinfuBZNdHnp5",
+	"XFdaLsRu4Su7V4mOika46XaQ5h7LTfo6Lcu3y9nT/95GeN9z9jHrx1v5m8kO5Qk7C3dj9KH+pQX3TyEa",
+	"ctr5M0aS3jPl0DCJt8gk1p+bCcvjxZ3VW+ja4XcI6iylmQ/OuKvpOFr0tDi2TukFj5eehXGkwEJ/0h66",
+	"QZLEAR5ccOO2iL1IgPiG+8Qsm+lbka+VFBw3jBXKt8lr9lBmeMp468XZwArgvfLhEuzjT5/3x7vhJl8f",
+	"Px+MChgRYR3gQ8sU3Od0cWaSe8t2S4TpVPR9zbAMNc7nX+Z24cCkpLeyNtO83d13BSc9jrB1P2x5reMu",
+	"ktRd49Zcvwwt8JUvtnf/a2veY0/LJa7nTdTzZLGVxkDrvZBm4BYQIzhJPZYNQUxzzX9jQ8Xn5r5u1lT9",
+	"0U42PCIqegOfey+qh7atcbMY7A6Qg+t/F7mDB8+VrX5irolr7m0eZjCHcClVPnFYWmrZRHnIZmDihXx/",
+	"+Ni/Pa0QqoQ6VNtOl+Y4cQuIphrE7qm80Y/EcFq1C6tErGm/dFnvYS18vpLgoJXxr7zTLValGIOO1jFh",
+	"kwzaJIjbk7fW24xLvdI07htuy2a0rzTRTMPbbVwT1GuJkcmyAx8qrph216NeUZHe0OSGlyVZsNaDo2Au",
+	"IctagYsVrRaqhqgmLzFaBhRq2J5zhaQrcIb72uQ+DnvtBaC5ldiLuwaDLFjd6SLU1WqtDAvqkk2tTWeB",
+	"kU+HFxgIJOZR11nmphrSLCe9iOraheWkWQbc4Ds/9DemKQweM76oDCIKJiZLrpj21dmhmgwy2tzHwMDf",
+	"soz9YFLxFccn8qANVGW7obcQxoBPpqcxx657xgk/5mAFwmx2fvrTmxdHA8UIhwLYkpiBwzCe9fTtz7Ns",
+	"BlXTfjh5c75buUN8fjke7vnJy7enFuSjl+cnp1hX8fzk6Ji8fZkYekD7xFEDsjzUnbB1f3Ru01JvK3Zk",
+	"jOKLOnUTuEOFeWj2xjDRnpp6qJL+Zx8hZIckTVMrgCCee6q7ub3+Ae/n0D7zLyV58sJ/oQtCN8tmEL2U",
+	"ZBswYAzEQBH8mFzNVg7f1KXhymeMdEpUhW+IOcgjwH9/HRUafPLt//PNTkW+xuL060W66PZJ6aq7tAHZ",
+	"+UnIwdcHo8M/UUoNFRZXssOqMNY/mUubikPzXKoCbrWuYAtoAATzZNDkDIVrLoRTg3V4oEnntKRKZ8S+",
+	"T3tg/28RvqFOlYXHnfDnC4H/zuzITPHcfqDC1SErWM43tHQ37owsbg2jtsXFxYc9qMf2gRVkzT5kF8Ke",
+	"mQdQN95CChO8Onu79//+++ET119n+LqUuDVruz5Waij61tTA6r4sBUUKl3L0ra0QobOwuhFT5Ojdq/0L",
+	"cSHO2DVTUOHYay7gq7WnrayYINQQKXK2T5ytQ7ukDChkuLglmgkgAzf6QjRVny//tfduVTKz12hEl2TN",
+	"aGEj85Ufyj/TbnvgR1JDACe7EJcukuMyAg3X77LRZpWdwC4kegX26exw/8n+Ib7EygSt+Ozp7O/7h/t/",
+	"B9XPrGGDwuPdVk+4doULXTKFFW5wXL4qZk9n3zNz5NtkM1+FDgb42+GhUwWNkzy0CmctsFQ7N2SSoAuT",
+	"9fXeXnk235aUXKPhVtcbtCzMTmt4QhyUPs40RgB4J0VYte3TwsPB7xUvPh6gox6EvdQm7VSn7h0YKM5H",
+	"iz14BibioP1Z1sHlCxj1Oc2vGJbjDWXfwMzN7ciWPD7d6emsAr2rkSr4kkRPijWvA/3yB2k0RhoH+Blf",
+	"CVoGa/nHbEQ7c/ENBSv5NSQvwY8wQIdeiJuWjwj1dZC4C5yafF2t5kibufvpm0EaGqY2XHjD4R2S8dwP",
+	"/BclU5QM6GnoFt37LAUDZVJELK65liqSR91yhJVURmMyIdNEWNFtTzO4S2LYqd3jXBueu8pVUKZNMc1M",
+	"Roq6CYnHEeB65f7G5CHFsAznkkNBFxeim0Xx808Os8PD8IyHrwtHXc0suTRM4Llg46MNt+eiPU+gxvLi",
+	"FqfDI27Z1PbQ5GYtdVPRQUhsGBcS2Scn1pSx5Hjg5FSBdNP1aoUF8Y+PXz+z/4dLEYwVmlDya12sUIux",
+	"/uOM6Dpf2/MUrRtebZAeMA4xcra93Ri1O3L7B4Oj1T1yqpsCqZ7i0ZeICZ2R0h7J2rg6hxZZXKDhBzSv",
+	"j9ns2zsErFXjOgWYlS1OjLCis0XwyQPFEMmFy10JFai7PvJmc/ADX145Fmptwhzx730bFDhMm+eyuL07",
+	"moQJvOvn48ePXdn28T6ZIgJgmADfh0LUZz++7hDAfyNQd9qqBpC4TUsSXhWPke62l/d7JhWlI34WtbrX",
+	"1UcTjSHgLAiFSslNZfQQEtyzsPbtNDxzo/USjBiVomHmNGMautjz964hxjynizd4K7ofvnTjfza2DPNP",
+	"4UrriQSEDVCFEr2WyoR2yKeelxHtGHExrOCcr1nH7Jko/8k71T+xZntTJjJUB4XjmsAxWgt4O+3SfrC/",
+	"XtpRoH5w6qw4Qjh/dDFM90J8nOIzkT6uVZy6qCBwDqN4FP3H0JgByIMXUQHwU7+WNrd0C7xSkGhA8oZN",
+	"3M4d3pnufnpPpHGjfybSNHdvcOgmqNO0IGBCeGhNIZrfWes7VyP8bvUzShLmjB6hndl46D7fwchjwr3V",
+	"qAuum6MGw1m6EtIEHSnv0q6Pir1KySV3AUZJnLzmOkLKO9/8IcwdvWmn2D0ipLm1pSwgdlXurYW810G7",
+	"hzNSwkAxalgfsHsVDs08GMc9SUg8uT8okmjHGMYENrsbFhoSOtRyiEEPfufFRzzEffnGNmmO4fcUabYb",
+	"IKbZH4It+z7ND91ItKTuCk1QFn/7sLc2CMPvkBQxP0DSbIKo/UJJNW1jJHHyKOhmz4oU0awV5tUxBrUm",
+	"SIfRvA9HvcclV//UOx1JP1l4T9UqHlqdeOU02R10CVDArBHKmlRv0fMVuZ3IUfQvZ6p0VdhslmVZwBMR",
+	"C8xLstdBq5OwwpVnEiG1NIRY+Xd/4CUNrs1+SoHpQKEbGnj1d5wCx6HVXeF/W4J+D8W4jmXjfrSCQqcW",
+	"a5/nCstq54hG6y7Kg9+tSfPjwe92pI8jtvJFze0Lf3YYl8kc5eeAadvVc4wyZKmhpVyFWBpM5ULvMpiE",
+	"dYYxlmAnWCkqjN4n52gah8dFi2DCzkL9sYzwggkDoZu2W/RInGvZFAXU5OuWVZxqcvT6/OQUs7C/yZoC",
+	"G+Bp1kYqumKtR5ScPT/z4UE6GDVCyRJXSElDhRMPhl2ZZXTXDbhYyitWkLqyaAA7jDMtW5QgezxzO8b3",
+	"kktnfbetuXYPbLEis0vh2u+va6ZKiVuhqSQ5YGrHmibHx68nHUGWO0YPoW4JypEikx5hPm4qVG6JI3lS",
+	"QUEj5TMzIit8p6u87RdG+brolQ20UR6JdYrGmPj5VaWGRAkZYD0y+O/P64iATPZaXAl5IyK/yAOeu54N",
+	"Bo7fU4aCoM6dNwu3Bw5PfNBMkIXBVjBs3Dpu2jwSTad9swjgNTK4b9lxVZUOfsc/Po7Zd46hSV9QdNji",
+	"Dvdh4Wd8HDvRISC1DeGLV1sez+3SgxViIAPhGSYnDrO3y168T9t6623Lx2VZd6sPlvWkcTxpFHeIPahK",
+	"2sJuR4WybhM/ztf/PDl9/vYMX8o4f/Xm+7OMvHx7+sPROQTIfWM1Nko0F6uSxS/M2Wcpxaqx0YuCPP/p",
+	"5cuT0zPMJLh0fhxwooB7xTZBrQGVt4oqbf14JRWoT1dM7cFr8hhmq7NQL0DWUNZZFOH9fgLcovfJkQgP",
+	"uzfgTfcMjTh9ntmn6xmKbfseun/gF2AN7Z9i40sXltB63zS8I/3t4X+Ex06/wcf4OrA2eURQy8dfDdyz",
+	"dUQzpqMGcIHohtO74L6mHgtUcaHII/vALnBdwWc1vP5GuIBH0ij52+FhADKlsTm2hLTk+9mTIWn5s+zK",
+	"bsp0b1/alYOu0RQVAo6ypdUwrISbzx2ScfdeOBqxKtWEuuBd2LZGMdaSPr7CQlruyBv3WCy8AseKvnJg",
+	"fSAa3zt23loA2E5s1uwWwnX5NcMttJAF5OKtfuPVnl2mYtpKlEQmkyj0hbg8ynNWmb0TkUsrvJ5Cz8sB",
+	"XpfK3Of5gzPcI6t3QrI/Zq32rj7CsC7Tbn4tin1a0XzN9iuq3tfMtHuH1KEFFzT1dnNiPCvEPmxK7Kr3",
+	"5HLJc1bIvLastq8rxWih14yZTbkP//1jU37YE8UglqI+NmrkINfXk9ptqLoq5M2WQVMnPKZFQRo9b3Er",
+	"K4hb2kOLkue08ED8QUGSzb57SMBhnxL2geX1sMcZce4jR5j2lwBK2mbQplpr3xTVu5aEouKTTBiP6mrf",
+	"gJ5kUPfx0V0rXoXqOyxagKNduzrvwe/w31ECumfozp3N6MFul95I9Th4oYWFVEAqfnfZ6I+NJdrQuaBu",
+	"qxEjqSLzeqjzfPC7/3OcO1yj47jmwvZ9HiUDPhL69teRorJr1b2ztz2PfnFQFVrJnBW1ih7uKyJRuuba",
+	"SF92Le39f1Eyqv7h2j1GUxYACE4MPDn8moYc5Nbn0awnxSu+6pljlpJvsJxZ9N6Mq3T73WG/tsbHLD2M",
+	"XC41GxgnNcx9sptb/xiyXRPChIFgfyx7b9P68baf8iR1COC5zKfIDaZ6VdV9x4T5KVJhklXVRHF1fPhV",
+	"RVx6G6ZNMHhoiyxLuoqEFtRRHPSHnWEOiiY3lBtrkcFHvGynLCSIwRAwR7WaQ2FGLlbzihf6628yIlDr",
+	"rEUR8mLcmL6p/XWDly4jq1C3xLWiCq0cSkqDXZjST50hAu7LIXsG6jxaUg8nqNkOo6lP9mxVrGT4IgSD",
+	"hWuyYIJRsybc7JMTW9HCvR4l4+oMDkcD/qjXgOiHcGk/d3h1xJvicD2NkRu9v+uJAA9FwPdHlRXyPHCQ",
+	"YpBprFP8iiweON4/xzfmA3/r2vxBclkzJupz71oZ8NsfoMAnjfrltRIHS+zn0WRDqw6SjsoyfF3ZYdE/",
+	"jfOhl9fOEfBjbS/DEuEEPLG2TWPkW9ySnoEYDLNQ68+KgJuQZoT7fCMhvyxnwpAnh4ekFq60CUwOe/6K",
+	"VeYZSf5MamF46YtOp/abpeE7WMcWrfutzV0srfyPZ/JadecUDDUae3pWU/btl4fY4a0Sjls3N7bGlWVx",
+	"8jRXjaEtGV2ho54dBgGwqBrJpPiBmnztDMtCFs1jAuZGeoKC0R7SEzNSMQXtMv9OGWYAbphZy0KTr6Hm",
+	"/Bl7T87sz0YSzAmz//omI79KLog2ihq24qwJaAhhDDgo4SJY+W0ohTa2gatPtJBm7SCDLEhv+s8wSkNb",
+	"O73hm6T9+gWiwzPdfRj17Nhums9kxG4g4Dqt5b+RBXMneOHehXvQW5yFcNBT7pAHDBizNrAdBKu0LhjI",
+	"59MijJ3f4q5iHR9Gk/50D7yP7Y2wOBbU+2Vip1thd1y0Pi5WxyjeiDyo1HGjY9/KaCzv/RLt7sVjr7Lw",
+	"Q0fuRodyn1oI02NklXdQiwhVsA7P2LNTMwNsg2WZG+EY1QGeYMqOnhz88ozZMfAp7DafH531MoYNq+Jp",
+	"V5ShIWV4vWBbRuyG3toYgPBwLFy4sA5QEyQLCj5kz4YiV0SKC3iEIQ49sNBArYEVMxrgsgF2TBh1azte",
+	"OifKJYItLoQzzeyT57c+SDbyymgjK00oFgXCggY+WjrAdiGiRF2sWmFdbFbTxLdgngG3X0oxBwf8Uwuw",
+	"4aJml2AWqMGVtrHD3N7Q231y+eLtu/8i+/v75OXp2x/I2fnxqzeXES48xlpxwaLkgkFJJ9rYLuATJZcX",
+	"F/uXxDbAyFdBKv2+hIpLp1Fwy81aliHb2NegWgS0QG3OIrikn5KUpxKWhL5ywkMhT5hWWvjA7XUG339g",
+	"WkO8MFMAmyupEdZpLbVFnTPrv790ccGXF2KD3Sypb11traZOKMnXtbjSGVYJpeSykIJdWkF0Cbhv+j+L",
+	"ESrc2zxuxiZ46NKR8JK4boC04yjCJhrFUt6PhHdJF1njC526sJoL0cTVJHT+0/pPEkQ2xde9dbQWLw07",
+	"Wu8h4xuEBEuFtME/E7WrUlWo7pPUOMPnyvd2kw9FJuH3Eg8RWZtc9kowYBNC7Y4KRcQiPCuG3fWaV3ok",
+	"+6IRcU3lINoyVVnpHqc6uEcI7YdNRqRyV/lL6A/hdit+zUQWD+rSES7XstKX7eHkEoUhYRyfiufKJ/OE",
+	"hA0Svz8aVUBSbMkUE7kPPZKq+QmmLxS9ER4I28MlfxROoMZYCoWKmiqFOiNv3xAsjgrIePuGuBf4MFDP",
+	"1QC8iYpQeQCwpj+M4ma21pFiwFZ92iLXFhOaM0AaCetrP6CPWuKAOS3UeRwJhOlOdqzoDYGCY3Z1gvHV",
+	"eiFrtZayCK80A4JHXekDAIUXIabD8zOYynHCtbwhG3vWtXNxbugt8gOHggSoCwxAYDky7WV7ks029APf",
+	"2CyUJ4fZbMOF+8cDu99i3vhe0WqdEhlur6B9L+alRxDA+IBaOaCBiC2RBVfslqwcJlPy8qDgdKXoZkRu",
+	"ioIplJswUN803xrQ6ng/MLWhvCBfM3WMw3+TEaDnNf+NHL89t+LL3hvNTz+8jqqIjsoKN9JfEuOLkxgD",
+	"fn8XKzglMW6DDAXvmdse1oBg6k2ZynR7KPnk+TGV14KfXGjJX3JpTC65ssUnp6QICHWCCqql7LmSsaMu",
+	"1TPb8kfXcFLsSvRQ2v3Zfab52zzsk4rsQmtnMhmvMuPxtqWyTDT9PZlOwwSfpZZMjN7hIjINym6Hqsd0",
+	"m/RZdKI7p4Xx/2MrxQz4dGIkDvt0vmAUjbLb2SCbOZ9K831SSZQHwNMjEQiPgIlDKZK0JIDhD8AxPWju",
+	"dk7i2AqRNRfwUCYhKr6QEZv4r7MQvOqSCUW9ceX6YysGF+B/9oeb9g8mOVOx9SHwJVzaTau6vlT2c6sr",
+	"UdaADpWllaxXa5KyscdJkRgD4QK8KNnwFbKrN+iDKaNp7sJuuQjPhakVM/vERUc5A7oBEFypB0gppEQx",
+	"ASlYrlSGXkNylgV4Iy1hLGC0KNIGiGO+XOIV4b4iKXB0O89nMrk1ACRPPEcmi6UujR5cU23VsOFogZcV",
+	"EwOxFYHPg2Eu5tjeXtTuLfJho6B1P3132IoYC51CRFg3xuwotCFwvbtiAuyCUPqcdlZkV2N5VREND3Qy",
+	"HQDO7INYTdkS3YQmcW/Ug1LpYTZfyMQPMBSchgxwFlb/IFpsa85JmqyHrxs25kvejASNOTZoCLxNyW1D",
+	"d58bv/0A/ke39+9Lue1gfUTBjSjzaEJsIaqhVWQdPWo1VO13hXkcHIObe6rW3WWBP104VXvPbEHoQaH4",
+	"0mz1ptxYeeTlli/w72YCK1MQXpe8QK9w+MHIS8xJl20WcNLQBXS2ipyB80WC50VuuDGseIpHPRxnhZLW",
+	"SzuoWEGjlm5FS4OvZ1hNqimPBQ3xSSNecvvEJPR0yxxUyPQ++UkDqt0av9Lk0qbYc1nruV8/Y22swaq4",
+	"ITfUHSb75DnEikoOXn73zqBDh6abFk5g3SPngb1MoT4A9LwPth8w7xk5+3y7JVryiA5UBU/bIygDBdRt",
+	"KR9yGanrbTUnkqG42bz2jbrD8DbfYkeLNOPPWpzPiZDB0nweG8SvqlkmJFHONTM2R0OPxY2dQdMz3/Lz",
+	"lc1BQIiH2V5m3rfiFdphV03Ncd3uGGEhxKMMinCLRx9ArzZYZS68z+UirFLvn4QMHq7CC17NdJgDB1X/",
+	"FBVXeHm7hEit+eL2cp9gjgf8AC00WfPVunkphn3IWWXImps5UMtfYavaQWuDnbS99lHFij1XZ2fNDdbV",
+	"cUr6gCRssDLJSOzBnmUT6RwmeGt7DsvGXTMn7zcU2SNl+FmfUyRlQ+Zn5JJeU16GQAgoJdTULwkZ7v5W",
+	"x33ye/d5KlmlH4Vr5kpx9QG8H3XXT4md2kFbTPJoSvF+1qMpsc37ZLVQ/v3hoDxNkLRXvFAzMyajkpw1",
+	"zWrwtx2tBumLup/1ge/q3Wn/2HU9RuZAjlefBNOv7j1g7yvCvzPPw1zg+6R47Hf46RJhy13/kzfm1Bt/",
+	"gnP+bJf+kZ03AccHBSsNnRRSGWVAtsK3v2qI+qkGgdA/df8fUjPP2mHdObCkBc+bjq2cLtnSwIOKd33j",
+	"9pMfA/4e0aU7+0vR9VRJCVrkNAJs/9kVrwkydifDwYXoieRQHdE/3TxiPqgXAVa9vdR95OiLOkaJglGK",
+	"V1MN/lbkayUF/815pQxU3liiTAGzHqb4R9sRbQsWM343Y3aLAafnWpaFFYNU6xuphkKSzyIQv7wMshb0",
+	"ySMiIsBjyyFrASeXLcHfr7uNPrRiMZxOcQYt/DMT96Ur4iTPv5Q39KBSiWA3bYxGRID1YEnBvuBo0D+9",
+	"OB0EAb5A+/ukHfXwVeUmvuBilzDt5RbbMhWTZ0N7YHn+ZeemSJkewu1B5KaYgOem7ReM685apiHdt04h",
+	"/twhvcHOELadg2grpl+5dl86lmEdUxAMDYdx6/E2jNfxAmcOmKV8pBidgMi0APbo6VVPww+a/8YwgEre",
+	"hJIxw2gML+zskN4WqVqhN6iLXKyZ4oZaRS9f87JQTGTWjlUrW4SmvM1Q3er3Jwt7gGNNG3uihA+Rz9WV",
+	"x3cdrtjtgMr1zrc5V4x9gdQP8L/Bsi79xP2ABF8O43Hk7Aew1pwpqvL1LUYQGldBdYADZclzPkFCvvMN",
+	"v9ANHeBPkdR98zs3VBPMa2UvT6764SOh9GkfQE/FKRS3ifRbqW3r4t8TpXc3KDw5vMeiowPDaKnMHNW6",
+	"2SesBrqD8WWWSns6Onsxy2bHJ2cvJj3phWeA5UxwIeNrB5fXtKyZvoyf+rMZd65UwlDume2bxg54+rK7",
+	"L063dWtaZht54oKuXLEL4Nyu1PMfXR1oaugg5/tH6rZy/7lv+CWrg7iGKbqgazqsDQbEdTALFqzt6NRn",
+	"0OzzhWHENSJclAOsC8HvF77E1bWVuaatR8C2hdtVs9n9bp4zE2pW9fRUZ2XDMi+lXCRurxVTGl8PNb79",
+	"YGKKze5oreruzS/tBX0BmSMWJzHqAnc07yCh+Jk7x9YguzQdLAbqyaJn/ohSpPqLSAqb0Iho36qfMIWP",
+	"Spl042EcHyzYio+8M/aOC90O5Q/v/fxr792qZGYvMrFFzUIyy2Kf/NiPpbI8UGtmrdNYGgh+jKDn+K4r",
+	"uLagxkfz3hde3tpPgNHayA01PIe8cro0TBHNIRiM8KJk/bCD53bhEXL/vAz0tmLdV9bw2TW62MY9SKLR",
+	"NK8NN6FEBYN8qtQjafi4bQMAXUjl63WFJ5rbJPdqXFNtDF+KWsLLz3BqfXv4H/upGrIbbv6ifEBFSnps",
+	"I7x/Om/Y/3DqWvyFaIcMJ6hGcW2j7w9+t/8ZP/1uK+bKpT7kezcW7Y+GLg0KUgS5rR7fGzcnot5kBEbU",
+	"3DA41SBWxFWG//jxfw8A0u6RnxI9AQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
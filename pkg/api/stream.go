@@ -0,0 +1,109 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/macleodmac/pglet/pkg/service"
+)
+
+const (
+	ndjsonContentType = "application/x-ndjson"
+
+	// streamChunkRows is how many rows are sent per "rows" message.
+	streamChunkRows = 500
+)
+
+// streamQuery runs req and writes the result as newline-delimited
+// QueryStreamMessage objects, flushing after every chunk so the client can
+// render rows while the rest are still arriving. Each statement of a script
+// produces its own columns, rows and done or error messages.
+func (s *Server) streamQuery(w http.ResponseWriter, r *http.Request, req QueryRequest) {
+	rc := http.NewResponseController(w)
+	enc := json.NewEncoder(w)
+	started := false
+
+	send := func(msg QueryStreamMessage) error {
+		if !started {
+			w.Header().Set("Content-Type", ndjsonContentType)
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if err := enc.Encode(msg); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	typed := req.Typed != nil && *req.Typed
+	chunk := make([][]any, 0, streamChunkRows)
+	flushChunk := func(stmt int) error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		rows, values := toResultRows(chunk, typed)
+		chunk = chunk[:0]
+		return send(QueryStreamMessage{Type: QueryStreamMessageTypeRows, Statement: &stmt, Rows: &rows, Values: values})
+	}
+
+	err := s.svc.StreamQuery(r.Context(), connID(r), req.TabId, req.Query, queryOptions(req), service.StreamCallbacks{
+		Columns: func(stmt int, columns, columnTypes []string) error {
+			return send(QueryStreamMessage{Type: QueryStreamMessageTypeColumns, Statement: &stmt, Columns: &columns, ColumnTypes: &columnTypes})
+		},
+		Row: func(stmt int, row []any) error {
+			chunk = append(chunk, row)
+			if len(chunk) < streamChunkRows {
+				return nil
+			}
+			return flushChunk(stmt)
+		},
+		Done: func(stmt int, result *service.StreamResult) error {
+			if err := flushChunk(stmt); err != nil {
+				return err
+			}
+			switch {
+			case result.Skipped:
+				return send(QueryStreamMessage{Type: QueryStreamMessageTypeSkipped, Statement: &stmt})
+			case result.Err != nil:
+				msg := QueryStreamMessage{Type: QueryStreamMessageTypeError, Statement: &stmt}
+				errMsg := result.Err.Error()
+				msg.Error = &errMsg
+				if result.ErrorPosition > 0 {
+					msg.ErrorPosition = &result.ErrorPosition
+				}
+				msg.ErrorDetail = toErrorDetail(result.Err, result.ErrorPosition)
+				return send(msg)
+			}
+			msg := QueryStreamMessage{
+				Type: QueryStreamMessageTypeDone, Statement: &stmt, RowCount: &result.RowCount,
+				DurationMs: &result.DurationMs, Truncated: &result.Truncated,
+			}
+			msg.RowsAffected, msg.CommandTag = commandTag(result.RowsAffected, result.CommandTag)
+			return send(msg)
+		},
+	})
+	if err != nil {
+		var qe *service.QueryError
+		var cr *service.ConfirmationRequired
+		switch {
+		case errors.As(err, &cr):
+			// Checked before anything runs, so nothing has been sent yet.
+			writeJSON(w, http.StatusConflict, toConfirmation(cr))
+		case errors.As(err, &qe):
+			// Rejected before running — report in-band like the buffered endpoint
+			msg := qe.Error()
+			send(QueryStreamMessage{Type: QueryStreamMessageTypeError, Error: &msg, ErrorDetail: toErrorDetail(qe.Err, 0)})
+		case !started:
+			writeErr(w, svcStatus(err), err)
+		}
+		// Otherwise the client went away mid-stream; nothing left to tell it.
+		return
+	}
+	if !started {
+		// The script had no statements.
+		rowCount, durationMs := 0, int64(0)
+		send(QueryStreamMessage{Type: QueryStreamMessageTypeDone, RowCount: &rowCount, DurationMs: &durationMs})
+	}
+}
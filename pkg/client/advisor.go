@@ -0,0 +1,308 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// FindingKind is the kind of problem an advisor finding reports.
+type FindingKind string
+
+const (
+	FindingUnusedIndex    FindingKind = "unused_index"
+	FindingDuplicateIndex FindingKind = "duplicate_index"
+	FindingSeqScans       FindingKind = "seq_scans"
+	FindingUnindexedFK    FindingKind = "unindexed_foreign_key"
+)
+
+// seqScanMinRows is the smallest table whose sequential scans are reported;
+// scanning smaller tables is usually cheaper than using an index.
+const seqScanMinRows = 10000
+
+// AdvisorFinding is one index recommendation.
+type AdvisorFinding struct {
+	Kind    FindingKind
+	Schema  string
+	Table   string
+	Index   string   // the index concerned, for index findings
+	Columns []string // the columns concerned, where there are any
+	Detail  string   // what was found, e.g. "Index x has never been scanned (24 MB)"
+	// SizeBytes is the size of the index for index findings, or of the
+	// table otherwise.
+	SizeBytes int64
+	Size      string
+	// DDL is the suggested fix. It is commented out where it needs the
+	// user's judgement, e.g. which column to index.
+	DDL string
+}
+
+// AdvisorReport is the result of Advise.
+type AdvisorReport struct {
+	Findings []AdvisorFinding
+	// StatsReset is when the database's statistics were last reset; scan
+	// counts cover the time since. Empty if they never were.
+	StatsReset string
+}
+
+// Advise inspects index usage and definitions in the current database and
+// reports unused and duplicate indexes, large tables read mostly by
+// sequential scans, and foreign keys without a supporting index.
+func (c *Client) Advise(ctx context.Context) (*AdvisorReport, error) {
+	report := &AdvisorReport{Findings: []AdvisorFinding{}}
+	err := c.db.QueryRowContext(ctx, `
+		SELECT COALESCE(stats_reset::text, '')
+		FROM pg_stat_database WHERE datname = current_database()`).Scan(&report.StatsReset)
+	if err != nil {
+		return nil, err
+	}
+	for _, find := range []func(context.Context) ([]AdvisorFinding, error){
+		c.unusedIndexes, c.duplicateIndexes, c.seqScanTables, c.unindexedForeignKeys,
+	} {
+		findings, err := find(ctx)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+	return report, nil
+}
+
+// unusedIndexes finds indexes that have never been scanned. Indexes that
+// enforce a constraint are skipped, as they are needed regardless.
+func (c *Client) unusedIndexes(ctx context.Context) ([]AdvisorFinding, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT s.schemaname, s.relname, s.indexrelname,
+			pg_relation_size(s.indexrelid), pg_size_pretty(pg_relation_size(s.indexrelid))
+		FROM pg_stat_user_indexes s
+		JOIN pg_index ix ON ix.indexrelid = s.indexrelid
+		WHERE s.idx_scan = 0
+			AND NOT ix.indisunique AND NOT ix.indisprimary
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = s.indexrelid AND con.contype IN ('p', 'u', 'x'))
+		ORDER BY pg_relation_size(s.indexrelid) DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []AdvisorFinding
+	for rows.Next() {
+		f := AdvisorFinding{Kind: FindingUnusedIndex}
+		if err := rows.Scan(&f.Schema, &f.Table, &f.Index, &f.SizeBytes, &f.Size); err != nil {
+			return nil, err
+		}
+		f.Detail = fmt.Sprintf("Index %s has never been scanned (%s)", f.Index, f.Size)
+		f.DDL = fmt.Sprintf("DROP INDEX CONCURRENTLY %s.%s;", quoteIdent(f.Schema), quoteIdent(f.Index))
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// indexDef is an index definition as compared by duplicateIndexes.
+type indexDef struct {
+	Schema, Table, Name string
+	Method              string
+	Keys                []string // column numbers, 0 for an expression
+	OpClasses           []string
+	Exprs, Pred         string
+	Columns             []string
+	Unique              bool
+	Constraint          bool // backs a primary key, unique or exclusion constraint
+	SizeBytes           int64
+	Size                string
+}
+
+func (c *Client) duplicateIndexes(ctx context.Context) ([]AdvisorFinding, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT n.nspname, t.relname, i.relname, am.amname,
+			ix.indkey::text, ix.indclass::text,
+			COALESCE(pg_get_expr(ix.indexprs, ix.indrelid), ''),
+			COALESCE(pg_get_expr(ix.indpred, ix.indrelid), ''),
+			ARRAY(SELECT COALESCE(a.attname, 'expr')
+				FROM unnest(ix.indkey::int2[]) WITH ORDINALITY k(attnum, ord)
+				LEFT JOIN pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum
+				ORDER BY k.ord),
+			ix.indisunique,
+			EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = i.oid AND con.contype IN ('p', 'u', 'x')),
+			pg_relation_size(i.oid), pg_size_pretty(pg_relation_size(i.oid))
+		FROM pg_index ix
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_am am ON am.oid = i.relam
+		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg_toast%'
+		ORDER BY n.nspname, t.relname, i.relname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var defs []indexDef
+	for rows.Next() {
+		var d indexDef
+		var keys, opclasses string
+		if err := rows.Scan(&d.Schema, &d.Table, &d.Name, &d.Method, &keys, &opclasses,
+			&d.Exprs, &d.Pred, pq.Array(&d.Columns), &d.Unique, &d.Constraint,
+			&d.SizeBytes, &d.Size); err != nil {
+			return nil, err
+		}
+		d.Keys, d.OpClasses = strings.Fields(keys), strings.Fields(opclasses)
+		defs = append(defs, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return findDuplicateIndexes(defs), nil
+}
+
+// findDuplicateIndexes reports indexes made redundant by another index on
+// the same table: one with an identical definition, or a b-tree whose
+// columns are a leading prefix of another's. An index that enforces
+// uniqueness is only reported when its duplicate enforces it too, and then
+// the one not backing a constraint is the one to drop.
+func findDuplicateIndexes(defs []indexDef) []AdvisorFinding {
+	var findings []AdvisorFinding
+	reported := make(map[int]bool)
+	for i := range defs {
+		for j := range defs {
+			a, b := &defs[i], &defs[j]
+			if i == j || reported[i] || reported[j] || a.Schema != b.Schema || a.Table != b.Table ||
+				a.Method != b.Method || a.Exprs != b.Exprs || a.Pred != b.Pred {
+				continue
+			}
+			var detail string
+			switch {
+			case equalStrings(a.Keys, b.Keys) && equalStrings(a.OpClasses, b.OpClasses):
+				// Of two identical indexes, keep the one backing a constraint,
+				// else the unique one, else the first by name.
+				if a.Constraint && !b.Constraint || a.Unique && !b.Unique ||
+					a.Constraint == b.Constraint && a.Unique == b.Unique && i < j {
+					continue
+				}
+				detail = fmt.Sprintf("Index %s duplicates %s", a.Name, b.Name)
+			case a.Method == "btree" && !a.Unique && a.Exprs == "" &&
+				len(a.Keys) < len(b.Keys) && equalStrings(a.Keys, b.Keys[:len(a.Keys)]) &&
+				len(a.OpClasses) <= len(b.OpClasses) && equalStrings(a.OpClasses, b.OpClasses[:len(a.OpClasses)]):
+				detail = fmt.Sprintf("Index %s is a prefix of %s, which can serve the same lookups", a.Name, b.Name)
+			default:
+				continue
+			}
+			if a.Constraint {
+				continue
+			}
+			reported[i] = true
+			findings = append(findings, AdvisorFinding{
+				Kind: FindingDuplicateIndex, Schema: a.Schema, Table: a.Table,
+				Index: a.Name, Columns: a.Columns, SizeBytes: a.SizeBytes, Size: a.Size,
+				Detail: detail + fmt.Sprintf(" (%s)", a.Size),
+				DDL:    fmt.Sprintf("DROP INDEX CONCURRENTLY %s.%s;", quoteIdent(a.Schema), quoteIdent(a.Name)),
+			})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].SizeBytes > findings[j].SizeBytes })
+	return findings
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// seqScanTables finds tables of at least seqScanMinRows rows that are read
+// by sequential scan more often than by index.
+func (c *Client) seqScanTables(ctx context.Context) ([]AdvisorFinding, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT schemaname, relname, seq_scan, seq_tup_read / seq_scan, COALESCE(idx_scan, 0), n_live_tup,
+			pg_relation_size(relid), pg_size_pretty(pg_relation_size(relid))
+		FROM pg_stat_user_tables
+		WHERE seq_scan > 0 AND n_live_tup >= $1 AND seq_scan > COALESCE(idx_scan, 0)
+		ORDER BY seq_tup_read DESC`, seqScanMinRows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []AdvisorFinding
+	for rows.Next() {
+		f := AdvisorFinding{Kind: FindingSeqScans}
+		var seqScans, avgRead, idxScans, liveRows int64
+		if err := rows.Scan(&f.Schema, &f.Table, &seqScans, &avgRead, &idxScans, &liveRows,
+			&f.SizeBytes, &f.Size); err != nil {
+			return nil, err
+		}
+		f.Detail = fmt.Sprintf("%d sequential scans reading %d rows each on average, against %d index scans (%d rows, %s)",
+			seqScans, avgRead, idxScans, liveRows, f.Size)
+		table := quoteIdent(f.Schema) + "." + quoteIdent(f.Table)
+		f.DDL = "-- Index the columns that queries on " + table + " filter or join on, e.g.\n" +
+			"-- CREATE INDEX CONCURRENTLY ON " + table + " (column_name);"
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// unindexedForeignKeys finds foreign keys whose columns are not the leading
+// key columns of any index on the referencing table, so deletes and updates
+// on the referenced table scan it in full. INCLUDE columns do not count, as
+// the index cannot be searched by them.
+func (c *Client) unindexedForeignKeys(ctx context.Context) ([]AdvisorFinding, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyColumns := "ix.indnatts"
+	if version >= 110000 {
+		keyColumns = "ix.indnkeyatts"
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT n.nspname, t.relname, con.conname, con.confrelid::regclass::text,
+			ARRAY(SELECT a.attname
+				FROM unnest(con.conkey) WITH ORDINALITY k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+				ORDER BY k.ord),
+			pg_relation_size(t.oid), pg_size_pretty(pg_relation_size(t.oid))
+		FROM pg_constraint con
+		JOIN pg_class t ON t.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE con.contype = 'f'
+			AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_index ix
+				WHERE ix.indrelid = con.conrelid AND ix.indpred IS NULL
+					AND (string_to_array(ix.indkey::text, ' ')::int2[])[1:least(cardinality(con.conkey), `+keyColumns+`)] @> con.conkey)
+		ORDER BY pg_relation_size(t.oid) DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var findings []AdvisorFinding
+	for rows.Next() {
+		f := AdvisorFinding{Kind: FindingUnindexedFK}
+		var name, referenced string
+		if err := rows.Scan(&f.Schema, &f.Table, &name, &referenced, pq.Array(&f.Columns),
+			&f.SizeBytes, &f.Size); err != nil {
+			return nil, err
+		}
+		cols := make([]string, len(f.Columns))
+		for i, col := range f.Columns {
+			cols[i] = quoteIdent(col)
+		}
+		f.Detail = fmt.Sprintf("Foreign key %s (%s) references %s but no index starts with its columns (%s)",
+			name, strings.Join(f.Columns, ", "), referenced, f.Size)
+		f.DDL = fmt.Sprintf("CREATE INDEX CONCURRENTLY ON %s.%s (%s);",
+			quoteIdent(f.Schema), quoteIdent(f.Table), strings.Join(cols, ", "))
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
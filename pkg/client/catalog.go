@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+// Catalog is the structure of one schema: its tables with their columns,
+// indexes and constraints, views, functions and enum types. Schema diffs
+// and snapshots are built from it, and snapshots store it as JSON, so its
+// JSON form is kept stable.
+type Catalog struct {
+	Schema    string            `json:"schema"`
+	Tables    []CatalogTable    `json:"tables"`
+	Views     []CatalogView     `json:"views"`
+	Functions []CatalogFunction `json:"functions"`
+	Enums     []CatalogEnum     `json:"enums"`
+}
+
+type CatalogTable struct {
+	Name        string              `json:"name"`
+	Columns     []CatalogColumn     `json:"columns"`
+	Indexes     []CatalogIndex      `json:"indexes"`
+	Constraints []CatalogConstraint `json:"constraints"`
+}
+
+type CatalogColumn struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"` // as format_type prints it, e.g. "character varying(20)"
+	Nullable  bool   `json:"nullable"`
+	Default   string `json:"default,omitempty"`
+	Identity  string `json:"identity,omitempty"`  // "ALWAYS" or "BY DEFAULT"
+	Generated string `json:"generated,omitempty"` // generation expression of a generated column
+}
+
+// CatalogIndex is an index that does not back a constraint.
+type CatalogIndex struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"` // CREATE INDEX statement
+}
+
+type CatalogConstraint struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`       // "p", "u", "f", "c" or "x", as in pg_constraint.contype
+	Definition string `json:"definition"` // e.g. "FOREIGN KEY (user_id) REFERENCES users(id)"
+}
+
+type CatalogView struct {
+	Name         string `json:"name"`
+	Materialized bool   `json:"materialized"`
+	Definition   string `json:"definition"` // the SELECT
+}
+
+type CatalogFunction struct {
+	Name       string `json:"name"`
+	Arguments  string `json:"arguments"` // identity arguments, e.g. "a integer, b integer"
+	Kind       string `json:"kind"`      // "function" or "procedure"
+	Definition string `json:"definition"`
+}
+
+// Signature identifies an overload, e.g. "add(a integer, b integer)".
+func (f CatalogFunction) Signature() string {
+	return f.Name + "(" + f.Arguments + ")"
+}
+
+type CatalogEnum struct {
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+// Catalog reads the structure of schema. Objects are sorted by name, and
+// columns by position.
+func (c *Client) Catalog(ctx context.Context, schema string) (*Catalog, error) {
+	cat := &Catalog{
+		Schema: schema, Tables: []CatalogTable{}, Views: []CatalogView{},
+		Functions: []CatalogFunction{}, Enums: []CatalogEnum{},
+	}
+	tables := make(map[string]*CatalogTable)
+	for _, load := range []func(context.Context, *Catalog, map[string]*CatalogTable) error{
+		c.catalogColumns, c.catalogIndexes, c.catalogConstraints,
+		c.catalogViews, c.catalogFunctions, c.catalogEnums,
+	} {
+		if err := load(ctx, cat, tables); err != nil {
+			return nil, err
+		}
+	}
+	return cat, nil
+}
+
+// catalogColumns loads the tables of the schema with their columns. Tables
+// without columns are included too.
+func (c *Client) catalogColumns(ctx context.Context, cat *Catalog, tables map[string]*CatalogTable) error {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return err
+	}
+	generated := "false"
+	if version >= 120000 {
+		generated = "a.attgenerated <> ''"
+	}
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT t.relname, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), ''),
+			CASE a.attidentity WHEN 'a' THEN 'ALWAYS' WHEN 'd' THEN 'BY DEFAULT' ELSE '' END,
+			`+generated+`
+		FROM pg_class t
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		LEFT JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum > 0 AND NOT a.attisdropped
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE n.nspname = $1 AND t.relkind IN ('r', 'p')
+		ORDER BY t.relname, a.attnum`, cat.Schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table string
+		var name, typ *string
+		var col CatalogColumn
+		var nullable, isGenerated *bool
+		var def, identity *string
+		if err := rows.Scan(&table, &name, &typ, &nullable, &def, &identity, &isGenerated); err != nil {
+			return err
+		}
+		if len(cat.Tables) == 0 || cat.Tables[len(cat.Tables)-1].Name != table {
+			cat.Tables = append(cat.Tables, CatalogTable{
+				Name: table, Columns: []CatalogColumn{},
+				Indexes: []CatalogIndex{}, Constraints: []CatalogConstraint{},
+			})
+		}
+		if name == nil {
+			continue
+		}
+		col.Name, col.Type, col.Nullable, col.Identity = *name, *typ, *nullable, *identity
+		if *isGenerated {
+			col.Generated = *def
+		} else {
+			col.Default = *def
+		}
+		t := &cat.Tables[len(cat.Tables)-1]
+		t.Columns = append(t.Columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := range cat.Tables {
+		tables[cat.Tables[i].Name] = &cat.Tables[i]
+	}
+	return nil
+}
+
+func (c *Client) catalogIndexes(ctx context.Context, cat *Catalog, tables map[string]*CatalogTable) error {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT t.relname, i.relname, pg_get_indexdef(ix.indexrelid)
+		FROM pg_index ix
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = $1 AND t.relkind IN ('r', 'p')
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint con
+				WHERE con.conindid = ix.indexrelid AND con.conrelid = ix.indrelid AND con.contype IN ('p', 'u', 'x'))
+		ORDER BY t.relname, i.relname`, cat.Schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table string
+		var idx CatalogIndex
+		if err := rows.Scan(&table, &idx.Name, &idx.Definition); err != nil {
+			return err
+		}
+		if t, ok := tables[table]; ok {
+			t.Indexes = append(t.Indexes, idx)
+		}
+	}
+	return rows.Err()
+}
+
+func (c *Client) catalogConstraints(ctx context.Context, cat *Catalog, tables map[string]*CatalogTable) error {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT t.relname, con.conname, con.contype::text, pg_get_constraintdef(con.oid, true)
+		FROM pg_constraint con
+		JOIN pg_class t ON t.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE n.nspname = $1 AND t.relkind IN ('r', 'p') AND con.contype IN ('p', 'u', 'f', 'c', 'x')
+		ORDER BY t.relname, con.conname`, cat.Schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table string
+		var con CatalogConstraint
+		if err := rows.Scan(&table, &con.Name, &con.Type, &con.Definition); err != nil {
+			return err
+		}
+		if t, ok := tables[table]; ok {
+			t.Constraints = append(t.Constraints, con)
+		}
+	}
+	return rows.Err()
+}
+
+func (c *Client) catalogViews(ctx context.Context, cat *Catalog, _ map[string]*CatalogTable) error {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT c.relname, c.relkind = 'm', pg_get_viewdef(c.oid, true)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind IN ('v', 'm')
+		ORDER BY c.relname`, cat.Schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v CatalogView
+		if err := rows.Scan(&v.Name, &v.Materialized, &v.Definition); err != nil {
+			return err
+		}
+		cat.Views = append(cat.Views, v)
+	}
+	return rows.Err()
+}
+
+// catalogFunctions loads functions and procedures, leaving out aggregates
+// and those belonging to extensions.
+func (c *Client) catalogFunctions(ctx context.Context, cat *Catalog, _ map[string]*CatalogTable) error {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT p.proname, pg_get_function_identity_arguments(p.oid),
+			CASE p.prokind WHEN 'p' THEN 'procedure' ELSE 'function' END,
+			pg_get_functiondef(p.oid)
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE n.nspname = $1 AND p.prokind IN ('f', 'p')
+			AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')
+		ORDER BY 1, 2`, cat.Schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var f CatalogFunction
+		if err := rows.Scan(&f.Name, &f.Arguments, &f.Kind, &f.Definition); err != nil {
+			return err
+		}
+		cat.Functions = append(cat.Functions, f)
+	}
+	return rows.Err()
+}
+
+func (c *Client) catalogEnums(ctx context.Context, cat *Catalog, _ map[string]*CatalogTable) error {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT t.typname, array_agg(e.enumlabel ORDER BY e.enumsortorder)
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		WHERE n.nspname = $1
+		GROUP BY t.typname
+		ORDER BY t.typname`, cat.Schema)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e CatalogEnum
+		if err := rows.Scan(&e.Name, pq.Array(&e.Labels)); err != nil {
+			return err
+		}
+		cat.Enums = append(cat.Enums, e)
+	}
+	return rows.Err()
+}
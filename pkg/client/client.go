@@ -3,25 +3,49 @@ package client
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
-
-	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
 type Client struct {
 	db      *sql.DB
 	connURL string
+	opts    Options
+	// pins holds a token per connection reserved by PinnedSession. It has
+	// room for one fewer than the pool, so other queries, metadata and
+	// cancel requests always have a connection to wait for.
+	pins chan struct{}
+}
+
+// DefaultMaxConns is the pool size used when Options.MaxConns is not set.
+const DefaultMaxConns = 10
+
+// ErrTooManySessions is returned by PinnedSession when every connection that
+// may be pinned is, e.g. by tabs with open transactions.
+var ErrTooManySessions = errors.New("too many open transactions on this connection: commit or roll back one, or raise --max-conns")
+
+// Options controls how a Client's sessions are set up.
+type Options struct {
+	// ReadOnly starts every session with default_transaction_read_only=on,
+	// so the server rejects writes as a second line of defence behind the
+	// service layer's statement check.
+	ReadOnly bool
+	// MaxConns is the maximum number of open connections, at least 2.
+	// Zero means DefaultMaxConns.
+	MaxConns int
 }
 
 type ConnectionInfo struct {
+	ID       string // registry ID, filled in by the service layer
 	Host     string
 	Port     int
 	User     string
 	Database string
 	Version  string
+	ReadOnly bool
+	Error    string // why the info could not be read, filled in by the service layer
 }
 
 type SchemaObject struct {
@@ -29,6 +53,9 @@ type SchemaObject struct {
 	Schema  string
 	Type    string
 	Comment string
+	// Parent is the table a trigger, policy or partition belongs to,
+	// schema-qualified if it is in another schema.
+	Parent string
 }
 
 type SchemaGroup struct {
@@ -37,7 +64,17 @@ type SchemaGroup struct {
 	MaterializedViews []SchemaObject
 	Functions         []SchemaObject
 	Sequences         []SchemaObject
-	Types             []SchemaObject
+	Types             []SchemaObject // enum, composite and range types
+	Domains           []SchemaObject
+	ForeignTables     []SchemaObject
+	Partitions        []SchemaObject
+	Triggers          []SchemaObject
+	Policies          []SchemaObject
+	Extensions        []SchemaObject // by the schema they are installed in
+	// Publications and Subscriptions belong to the database; they are listed
+	// under each schema whose tables they replicate.
+	Publications  []SchemaObject
+	Subscriptions []SchemaObject
 }
 
 type Column struct {
@@ -56,6 +93,11 @@ type QueryResult struct {
 	Rows        [][]any
 	RowCount    int
 	DurationMs  int64
+	Truncated   bool // more rows were available than the requested limit
+	// RowsAffected is the row count from the command tag: rows inserted,
+	// updated, deleted or copied, or rows selected before truncation.
+	RowsAffected int64
+	CommandTag   string // e.g. "UPDATE 50000", "INSERT 0 3" or "CREATE TABLE"
 }
 
 type TableInfo struct {
@@ -91,8 +133,8 @@ type Activity struct {
 	WaitEventType string
 }
 
-func New(connURL string) (*Client, error) {
-	db, err := sql.Open("postgres", connURL)
+func New(connURL string, opts Options) (*Client, error) {
+	db, err := sql.Open(driverName, sessionDSN(connURL, opts))
 	if err != nil {
 		return nil, fmt.Errorf("open connection: %w", err)
 	}
@@ -100,9 +142,37 @@ func New(connURL string) (*Client, error) {
 		db.Close()
 		return nil, fmt.Errorf("ping: %w", err)
 	}
-	db.SetMaxOpenConns(5)
+	maxConns := opts.MaxConns
+	if maxConns == 0 {
+		maxConns = DefaultMaxConns
+	}
+	maxConns = max(maxConns, 2)
+	db.SetMaxOpenConns(maxConns)
 	db.SetMaxIdleConns(2)
-	return &Client{db: db, connURL: connURL}, nil
+	return &Client{db: db, connURL: connURL, opts: opts, pins: make(chan struct{}, maxConns-1)}, nil
+}
+
+// sessionDSN adds the run-time parameters implied by opts to connURL. lib/pq
+// sends unrecognised connection parameters to the server at startup.
+func sessionDSN(connURL string, opts Options) string {
+	if !opts.ReadOnly {
+		return connURL
+	}
+	if strings.HasPrefix(connURL, "postgres://") || strings.HasPrefix(connURL, "postgresql://") {
+		u, err := url.Parse(connURL)
+		if err == nil {
+			q := u.Query()
+			q.Set("default_transaction_read_only", "on")
+			u.RawQuery = q.Encode()
+			return u.String()
+		}
+	}
+	return connURL + " default_transaction_read_only=on"
+}
+
+// ReadOnly reports whether the client was opened in read-only mode.
+func (c *Client) ReadOnly() bool {
+	return c.opts.ReadOnly
 }
 
 func (c *Client) Close() {
@@ -120,7 +190,7 @@ func (c *Client) Database() string {
 }
 
 func (c *Client) Info() (*ConnectionInfo, error) {
-	info := &ConnectionInfo{}
+	info := &ConnectionInfo{ReadOnly: c.opts.ReadOnly}
 
 	u, err := url.Parse(c.connURL)
 	if err == nil {
@@ -140,13 +210,20 @@ func (c *Client) Info() (*ConnectionInfo, error) {
 	return info, nil
 }
 
+// serverVersion returns the server's version number, e.g. 160002 for 16.2.
+func (c *Client) serverVersion(ctx context.Context) (int, error) {
+	var version int
+	err := c.db.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::int").Scan(&version)
+	return version, err
+}
+
 func (c *Client) SwitchDatabase(database string) (*Client, error) {
 	u, err := url.Parse(c.connURL)
 	if err != nil {
 		return nil, fmt.Errorf("parse URL: %w", err)
 	}
 	u.Path = "/" + database
-	return New(u.String())
+	return New(u.String(), c.opts)
 }
 
 func (c *Client) Databases() ([]string, error) {
@@ -292,28 +369,32 @@ func (c *Client) Objects() (map[string]*SchemaGroup, error) {
 		}
 	}
 
-	// Types
+	// Types: enums, ranges and standalone composite types (every table has
+	// a composite row type of its own, which is left out)
 	typeRows, err := c.db.Query(`
-		SELECT n.nspname, t.typname
+		SELECT n.nspname, t.typname,
+			CASE t.typtype WHEN 'e' THEN 'enum' WHEN 'r' THEN 'range' ELSE 'composite' END
 		FROM pg_type t
 		JOIN pg_namespace n ON n.oid = t.typnamespace
-		WHERE t.typtype = 'e'
+		LEFT JOIN pg_class c ON c.oid = t.typrelid
+		WHERE (t.typtype IN ('e', 'r') OR t.typtype = 'c' AND c.relkind = 'c')
 		AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
 		ORDER BY n.nspname, t.typname`)
 	if err == nil {
 		defer typeRows.Close()
 		for typeRows.Next() {
-			var schema, name string
-			if err := typeRows.Scan(&schema, &name); err != nil {
+			var schema, name, kind string
+			if err := typeRows.Scan(&schema, &name, &kind); err != nil {
 				continue
 			}
 			g := ensureGroup(schema)
 			g.Types = append(g.Types, SchemaObject{
-				Name: name, Schema: schema, Type: "enum",
+				Name: name, Schema: schema, Type: kind,
 			})
 		}
 	}
 
+	c.moreObjects(ensureGroup)
 	return result, nil
 }
 
@@ -415,7 +496,7 @@ func (c *Client) TableRows(ctx context.Context, table string, limit, offset int,
 	}
 	q += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, offset)
 
-	result, err := c.queryContext(ctx, q)
+	result, err := c.queryContext(ctx, q, 0)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -534,7 +615,13 @@ func (c *Client) FunctionDefinition(schema, name string) (*FunctionDefinition, e
 }
 
 func (c *Client) QueryWithContext(ctx context.Context, query string) (*QueryResult, error) {
-	return c.queryContext(ctx, query)
+	return c.queryContext(ctx, query, 0)
+}
+
+// QueryWithLimit executes a query and buffers at most maxRows rows, setting
+// Truncated when the result set had more.
+func (c *Client) QueryWithLimit(ctx context.Context, query string, maxRows int) (*QueryResult, error) {
+	return c.queryContext(ctx, query, maxRows)
 }
 
 func (c *Client) TablesStats() (*QueryResult, error) {
@@ -551,7 +638,7 @@ func (c *Client) TablesStats() (*QueryResult, error) {
 			last_analyze,
 			last_autoanalyze
 		FROM pg_stat_user_tables
-		ORDER BY n_live_tup DESC`)
+		ORDER BY n_live_tup DESC`, 0)
 }
 
 func (c *Client) Activity() ([]Activity, error) {
@@ -587,62 +674,74 @@ func (c *Client) Activity() ([]Activity, error) {
 	return activities, rows.Err()
 }
 
+// CancelBackend asks the server to cancel the current query of the backend
+// with the given PID. It reports whether the signal was delivered.
+func (c *Client) CancelBackend(ctx context.Context, pid int) (bool, error) {
+	var ok bool
+	err := c.db.QueryRowContext(ctx, "SELECT pg_cancel_backend($1)", pid).Scan(&ok)
+	return ok, err
+}
+
+// TerminateBackend asks the server to end the session of the backend with
+// the given PID. It reports whether the signal was delivered.
+func (c *Client) TerminateBackend(ctx context.Context, pid int) (bool, error) {
+	var ok bool
+	err := c.db.QueryRowContext(ctx, "SELECT pg_terminate_backend($1)", pid).Scan(&ok)
+	return ok, err
+}
+
 func (c *Client) ServerSettings() (*QueryResult, error) {
 	return c.queryContext(context.Background(), `
 		SELECT name, setting, unit, short_desc
 		FROM pg_settings
-		ORDER BY name`)
+		ORDER BY name`, 0)
 }
 
 // queryContext executes a query and returns columns, types, and rows.
-func (c *Client) queryContext(ctx context.Context, query string) (*QueryResult, error) {
-	start := time.Now()
-	rows, err := c.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	colTypes, err := rows.ColumnTypes()
+// A positive maxRows stops buffering after that many rows.
+func (c *Client) queryContext(ctx context.Context, query string, maxRows int) (*QueryResult, error) {
+	rows, err := c.QueryRows(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	return collectRows(rows, maxRows)
+}
 
-	columns := make([]string, len(colTypes))
-	typeNames := make([]string, len(colTypes))
-	for i, ct := range colTypes {
-		columns[i] = ct.Name()
-		typeNames[i] = ct.DatabaseTypeName()
-	}
+// collectRows buffers at most maxRows rows (all if maxRows is 0) and closes
+// rows.
+func collectRows(rows *Rows, maxRows int) (*QueryResult, error) {
+	defer rows.Close()
 
 	var data [][]any
+	truncated := false
 	for rows.Next() {
-		vals := make([]any, len(columns))
-		ptrs := make([]any, len(columns))
-		for i := range vals {
-			ptrs[i] = &vals[i]
+		if maxRows > 0 && len(data) >= maxRows {
+			truncated = true
+			break
 		}
-		if err := rows.Scan(ptrs...); err != nil {
+		vals, err := rows.Values()
+		if err != nil {
 			return nil, err
 		}
-		// Convert []byte to string for readability
-		for i, v := range vals {
-			if b, ok := v.([]byte); ok {
-				vals[i] = string(b)
-			}
-		}
 		data = append(data, vals)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	// The command tag arrives after the last row, so a truncated result must
+	// be drained before it can be read, unless it is stopped early instead.
+	rows.Close()
+	tag := rows.CommandTag()
 
 	return &QueryResult{
-		Columns:     columns,
-		ColumnTypes: typeNames,
-		Rows:        data,
-		RowCount:    len(data),
-		DurationMs:  time.Since(start).Milliseconds(),
+		Columns:      rows.Columns,
+		ColumnTypes:  rows.ColumnTypes,
+		Rows:         data,
+		RowCount:     len(data),
+		DurationMs:   rows.DurationMs(),
+		Truncated:    truncated,
+		RowsAffected: tag.RowsAffected,
+		CommandTag:   tag.String(),
 	}, nil
 }
 
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// copyDataStmt is implemented by lib/pq's COPY FROM STDIN statement.
+type copyDataStmt interface {
+	driver.Stmt
+	CopyData(ctx context.Context, line string) (driver.Result, error)
+}
+
+// CopyFrom runs a COPY ... FROM STDIN statement on the session and feeds it
+// data, which holds the rows in the statement's format, one per line. lib/pq
+// only allows COPY inside a transaction, so if none is open one is wrapped
+// around it. It returns the number of rows copied.
+func (s *Session) CopyFrom(ctx context.Context, stmt, data string) (int64, error) {
+	status, err := s.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status == TxIdle {
+		if err := s.Exec(ctx, "BEGIN"); err != nil {
+			return 0, err
+		}
+	}
+
+	var n int64
+	err = s.conn.Raw(func(dc any) error {
+		st, err := dc.(driver.Conn).Prepare(stmt)
+		if err != nil {
+			return err
+		}
+		defer st.Close()
+		cs, ok := st.(copyDataStmt)
+		if !ok {
+			return fmt.Errorf("not a COPY FROM STDIN statement")
+		}
+		if data != "" {
+			for _, line := range strings.Split(strings.TrimSuffix(data, "\n"), "\n") {
+				if _, err := cs.CopyData(ctx, strings.TrimSuffix(line, "\r")); err != nil {
+					return err
+				}
+			}
+		}
+		res, err := cs.Exec(nil)
+		if err != nil {
+			return err
+		}
+		n, err = res.RowsAffected()
+		return err
+	})
+
+	if status == TxIdle {
+		if err != nil {
+			s.Exec(context.Background(), "ROLLBACK")
+			return 0, err
+		}
+		if err := s.Exec(ctx, "COMMIT"); err != nil {
+			return 0, err
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,725 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Object kinds accepted by ObjectDDL.
+const (
+	KindTable            = "table"
+	KindView             = "view"
+	KindMaterializedView = "materialized_view"
+	KindSequence         = "sequence"
+	KindEnum             = "enum"
+	KindTrigger          = "trigger"
+	KindFunction         = "function"
+)
+
+var (
+	ErrUnknownKind    = errors.New("unknown object kind")
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// aclColumns selects the grantee, privilege and grant option of each entry
+// of an ACL exploded as g by aclexplode.
+const aclColumns = `CASE WHEN g.grantee = 0 THEN 'PUBLIC' ELSE quote_ident(pg_get_userbyid(g.grantee)) END,
+	g.privilege_type, g.is_grantable`
+
+// ObjectDDL reconstructs the DDL of an object from the catalogs, as a series
+// of statements separated by blank lines. Tables include their columns,
+// defaults, identity and generated columns, constraints, partitioning,
+// storage parameters, indexes, triggers and any sequences their serial
+// columns own. Every kind includes its comments, owner and grants.
+//
+// A trigger is looked up by name within schema, and every trigger of that
+// name is returned. Functions return every overload. ObjectDDL needs
+// PostgreSQL 10 or later.
+func (c *Client) ObjectDDL(ctx context.Context, kind, schema, name string) (string, error) {
+	var stmts []string
+	var err error
+	switch kind {
+	case KindTable:
+		stmts, err = c.tableDDL(ctx, schema, name)
+	case KindView, KindMaterializedView:
+		stmts, err = c.viewDDL(ctx, kind, schema, name)
+	case KindSequence:
+		stmts, err = c.sequenceStatements(ctx, schema, name, true)
+	case KindEnum:
+		stmts, err = c.enumDDL(ctx, schema, name)
+	case KindTrigger:
+		stmts, err = c.triggerDDL(ctx, schema, name)
+	case KindFunction:
+		stmts, err = c.functionDDL(ctx, schema, name)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+	if errors.Is(err, sql.ErrNoRows) || err == nil && len(stmts) == 0 {
+		return "", fmt.Errorf("%w: %s %s.%s", ErrObjectNotFound, kind, schema, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(stmts, "\n\n") + "\n", nil
+}
+
+// relation is the catalog entry of a table, view or sequence.
+type relation struct {
+	oid          int64
+	kind         string // pg_class.relkind
+	qualified    string // quoted schema.name
+	owner        string // quoted
+	unlogged     bool
+	options      []string
+	tablespace   string // quoted, empty for the default
+	comment      string
+	partitionOf  string // quoted parent, for a partition
+	bound        string // partition bound, e.g. "FOR VALUES IN (1)"
+	partitionKey string // e.g. "RANGE (created_at)", for a partitioned table
+	rowSecurity  bool
+	forceRLS     bool
+}
+
+func (c *Client) lookupRelation(ctx context.Context, schema, name string, relkinds ...string) (*relation, error) {
+	r := &relation{}
+	err := c.db.QueryRowContext(ctx, `
+		SELECT c.oid, c.relkind::text, quote_ident(n.nspname) || '.' || quote_ident(c.relname),
+			quote_ident(pg_get_userbyid(c.relowner)), c.relpersistence = 'u',
+			COALESCE(c.reloptions, '{}'), COALESCE(quote_ident(ts.spcname), ''),
+			COALESCE(obj_description(c.oid, 'pg_class'), ''),
+			COALESCE((SELECT quote_ident(pn.nspname) || '.' || quote_ident(p.relname)
+				FROM pg_inherits i
+				JOIN pg_class p ON p.oid = i.inhparent
+				JOIN pg_namespace pn ON pn.oid = p.relnamespace
+				WHERE i.inhrelid = c.oid AND c.relispartition), ''),
+			COALESCE(pg_get_expr(c.relpartbound, c.oid), ''),
+			CASE WHEN c.relkind = 'p' THEN pg_get_partkeydef(c.oid) ELSE '' END,
+			c.relrowsecurity, c.relforcerowsecurity
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_tablespace ts ON ts.oid = c.reltablespace
+		WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind::text = ANY($3)`,
+		schema, name, pq.Array(relkinds)).Scan(
+		&r.oid, &r.kind, &r.qualified, &r.owner, &r.unlogged,
+		pq.Array(&r.options), &r.tablespace, &r.comment,
+		&r.partitionOf, &r.bound, &r.partitionKey, &r.rowSecurity, &r.forceRLS,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// tableColumn is a column as written in CREATE TABLE.
+type tableColumn struct {
+	name, typ, collation string
+	def                  string
+	identity             string // pg_attribute.attidentity: "a", "d" or empty
+	generated            string // pg_attribute.attgenerated: "s", "v" or empty
+	notNull, local       bool
+	comment              string
+}
+
+func (col tableColumn) definition() string {
+	var b strings.Builder
+	b.WriteString(col.name + " " + col.typ)
+	if col.collation != "" {
+		b.WriteString(" COLLATE " + col.collation)
+	}
+	switch {
+	case col.generated == "s":
+		b.WriteString(" GENERATED ALWAYS AS (" + col.def + ") STORED")
+	case col.generated == "v":
+		b.WriteString(" GENERATED ALWAYS AS (" + col.def + ") VIRTUAL")
+	case col.identity == "a":
+		b.WriteString(" GENERATED ALWAYS AS IDENTITY")
+	case col.identity == "d":
+		b.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+	case col.def != "":
+		b.WriteString(" DEFAULT " + col.def)
+	}
+	if col.notNull {
+		b.WriteString(" NOT NULL")
+	}
+	return b.String()
+}
+
+func (c *Client) tableColumns(ctx context.Context, oid int64) ([]tableColumn, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	generated := "''"
+	if version >= 120000 {
+		generated = "a.attgenerated::text"
+	}
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT quote_ident(a.attname), format_type(a.atttypid, a.atttypmod),
+			COALESCE((SELECT quote_ident(cn.nspname) || '.' || quote_ident(co.collname)
+				FROM pg_collation co
+				JOIN pg_namespace cn ON cn.oid = co.collnamespace
+				WHERE co.oid = a.attcollation AND a.attcollation <> t.typcollation), ''),
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), ''),
+			a.attidentity::text, `+generated+`, a.attnotnull, a.attislocal,
+			COALESCE(col_description(a.attrelid, a.attnum), '')
+		FROM pg_attribute a
+		JOIN pg_type t ON t.oid = a.atttypid
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cols []tableColumn
+	for rows.Next() {
+		var col tableColumn
+		if err := rows.Scan(&col.name, &col.typ, &col.collation, &col.def,
+			&col.identity, &col.generated, &col.notNull, &col.local, &col.comment); err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	return cols, rows.Err()
+}
+
+func (c *Client) tableDDL(ctx context.Context, schema, name string) ([]string, error) {
+	rel, err := c.lookupRelation(ctx, schema, name, "r", "p")
+	if err != nil {
+		return nil, err
+	}
+	cols, err := c.tableColumns(ctx, rel.oid)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sequences owned by serial columns must exist before the table.
+	var stmts, ownedBy []string
+	seqRows, err := c.db.QueryContext(ctx, `
+		SELECT n.nspname, s.relname,
+			'ALTER SEQUENCE ' || quote_ident(n.nspname) || '.' || quote_ident(s.relname)
+				|| ' OWNED BY ' || $2 || '.' || quote_ident(a.attname) || ';'
+		FROM pg_depend d
+		JOIN pg_class s ON s.oid = d.objid AND s.relkind = 'S'
+		JOIN pg_namespace n ON n.oid = s.relnamespace
+		JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE d.classid = 'pg_class'::regclass AND d.refclassid = 'pg_class'::regclass
+			AND d.refobjid = $1 AND d.deptype = 'a'
+		ORDER BY a.attnum`, rel.oid, rel.qualified)
+	if err != nil {
+		return nil, err
+	}
+	type ownedSeq struct{ schema, name, ownedBy string }
+	var seqs []ownedSeq
+	for seqRows.Next() {
+		var s ownedSeq
+		if err := seqRows.Scan(&s.schema, &s.name, &s.ownedBy); err != nil {
+			seqRows.Close()
+			return nil, err
+		}
+		seqs = append(seqs, s)
+	}
+	seqRows.Close()
+	if err := seqRows.Err(); err != nil {
+		return nil, err
+	}
+	for _, s := range seqs {
+		seqStmts, err := c.sequenceStatements(ctx, s.schema, s.name, false)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, seqStmts...)
+		ownedBy = append(ownedBy, s.ownedBy)
+	}
+
+	constraints, foreignKeys, err := c.tableConstraintDDL(ctx, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if rel.unlogged {
+		b.WriteString("UNLOGGED ")
+	}
+	b.WriteString("TABLE " + rel.qualified)
+	var body []string
+	if rel.partitionOf == "" {
+		for _, col := range cols {
+			if col.local {
+				body = append(body, col.definition())
+			}
+		}
+	}
+	body = append(body, constraints...)
+	if rel.partitionOf != "" {
+		b.WriteString(" PARTITION OF " + rel.partitionOf)
+	}
+	if len(body) > 0 || rel.partitionOf == "" {
+		b.WriteString(" (\n    " + strings.Join(body, ",\n    ") + "\n)")
+	}
+	if rel.partitionOf != "" {
+		b.WriteString(" " + rel.bound)
+	} else {
+		parents, err := c.inheritsFrom(ctx, rel.oid)
+		if err != nil {
+			return nil, err
+		}
+		if len(parents) > 0 {
+			b.WriteString("\nINHERITS (" + strings.Join(parents, ", ") + ")")
+		}
+	}
+	if rel.partitionKey != "" {
+		b.WriteString("\nPARTITION BY " + rel.partitionKey)
+	}
+	if len(rel.options) > 0 {
+		b.WriteString("\nWITH (" + strings.Join(rel.options, ", ") + ")")
+	}
+	if rel.tablespace != "" {
+		b.WriteString("\nTABLESPACE " + rel.tablespace)
+	}
+	b.WriteString(";")
+	stmts = append(stmts, b.String())
+	stmts = append(stmts, ownedBy...)
+	stmts = append(stmts, foreignKeys...)
+
+	indexes, err := c.indexDDL(ctx, rel.oid)
+	if err != nil {
+		return nil, err
+	}
+	stmts = append(stmts, indexes...)
+	triggers, err := c.triggerStatements(ctx, "tg.tgrelid = $1", rel.oid)
+	if err != nil {
+		return nil, err
+	}
+	stmts = append(stmts, triggers...)
+	if rel.rowSecurity {
+		stmts = append(stmts, "ALTER TABLE "+rel.qualified+" ENABLE ROW LEVEL SECURITY;")
+	}
+	if rel.forceRLS {
+		stmts = append(stmts, "ALTER TABLE "+rel.qualified+" FORCE ROW LEVEL SECURITY;")
+	}
+
+	stmts = append(stmts, commentStatement("TABLE "+rel.qualified, rel.comment)...)
+	for _, col := range cols {
+		if col.local || rel.partitionOf != "" {
+			stmts = append(stmts, commentStatement("COLUMN "+rel.qualified+"."+col.name, col.comment)...)
+		}
+	}
+	return c.appendRelationACL(ctx, stmts, "TABLE", rel)
+}
+
+// tableConstraintDDL returns a table's own constraints as CREATE TABLE
+// clauses, except foreign keys, which are returned as ALTER TABLE
+// statements so that tables referencing each other can be created first.
+func (c *Client) tableConstraintDDL(ctx context.Context, rel *relation) (clauses, foreignKeys []string, err error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT quote_ident(conname), contype::text, pg_get_constraintdef(oid, true),
+			COALESCE(obj_description(oid, 'pg_constraint'), '')
+		FROM pg_constraint
+		WHERE conrelid = $1 AND conislocal AND contype IN ('p', 'u', 'c', 'f', 'x')
+		ORDER BY contype = 'f', contype <> 'p', conname`, rel.oid)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var comments []string
+	for rows.Next() {
+		var name, typ, def, comment string
+		if err := rows.Scan(&name, &typ, &def, &comment); err != nil {
+			return nil, nil, err
+		}
+		if typ == "f" {
+			foreignKeys = append(foreignKeys, "ALTER TABLE "+rel.qualified+" ADD CONSTRAINT "+name+" "+def+";")
+		} else {
+			clauses = append(clauses, "CONSTRAINT "+name+" "+def)
+		}
+		comments = append(comments, commentStatement("CONSTRAINT "+name+" ON "+rel.qualified, comment)...)
+	}
+	return clauses, append(foreignKeys, comments...), rows.Err()
+}
+
+func (c *Client) inheritsFrom(ctx context.Context, oid int64) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT quote_ident(n.nspname) || '.' || quote_ident(p.relname)
+		FROM pg_inherits i
+		JOIN pg_class p ON p.oid = i.inhparent
+		JOIN pg_namespace n ON n.oid = p.relnamespace
+		WHERE i.inhrelid = $1
+		ORDER BY i.inhseqno`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var parents []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		parents = append(parents, p)
+	}
+	return parents, rows.Err()
+}
+
+// indexDDL returns CREATE INDEX statements for the indexes of a relation
+// that do not back a constraint and are not created by a partitioned
+// parent's index.
+func (c *Client) indexDDL(ctx context.Context, oid int64) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT pg_get_indexdef(ix.indexrelid),
+			quote_ident(n.nspname) || '.' || quote_ident(i.relname),
+			COALESCE(obj_description(ix.indexrelid, 'pg_class'), '')
+		FROM pg_index ix
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_namespace n ON n.oid = i.relnamespace
+		WHERE ix.indrelid = $1
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint con
+				WHERE con.conindid = ix.indexrelid AND con.conrelid = ix.indrelid AND con.contype IN ('p', 'u', 'x'))
+			AND NOT EXISTS (SELECT 1 FROM pg_inherits inh WHERE inh.inhrelid = ix.indexrelid)
+		ORDER BY i.relname`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var stmts []string
+	for rows.Next() {
+		var def, name, comment string
+		if err := rows.Scan(&def, &name, &comment); err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, def+";")
+		stmts = append(stmts, commentStatement("INDEX "+name, comment)...)
+	}
+	return stmts, rows.Err()
+}
+
+// triggerStatements returns CREATE TRIGGER statements for the user-defined
+// triggers matching where, a condition on pg_trigger tg, pg_class t and
+// pg_namespace n. Triggers cloned onto partitions from their parent are
+// left out.
+func (c *Client) triggerStatements(ctx context.Context, where string, args ...any) ([]string, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if version >= 130000 {
+		where += " AND tg.tgparentid = 0"
+	}
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT pg_get_triggerdef(tg.oid, true),
+			quote_ident(tg.tgname) || ' ON ' || quote_ident(n.nspname) || '.' || quote_ident(t.relname),
+			COALESCE(obj_description(tg.oid, 'pg_trigger'), '')
+		FROM pg_trigger tg
+		JOIN pg_class t ON t.oid = tg.tgrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		WHERE NOT tg.tgisinternal AND `+where+`
+		ORDER BY t.relname, tg.tgname`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var stmts []string
+	for rows.Next() {
+		var def, name, comment string
+		if err := rows.Scan(&def, &name, &comment); err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, def+";")
+		stmts = append(stmts, commentStatement("TRIGGER "+name, comment)...)
+	}
+	return stmts, rows.Err()
+}
+
+func (c *Client) triggerDDL(ctx context.Context, schema, name string) ([]string, error) {
+	return c.triggerStatements(ctx, "n.nspname = $1 AND tg.tgname = $2", schema, name)
+}
+
+func (c *Client) viewDDL(ctx context.Context, kind, schema, name string) ([]string, error) {
+	relkind, keyword := "v", "VIEW"
+	if kind == KindMaterializedView {
+		relkind, keyword = "m", "MATERIALIZED VIEW"
+	}
+	rel, err := c.lookupRelation(ctx, schema, name, relkind)
+	if err != nil {
+		return nil, err
+	}
+	var def string
+	if err := c.db.QueryRowContext(ctx, "SELECT pg_get_viewdef($1::oid, true)", rel.oid).Scan(&def); err != nil {
+		return nil, err
+	}
+	def = strings.TrimSuffix(strings.TrimSpace(def), ";")
+
+	var b strings.Builder
+	if relkind == "v" {
+		b.WriteString("CREATE OR REPLACE VIEW " + rel.qualified)
+	} else {
+		b.WriteString("CREATE MATERIALIZED VIEW " + rel.qualified)
+	}
+	// A view's check_option is stored as a reloption but written as a clause.
+	var options []string
+	checkOption := ""
+	for _, opt := range rel.options {
+		if v, ok := strings.CutPrefix(opt, "check_option="); ok {
+			checkOption = "\nWITH " + strings.ToUpper(v) + " CHECK OPTION"
+			continue
+		}
+		options = append(options, opt)
+	}
+	if len(options) > 0 {
+		b.WriteString(" WITH (" + strings.Join(options, ", ") + ")")
+	}
+	if rel.tablespace != "" {
+		b.WriteString("\nTABLESPACE " + rel.tablespace)
+	}
+	b.WriteString(" AS\n" + def + checkOption + ";")
+	stmts := []string{b.String()}
+
+	if relkind == "m" {
+		indexes, err := c.indexDDL(ctx, rel.oid)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, indexes...)
+	} else {
+		triggers, err := c.triggerStatements(ctx, "tg.tgrelid = $1", rel.oid)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, triggers...)
+	}
+	stmts = append(stmts, commentStatement(keyword+" "+rel.qualified, rel.comment)...)
+	cols, err := c.tableColumns(ctx, rel.oid)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range cols {
+		stmts = append(stmts, commentStatement("COLUMN "+rel.qualified+"."+col.name, col.comment)...)
+	}
+	return c.appendRelationACL(ctx, stmts, keyword, rel)
+}
+
+// sequenceStatements returns the DDL of a sequence, with its OWNED BY
+// clause if withOwnedBy is set.
+func (c *Client) sequenceStatements(ctx context.Context, schema, name string, withOwnedBy bool) ([]string, error) {
+	rel, err := c.lookupRelation(ctx, schema, name, "S")
+	if err != nil {
+		return nil, err
+	}
+	var typ string
+	var start, minValue, maxValue, increment, cache int64
+	var cycle bool
+	err = c.db.QueryRowContext(ctx, `
+		SELECT format_type(seqtypid, NULL), seqstart, seqmin, seqmax, seqincrement, seqcache, seqcycle
+		FROM pg_sequence WHERE seqrelid = $1`, rel.oid).Scan(
+		&typ, &start, &minValue, &maxValue, &increment, &cache, &cycle)
+	if err != nil {
+		return nil, err
+	}
+	create := fmt.Sprintf("CREATE SEQUENCE %s\n    AS %s\n    START WITH %d\n    INCREMENT BY %d\n    MINVALUE %d\n    MAXVALUE %d\n    CACHE %d",
+		rel.qualified, typ, start, increment, minValue, maxValue, cache)
+	if cycle {
+		create += "\n    CYCLE"
+	}
+	if rel.unlogged {
+		create = strings.Replace(create, "CREATE SEQUENCE", "CREATE UNLOGGED SEQUENCE", 1)
+	}
+	stmts := []string{create + ";"}
+
+	if withOwnedBy {
+		var owned string
+		err := c.db.QueryRowContext(ctx, `
+			SELECT quote_ident(n.nspname) || '.' || quote_ident(t.relname) || '.' || quote_ident(a.attname)
+			FROM pg_depend d
+			JOIN pg_class t ON t.oid = d.refobjid
+			JOIN pg_namespace n ON n.oid = t.relnamespace
+			JOIN pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+			WHERE d.classid = 'pg_class'::regclass AND d.refclassid = 'pg_class'::regclass
+				AND d.objid = $1 AND d.deptype IN ('a', 'i')`, rel.oid).Scan(&owned)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if owned != "" {
+			stmts = append(stmts, "ALTER SEQUENCE "+rel.qualified+" OWNED BY "+owned+";")
+		}
+	}
+	stmts = append(stmts, commentStatement("SEQUENCE "+rel.qualified, rel.comment)...)
+	return c.appendRelationACL(ctx, stmts, "SEQUENCE", rel)
+}
+
+func (c *Client) enumDDL(ctx context.Context, schema, name string) ([]string, error) {
+	var oid int64
+	var qualified, owner, comment string
+	err := c.db.QueryRowContext(ctx, `
+		SELECT t.oid, quote_ident(n.nspname) || '.' || quote_ident(t.typname),
+			quote_ident(pg_get_userbyid(t.typowner)), COALESCE(obj_description(t.oid, 'pg_type'), '')
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = 'e'`, schema, name).Scan(
+		&oid, &qualified, &owner, &comment)
+	if err != nil {
+		return nil, err
+	}
+	var labels []string
+	err = c.db.QueryRowContext(ctx, `
+		SELECT array_agg(quote_literal(enumlabel) ORDER BY enumsortorder)
+		FROM pg_enum WHERE enumtypid = $1`, oid).Scan(pq.Array(&labels))
+	if err != nil {
+		return nil, err
+	}
+	stmts := []string{"CREATE TYPE " + qualified + " AS ENUM (\n    " + strings.Join(labels, ",\n    ") + "\n);"}
+	stmts = append(stmts, commentStatement("TYPE "+qualified, comment)...)
+	stmts = append(stmts, "ALTER TYPE "+qualified+" OWNER TO "+owner+";")
+	grants, err := c.grantStatements(ctx, "TYPE "+qualified, owner,
+		`SELECT `+aclColumns+` FROM pg_type t, aclexplode(t.typacl) g WHERE t.oid = $1`, oid)
+	if err != nil {
+		return nil, err
+	}
+	return append(stmts, grants...), nil
+}
+
+func (c *Client) functionDDL(ctx context.Context, schema, name string) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT p.oid, pg_get_functiondef(p.oid),
+			CASE p.prokind WHEN 'p' THEN 'PROCEDURE' ELSE 'FUNCTION' END || ' '
+				|| quote_ident(n.nspname) || '.' || quote_ident(p.proname)
+				|| '(' || pg_get_function_identity_arguments(p.oid) || ')',
+			quote_ident(pg_get_userbyid(p.proowner)), COALESCE(obj_description(p.oid, 'pg_proc'), '')
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE n.nspname = $1 AND p.proname = $2 AND p.prokind <> 'a'
+		ORDER BY p.oid`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	type function struct {
+		oid                            int64
+		def, signature, owner, comment string
+	}
+	var fns []function
+	for rows.Next() {
+		var f function
+		if err := rows.Scan(&f.oid, &f.def, &f.signature, &f.owner, &f.comment); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		fns = append(fns, f)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var stmts []string
+	for _, f := range fns {
+		stmts = append(stmts, strings.TrimSpace(f.def)+";")
+		stmts = append(stmts, commentStatement(f.signature, f.comment)...)
+		stmts = append(stmts, "ALTER "+f.signature+" OWNER TO "+f.owner+";")
+		grants, err := c.grantStatements(ctx, f.signature, f.owner,
+			`SELECT `+aclColumns+` FROM pg_proc p, aclexplode(p.proacl) g WHERE p.oid = $1`, f.oid)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, grants...)
+	}
+	return stmts, nil
+}
+
+// appendRelationACL appends the owner and grants of a relation, including
+// column-level grants, to stmts.
+func (c *Client) appendRelationACL(ctx context.Context, stmts []string, keyword string, rel *relation) ([]string, error) {
+	stmts = append(stmts, "ALTER "+keyword+" "+rel.qualified+" OWNER TO "+rel.owner+";")
+	// GRANT takes TABLE for views and materialized views too.
+	grantOn := "TABLE"
+	if keyword == "SEQUENCE" {
+		grantOn = "SEQUENCE"
+	}
+	grants, err := c.grantStatements(ctx, grantOn+" "+rel.qualified, rel.owner,
+		`SELECT `+aclColumns+` FROM pg_class c, aclexplode(c.relacl) g WHERE c.oid = $1`, rel.oid)
+	if err != nil {
+		return nil, err
+	}
+	stmts = append(stmts, grants...)
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT quote_ident(a.attname), `+aclColumns+`
+		FROM pg_attribute a, aclexplode(a.attacl) g
+		WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, rel.oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var col, grantee, privilege string
+		var grantable bool
+		if err := rows.Scan(&col, &grantee, &privilege, &grantable); err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, grantStatement(privilege+" ("+col+")", "TABLE "+rel.qualified, grantee, grantable))
+	}
+	return stmts, rows.Err()
+}
+
+// grantStatements turns the ACL entries selected by query into GRANT
+// statements on target, one per grantee. The owner's own privileges are
+// implied and left out.
+func (c *Client) grantStatements(ctx context.Context, target, owner, query string, args ...any) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type grant struct {
+		grantee   string
+		grantable bool
+	}
+	var order []grant
+	privileges := make(map[grant][]string)
+	for rows.Next() {
+		var g grant
+		var privilege string
+		if err := rows.Scan(&g.grantee, &privilege, &g.grantable); err != nil {
+			return nil, err
+		}
+		if g.grantee == owner {
+			continue
+		}
+		if _, ok := privileges[g]; !ok {
+			order = append(order, g)
+		}
+		privileges[g] = append(privileges[g], privilege)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	stmts := make([]string, len(order))
+	for i, g := range order {
+		stmts[i] = grantStatement(strings.Join(privileges[g], ", "), target, g.grantee, g.grantable)
+	}
+	return stmts, nil
+}
+
+func grantStatement(privileges, target, grantee string, grantable bool) string {
+	stmt := "GRANT " + privileges + " ON " + target + " TO " + grantee
+	if grantable {
+		stmt += " WITH GRANT OPTION"
+	}
+	return stmt + ";"
+}
+
+// commentStatement returns a COMMENT ON statement for object, or nothing if
+// the comment is empty.
+func commentStatement(object, comment string) []string {
+	if comment == "" {
+		return nil
+	}
+	return []string{"COMMENT ON " + object + " IS " + strings.TrimSpace(pq.QuoteLiteral(comment)) + ";"}
+}
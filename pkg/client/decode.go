@@ -0,0 +1,213 @@
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decodeValue converts a value scanned by lib/pq into a faithful,
+// JSON-friendly cell based on the column's database type name:
+//
+//   - json/jsonb become json.RawMessage so they serialise as JSON values
+//   - arrays (type names starting with "_") become []any, recursively
+//   - numeric stays an exact decimal string
+//   - bytea becomes "\x"-prefixed hex, matching PostgreSQL's output
+//   - date/time types become ISO-8601 strings
+//   - integers, floats and booleans keep their native types, except
+//     non-finite floats which become strings ("NaN", "Infinity")
+//
+// Anything else (text, uuid, interval, ...) is returned as its text form.
+func decodeValue(typeName string, v any) any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if typeName == "BYTEA" {
+			return `\x` + hex.EncodeToString(t)
+		}
+		return decodeText(typeName, string(t))
+	case string:
+		return decodeText(typeName, t)
+	case time.Time:
+		return formatTime(typeName, t)
+	case float64:
+		return finiteFloat(t)
+	default:
+		return v
+	}
+}
+
+// decodeText converts the text representation of a value of typeName.
+func decodeText(typeName, s string) any {
+	switch {
+	case typeName == "JSON" || typeName == "JSONB":
+		if json.Valid([]byte(s)) {
+			return json.RawMessage(s)
+		}
+		return s
+	case strings.HasPrefix(typeName, "_"):
+		if arr, ok := parseArray(s, typeName[1:]); ok {
+			return arr
+		}
+		return s
+	}
+	return s
+}
+
+// decodeElement converts one unquoted array element of elemType from text.
+func decodeElement(elemType, s string) any {
+	switch elemType {
+	case "INT2", "INT4", "INT8", "OID":
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case "FLOAT4", "FLOAT8":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return finiteFloat(f)
+		}
+	case "BOOL":
+		return s == "t"
+	case "JSON", "JSONB":
+		if json.Valid([]byte(s)) {
+			return json.RawMessage(s)
+		}
+	case "DATE", "TIME", "TIMETZ", "TIMESTAMP", "TIMESTAMPTZ":
+		// PostgreSQL text output separates date and time with a space.
+		return strings.Replace(s, " ", "T", 1)
+	}
+	return s
+}
+
+func formatTime(typeName string, t time.Time) string {
+	switch typeName {
+	case "DATE":
+		return t.Format("2006-01-02")
+	case "TIME":
+		return t.Format("15:04:05.999999")
+	case "TIMETZ":
+		return t.Format("15:04:05.999999Z07:00")
+	case "TIMESTAMP":
+		return t.Format("2006-01-02T15:04:05.999999")
+	default:
+		return t.Format("2006-01-02T15:04:05.999999Z07:00")
+	}
+}
+
+// finiteFloat returns f, or its PostgreSQL spelling for values JSON cannot
+// represent.
+func finiteFloat(f float64) any {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// parseArray parses a PostgreSQL array literal such as {1,2,NULL} or
+// {{"a b","c\"d"},{e,f}} into nested []any, decoding elements as elemType.
+func parseArray(s, elemType string) ([]any, bool) {
+	// Skip explicit bounds decoration, e.g. [0:2]={1,2,3}
+	if strings.HasPrefix(s, "[") {
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			return nil, false
+		}
+		s = s[eq+1:]
+	}
+	p := arrayParser{s: s, elemType: elemType}
+	arr, ok := p.parse()
+	if !ok || p.pos != len(p.s) {
+		return nil, false
+	}
+	return arr, true
+}
+
+type arrayParser struct {
+	s        string
+	pos      int
+	elemType string
+}
+
+func (p *arrayParser) parse() ([]any, bool) {
+	if p.pos >= len(p.s) || p.s[p.pos] != '{' {
+		return nil, false
+	}
+	p.pos++
+	result := []any{}
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return result, true
+	}
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case '{':
+			sub, ok := p.parse()
+			if !ok {
+				return nil, false
+			}
+			result = append(result, sub)
+		case '"':
+			val, ok := p.quoted()
+			if !ok {
+				return nil, false
+			}
+			result = append(result, decodeElement(p.elemType, val))
+		default:
+			start := p.pos
+			for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != '}' {
+				p.pos++
+			}
+			val := strings.TrimSpace(p.s[start:p.pos])
+			if val == "NULL" {
+				result = append(result, nil)
+			} else {
+				result = append(result, decodeElement(p.elemType, val))
+			}
+		}
+		if p.pos >= len(p.s) {
+			return nil, false
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return result, true
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// quoted reads a double-quoted element, unescaping backslashes.
+func (p *arrayParser) quoted() (string, bool) {
+	p.pos++ // opening quote
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch c {
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.s) {
+				return "", false
+			}
+			b.WriteByte(p.s[p.pos])
+		case '"':
+			p.pos++
+			return b.String(), true
+		default:
+			b.WriteByte(c)
+		}
+		p.pos++
+	}
+	return "", false
+}
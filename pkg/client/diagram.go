@@ -0,0 +1,225 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DiagramFormat is a text format a relationship graph can be drawn in.
+type DiagramFormat string
+
+const (
+	DiagramMermaid  DiagramFormat = "mermaid"
+	DiagramDOT      DiagramFormat = "dot"
+	DiagramPlantUML DiagramFormat = "plantuml"
+)
+
+// Diagram renders the graph as an entity-relationship diagram: a Mermaid
+// erDiagram, a Graphviz digraph or a PlantUML entity diagram.
+func (g *RelationshipGraph) Diagram(format DiagramFormat) (string, error) {
+	switch format {
+	case DiagramMermaid:
+		return g.mermaid(), nil
+	case DiagramDOT:
+		return g.dot(), nil
+	case DiagramPlantUML:
+		return g.plantUML(), nil
+	}
+	return "", fmt.Errorf("unknown diagram format %q", format)
+}
+
+func (g *RelationshipGraph) mermaid() string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	id := g.diagramIDs()
+	for _, t := range g.Tables {
+		fmt.Fprintf(&b, "    %s[\"%s\"] {\n", id(t.Schema, t.Name), mermaidLabel(t.Schema+"."+t.Name))
+		for _, col := range t.Columns {
+			fmt.Fprintf(&b, "        %s %s", mermaidWord(col.Type), mermaidWord(col.Name))
+			var keys []string
+			if col.PrimaryKey {
+				keys = append(keys, "PK")
+			}
+			if col.ForeignKey {
+				keys = append(keys, "FK")
+			}
+			if len(keys) > 0 {
+				b.WriteString(" " + strings.Join(keys, ", "))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("    }\n")
+	}
+	for _, r := range g.Relationships {
+		fmt.Fprintf(&b, "    %s %s %s : \"%s\"\n", id(r.SourceSchema, r.SourceTable),
+			g.crowsFoot(r), id(r.TargetSchema, r.TargetTable), mermaidLabel(r.Name))
+	}
+	return b.String()
+}
+
+func (g *RelationshipGraph) dot() string {
+	var b strings.Builder
+	b.WriteString("digraph relationships {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=record];\n")
+	for _, t := range g.Tables {
+		fields := make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			field := col.Name + " : " + col.Type
+			if col.PrimaryKey {
+				field += " (PK)"
+			}
+			if col.ForeignKey {
+				field += " (FK)"
+			}
+			fields[i] = dotRecord(field) + `\l`
+		}
+		name := t.Schema + "." + t.Name
+		fmt.Fprintf(&b, "    %s [label=\"{%s|%s}\"];\n", dotQuote(name), dotRecord(name), strings.Join(fields, ""))
+	}
+	for _, r := range g.Relationships {
+		fmt.Fprintf(&b, "    %s -> %s [label=%s];\n", dotQuote(r.SourceSchema+"."+r.SourceTable),
+			dotQuote(r.TargetSchema+"."+r.TargetTable), dotQuote(r.Name))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (g *RelationshipGraph) plantUML() string {
+	var b strings.Builder
+	b.WriteString("@startuml\n")
+	b.WriteString("hide circle\n")
+	id := g.diagramIDs()
+	for _, t := range g.Tables {
+		fmt.Fprintf(&b, "entity \"%s\" as %s {\n", plantUMLLabel(t.Schema+"."+t.Name), id(t.Schema, t.Name))
+		var keys, others []string
+		for _, col := range t.Columns {
+			line := "  "
+			if !col.Nullable {
+				line += "* "
+			}
+			line += col.Name + " : " + col.Type
+			if col.PrimaryKey {
+				line += " <<PK>>"
+			}
+			if col.ForeignKey {
+				line += " <<FK>>"
+			}
+			if col.PrimaryKey {
+				keys = append(keys, line)
+			} else {
+				others = append(others, line)
+			}
+		}
+		for _, line := range keys {
+			b.WriteString(line + "\n")
+		}
+		if len(keys) > 0 {
+			b.WriteString("  --\n")
+		}
+		for _, line := range others {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("}\n")
+	}
+	for _, r := range g.Relationships {
+		fmt.Fprintf(&b, "%s %s %s : %s\n", id(r.SourceSchema, r.SourceTable),
+			g.crowsFoot(r), id(r.TargetSchema, r.TargetTable), r.Name)
+	}
+	b.WriteString("@enduml\n")
+	return b.String()
+}
+
+// crowsFoot is the crow's foot notation of a relationship, shared by Mermaid
+// and PlantUML. The source side is many unless its columns are unique; the
+// target side is optional if any source column is nullable.
+func (g *RelationshipGraph) crowsFoot(r Relationship) string {
+	source := "}o"
+	if r.Unique {
+		source = "|o"
+	}
+	target := "||"
+	for _, t := range g.Tables {
+		if t.Schema != r.SourceSchema || t.Name != r.SourceTable {
+			continue
+		}
+		for _, col := range t.Columns {
+			for _, name := range r.SourceColumns {
+				if col.Name == name && col.Nullable {
+					target = "o|"
+				}
+			}
+		}
+	}
+	return source + "--" + target
+}
+
+// diagramIDs returns a function giving each table of the graph an
+// identifier of its own. Tables whose names differ only in the characters
+// diagramID replaces, such as a_b.c and a.b_c, get a numeric suffix.
+func (g *RelationshipGraph) diagramIDs() func(schema, name string) string {
+	type table struct{ schema, name string }
+	ids := make(map[table]string, len(g.Tables))
+	used := make(map[string]bool, len(g.Tables))
+	for i, t := range g.Tables {
+		id := diagramID(t.Schema, t.Name)
+		for n := i + 1; used[id]; n++ {
+			id = diagramID(t.Schema, t.Name) + "_" + strconv.Itoa(n)
+		}
+		used[id] = true
+		ids[table{t.Schema, t.Name}] = id
+	}
+	return func(schema, name string) string {
+		if id, ok := ids[table{schema, name}]; ok {
+			return id
+		}
+		return diagramID(schema, name)
+	}
+}
+
+// diagramID is an identifier for a table that Mermaid and PlantUML accept
+// unquoted.
+func diagramID(schema, name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, schema+"_"+name)
+}
+
+// mermaidWord replaces the characters Mermaid does not allow in an
+// attribute name or type with underscores.
+func mermaidWord(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '_', r == '-', r == '(', r == ')', r == '[', r == ']':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// mermaidLabel escapes the double quotes that would end a quoted Mermaid
+// label.
+func mermaidLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// plantUMLLabel escapes the double quotes that would end a quoted PlantUML
+// name.
+func plantUMLLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "<U+0022>")
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// dotRecord escapes the characters that structure a record label.
+func dotRecord(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `{`, `\{`, `}`, `\}`,
+		`|`, `\|`, `<`, `\<`, `>`, `\>`).Replace(s)
+}
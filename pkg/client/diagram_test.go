@@ -0,0 +1,73 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagramIDsAreUnique(t *testing.T) {
+	g := &RelationshipGraph{
+		Tables: []GraphTable{
+			{Schema: "a_b", Name: "c"},
+			{Schema: "a", Name: "b_c"},
+			{Schema: "a", Name: "b-c"},
+			{Schema: "a", Name: "b_c_2"},
+		},
+		Relationships: []Relationship{
+			{Name: "fk", SourceSchema: "a", SourceTable: "b-c", TargetSchema: "a_b", TargetTable: "c"},
+		},
+	}
+	id := g.diagramIDs()
+	seen := map[string]bool{}
+	for _, tbl := range g.Tables {
+		got := id(tbl.Schema, tbl.Name)
+		if seen[got] {
+			t.Errorf("%s.%s: duplicate ID %q", tbl.Schema, tbl.Name, got)
+		}
+		seen[got] = true
+	}
+	if got := id("a_b", "c"); got != "a_b_c" {
+		t.Errorf("first table ID = %q, want a_b_c", got)
+	}
+	if got := id("x", "y"); got != "x_y" {
+		t.Errorf("ID of a table outside the graph = %q, want x_y", got)
+	}
+
+	for _, format := range []DiagramFormat{DiagramMermaid, DiagramPlantUML} {
+		out, err := g.Diagram(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := id("a", "b-c") + " }o--|| " + id("a_b", "c")
+		if !strings.Contains(out, want) {
+			t.Errorf("%s: relationship %q missing from\n%s", format, want, out)
+		}
+	}
+}
+
+func TestDiagramLabelQuotes(t *testing.T) {
+	g := &RelationshipGraph{
+		Tables: []GraphTable{{Schema: "public", Name: `say "hi"`}},
+		Relationships: []Relationship{
+			{Name: `fk "x"`, SourceSchema: "public", SourceTable: `say "hi"`, TargetSchema: "public", TargetTable: `say "hi"`},
+		},
+	}
+	tests := []struct {
+		format DiagramFormat
+		want   []string
+	}{
+		{DiagramMermaid, []string{`public_say__hi_["public.say #quot;hi#quot;"] {`, `: "fk #quot;x#quot;"`}},
+		{DiagramPlantUML, []string{`entity "public.say <U+0022>hi<U+0022>" as public_say__hi_ {`}},
+	}
+	for _, tt := range tests {
+		out, err := g.Diagram(tt.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s: %q missing from\n%s", tt.format, want, out)
+			}
+		}
+	}
+}
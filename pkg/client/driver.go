@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// driverName is lib/pq wrapped so that the command tag of a query (e.g.
+// "UPDATE 50000" or "CREATE TABLE") can be read back after its rows are
+// closed. database/sql does not expose it otherwise.
+const driverName = "pglet-postgres"
+
+func init() {
+	sql.Register(driverName, tagDriver{})
+}
+
+// CommandTag is the server's completion tag for a statement.
+type CommandTag struct {
+	Tag          string // command name, e.g. "INSERT" or "CREATE TABLE"
+	RowsAffected int64  // rows inserted, updated, deleted, copied, selected or fetched
+}
+
+// String renders the tag as psql prints it, e.g. "UPDATE 3" or "INSERT 0 3".
+func (t CommandTag) String() string {
+	switch t.Tag {
+	case "":
+		return ""
+	case "INSERT":
+		return "INSERT 0 " + strconv.FormatInt(t.RowsAffected, 10)
+	case "SELECT", "UPDATE", "DELETE", "MERGE", "FETCH", "MOVE", "COPY":
+		return t.Tag + " " + strconv.FormatInt(t.RowsAffected, 10)
+	}
+	return t.Tag
+}
+
+// parseCommandTag completes the tag lib/pq reports. lib/pq strips the row
+// count from the tags it knows about and passes it as the result; newer tags
+// such as MERGE still carry theirs.
+func parseCommandTag(tag string, res driver.Result) CommandTag {
+	t := CommandTag{Tag: tag}
+	if n, err := res.RowsAffected(); err == nil {
+		t.RowsAffected = n
+	}
+	if i := strings.LastIndexByte(tag, ' '); i > 0 {
+		if n, err := strconv.ParseInt(tag[i+1:], 10, 64); err == nil {
+			t.Tag, t.RowsAffected = tag[:i], n
+		}
+	}
+	return t
+}
+
+// tagKey is the context key under which a query's *CommandTag receiver is
+// stored.
+type tagKey struct{}
+
+// withCommandTag returns a context whose queries record their command tag
+// into the returned CommandTag once their rows are closed.
+func withCommandTag(ctx context.Context) (context.Context, *CommandTag) {
+	tag := &CommandTag{}
+	return context.WithValue(ctx, tagKey{}, tag), tag
+}
+
+type tagDriver struct{}
+
+func (tagDriver) Open(dsn string) (driver.Conn, error) {
+	cn, err := pq.Driver{}.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &tagConn{Conn: cn}, nil
+}
+
+// tagConn forwards to the lib/pq connection, wrapping query results so their
+// command tag is captured.
+type tagConn struct {
+	driver.Conn
+	pid int // backend process ID, once Session.BackendPID has looked it up
+}
+
+func (c *tagConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if tag, ok := ctx.Value(tagKey{}).(*CommandTag); ok {
+		return &tagRows{Rows: rows, tag: tag}, nil
+	}
+	return rows, nil
+}
+
+func (c *tagConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *tagConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+}
+
+func (c *tagConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *tagConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *tagConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *tagConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+func (c *tagConn) CheckNamedValue(nv *driver.NamedValue) error {
+	return c.Conn.(driver.NamedValueChecker).CheckNamedValue(nv)
+}
+
+// tagRows records the command tag when the result is closed, by which point
+// lib/pq has read the CommandComplete message.
+type tagRows struct {
+	driver.Rows
+	tag *CommandTag
+}
+
+func (r *tagRows) Close() error {
+	err := r.Rows.Close()
+	if pr, ok := r.Rows.(interface {
+		Tag() string
+		Result() driver.Result
+	}); ok {
+		*r.tag = parseCommandTag(pr.Tag(), pr.Result())
+	}
+	return err
+}
+
+func (r *tagRows) ColumnTypeDatabaseTypeName(i int) string {
+	return r.Rows.(driver.RowsColumnTypeDatabaseTypeName).ColumnTypeDatabaseTypeName(i)
+}
+
+func (r *tagRows) ColumnTypeScanType(i int) reflect.Type {
+	return r.Rows.(driver.RowsColumnTypeScanType).ColumnTypeScanType(i)
+}
+
+func (r *tagRows) ColumnTypeLength(i int) (int64, bool) {
+	return r.Rows.(driver.RowsColumnTypeLength).ColumnTypeLength(i)
+}
+
+func (r *tagRows) ColumnTypePrecisionScale(i int) (int64, int64, bool) {
+	return r.Rows.(driver.RowsColumnTypePrecisionScale).ColumnTypePrecisionScale(i)
+}
+
+func (r *tagRows) HasNextResultSet() bool {
+	return r.Rows.(driver.RowsNextResultSet).HasNextResultSet()
+}
+
+func (r *tagRows) NextResultSet() error {
+	return r.Rows.(driver.RowsNextResultSet).NextResultSet()
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/lib/pq"
+)
+
+// ErrorDetails are the fields of an error reported by the server. Optional
+// fields are empty, and positions 0, when the server did not send them.
+type ErrorDetails struct {
+	Severity         string
+	Code             string // SQLSTATE, e.g. "23505"
+	Condition        string // condition name, e.g. "unique_violation"
+	Message          string
+	Detail           string
+	Hint             string
+	Position         int // 1-based character position in the statement
+	InternalPosition int // position in InternalQuery
+	InternalQuery    string
+	Where            string // call stack context, e.g. "PL/pgSQL function f() line 3"
+	Schema           string
+	Table            string
+	Column           string
+	DataType         string
+	Constraint       string
+}
+
+// ServerError returns the details of err if it, or an error it wraps, was
+// reported by the server, and nil otherwise.
+func ServerError(err error) *ErrorDetails {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return nil
+	}
+	pos, _ := strconv.Atoi(pqErr.Position)
+	internalPos, _ := strconv.Atoi(pqErr.InternalPosition)
+	return &ErrorDetails{
+		Severity:         pqErr.Severity,
+		Code:             string(pqErr.Code),
+		Condition:        pqErr.Code.Name(),
+		Message:          pqErr.Message,
+		Detail:           pqErr.Detail,
+		Hint:             pqErr.Hint,
+		Position:         pos,
+		InternalPosition: internalPos,
+		InternalQuery:    pqErr.InternalQuery,
+		Where:            pqErr.Where,
+		Schema:           pqErr.Schema,
+		Table:            pqErr.Table,
+		Column:           pqErr.Column,
+		DataType:         pqErr.DataTypeName,
+		Constraint:       pqErr.Constraint,
+	}
+}
+
+// ErrorPosition returns the 1-based character position in the statement at
+// which the server reported err, or 0 if it did not report one.
+func ErrorPosition(err error) int {
+	if d := ServerError(err); d != nil {
+		return d.Position
+	}
+	return 0
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// explainPlan is the subset of an EXPLAIN (FORMAT JSON) node needed for row
+// estimates.
+type explainPlan struct {
+	NodeType string        `json:"Node Type"`
+	PlanRows float64       `json:"Plan Rows"`
+	Plans    []explainPlan `json:"Plans"`
+}
+
+// EstimateRows returns the planner's row estimate for stmt without running
+// it. For INSERT, UPDATE, DELETE and MERGE this is the number of rows the
+// statement would touch rather than the number it returns. args bind any
+// placeholders in stmt.
+func (c *Client) EstimateRows(ctx context.Context, stmt string, args ...any) (int64, error) {
+	var raw []byte
+	if err := c.db.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+stmt, args...).Scan(&raw); err != nil {
+		return 0, err
+	}
+	var out []struct {
+		Plan explainPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return 0, fmt.Errorf("parse plan: %w", err)
+	}
+	if len(out) == 0 {
+		return 0, fmt.Errorf("empty plan")
+	}
+	plan := out[0].Plan
+	if plan.NodeType == "ModifyTable" && len(plan.Plans) > 0 {
+		plan = plan.Plans[0]
+	}
+	return int64(plan.PlanRows), nil
+}
+
+// TableRowEstimate returns the row count recorded in pg_class for a table,
+// or false if the relation does not exist or has never been analyzed.
+func (c *Client) TableRowEstimate(ctx context.Context, name string) (int64, bool) {
+	var n float64
+	err := c.db.QueryRowContext(ctx, "SELECT reltuples FROM pg_class WHERE oid = to_regclass($1)", name).Scan(&n)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return int64(n), true
+}
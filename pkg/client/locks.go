@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lib/pq"
+)
+
+// Lock is a lock a session holds or waits for.
+type Lock struct {
+	LockType string // e.g. "relation", "tuple" or "transactionid"
+	Mode     string // e.g. "RowExclusiveLock"
+	Relation string // empty for locks not on a relation
+	Granted  bool
+}
+
+// BlockingSession is a session in a blocking tree: one that is waiting for
+// a lock, or holding one that another session waits for.
+type BlockingSession struct {
+	PID           int
+	Database      string
+	User          string
+	Application   string
+	State         string
+	Query         string
+	WaitEventType string
+	WaitEvent     string
+	// WaitingMs is how long the session has waited for its lock, nil if it
+	// is not waiting. Before PostgreSQL 14 it is measured from the start of
+	// the waiting statement.
+	WaitingMs *float64
+	XactMs    *float64 // age of the open transaction, nil if there is none
+	// WaitingFor is the lock the session is waiting for, nil if none.
+	WaitingFor *Lock
+	// Locks are the relation locks the session holds.
+	Locks []Lock
+	// BlockedBy lists the PIDs holding or queued ahead for the lock the
+	// session waits for, as reported by pg_blocking_pids.
+	BlockedBy []int
+	// Blocking are the sessions waiting on this one. A session blocked by
+	// several others appears under each of them.
+	Blocking []*BlockingSession
+}
+
+// BlockingTree returns the sessions involved in lock waits as a forest
+// rooted at the blockers that are not themselves waiting. Cancelling or
+// terminating a root releases the waits beneath it.
+func (c *Client) BlockingTree(ctx context.Context) ([]*BlockingSession, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	waitStart := "a.query_start"
+	if version >= 140000 {
+		waitStart = "COALESCE(w.waitstart, a.query_start)"
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		WITH blocked AS (
+			SELECT pid, pg_blocking_pids(pid) AS blockers
+			FROM pg_stat_activity
+			WHERE cardinality(pg_blocking_pids(pid)) > 0
+		), involved AS (
+			SELECT pid FROM blocked
+			UNION
+			SELECT unnest(blockers) FROM blocked
+		)
+		SELECT a.pid, COALESCE(a.datname, ''), COALESCE(a.usename, ''),
+			COALESCE(a.application_name, ''), COALESCE(a.state, ''), COALESCE(a.query, ''),
+			COALESCE(a.wait_event_type, ''), COALESCE(a.wait_event, ''),
+			CASE WHEN w.pid IS NOT NULL THEN EXTRACT(EPOCH FROM now() - `+waitStart+`)::float8 * 1000 END,
+			EXTRACT(EPOCH FROM now() - a.xact_start)::float8 * 1000,
+			w.locktype, w.mode, COALESCE(w.relation::regclass::text, ''),
+			COALESCE(b.blockers, '{}')
+		FROM involved i
+		JOIN pg_stat_activity a ON a.pid = i.pid
+		LEFT JOIN blocked b ON b.pid = a.pid
+		LEFT JOIN pg_locks w ON w.pid = a.pid AND NOT w.granted
+		ORDER BY a.pid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make(map[int]*BlockingSession)
+	var pids []int
+	for rows.Next() {
+		s := &BlockingSession{Locks: []Lock{}}
+		var lockType, mode *string
+		var relation string
+		var blockers pq.Int64Array
+		if err := rows.Scan(&s.PID, &s.Database, &s.User, &s.Application, &s.State, &s.Query,
+			&s.WaitEventType, &s.WaitEvent, &s.WaitingMs, &s.XactMs,
+			&lockType, &mode, &relation, &blockers); err != nil {
+			return nil, err
+		}
+		if lockType != nil {
+			s.WaitingFor = &Lock{LockType: *lockType, Mode: *mode, Relation: relation}
+		}
+		s.BlockedBy = make([]int, len(blockers))
+		for i, b := range blockers {
+			s.BlockedBy[i] = int(b)
+		}
+		// A session waits for at most one lock, but guard against duplicates.
+		if _, ok := sessions[s.PID]; !ok {
+			sessions[s.PID] = s
+			pids = append(pids, s.PID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(pids) == 0 {
+		return []*BlockingSession{}, nil
+	}
+	if err := c.heldLocks(ctx, sessions, pids); err != nil {
+		return nil, err
+	}
+	return buildBlockingTree(sessions, pids), nil
+}
+
+// heldLocks fills in the relation locks held by the given sessions.
+func (c *Client) heldLocks(ctx context.Context, sessions map[int]*BlockingSession, pids []int) error {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT pid, locktype, mode, relation::regclass::text
+		FROM pg_locks
+		WHERE granted AND relation IS NOT NULL AND pid = ANY($1)
+		ORDER BY pid, relation::regclass::text, mode`, pq.Array(pids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pid int
+		l := Lock{Granted: true}
+		if err := rows.Scan(&pid, &l.LockType, &l.Mode, &l.Relation); err != nil {
+			return err
+		}
+		if s, ok := sessions[pid]; ok {
+			s.Locks = append(s.Locks, l)
+		}
+	}
+	return rows.Err()
+}
+
+// buildBlockingTree links sessions under the sessions blocking them. Roots
+// are sessions with no blocker among those listed: blockers that are not
+// waiting, or waiters blocked by a prepared transaction. Sessions in a
+// deadlock cycle are not reached from such a root, so the first of them by
+// PID is made one.
+func buildBlockingTree(sessions map[int]*BlockingSession, pids []int) []*BlockingSession {
+	sort.Ints(pids)
+	children := make(map[int][]int)
+	var roots []int
+	for _, pid := range pids {
+		root := true
+		for _, b := range sessions[pid].BlockedBy {
+			if _, ok := sessions[b]; ok && b != pid {
+				children[b] = append(children[b], pid)
+				root = false
+			}
+		}
+		if root {
+			roots = append(roots, pid)
+		}
+	}
+
+	reached := make(map[int]bool)
+	var build func(pid int, path map[int]bool) *BlockingSession
+	build = func(pid int, path map[int]bool) *BlockingSession {
+		reached[pid] = true
+		path[pid] = true
+		defer delete(path, pid)
+		s := *sessions[pid]
+		s.Blocking = []*BlockingSession{}
+		for _, child := range children[pid] {
+			if !path[child] {
+				s.Blocking = append(s.Blocking, build(child, path))
+			}
+		}
+		return &s
+	}
+
+	forest := []*BlockingSession{}
+	for _, pid := range roots {
+		forest = append(forest, build(pid, map[int]bool{}))
+	}
+	for _, pid := range pids {
+		if !reached[pid] {
+			forest = append(forest, build(pid, map[int]bool{}))
+		}
+	}
+	return forest
+}
@@ -0,0 +1,573 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// objectCategories are the SchemaGroup categories beyond tables, views,
+// functions, sequences and types. Each query returns the schema, name,
+// parent and comment of the objects, in order.
+var objectCategories = []struct {
+	kind  string
+	group func(*SchemaGroup) *[]SchemaObject
+	query string
+}{
+	{"domain", func(g *SchemaGroup) *[]SchemaObject { return &g.Domains }, `
+		SELECT n.nspname, t.typname, '', COALESCE(obj_description(t.oid, 'pg_type'), '')
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE t.typtype = 'd'
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		ORDER BY n.nspname, t.typname`},
+	{"foreign_table", func(g *SchemaGroup) *[]SchemaObject { return &g.ForeignTables }, `
+		SELECT n.nspname, c.relname, '', COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'f'
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		ORDER BY n.nspname, c.relname`},
+	{"partition", func(g *SchemaGroup) *[]SchemaObject { return &g.Partitions }, `
+		SELECT n.nspname, c.relname,
+			CASE WHEN pn.nspname = n.nspname THEN p.relname ELSE pn.nspname || '.' || p.relname END,
+			COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_inherits i ON i.inhrelid = c.oid
+		JOIN pg_class p ON p.oid = i.inhparent
+		JOIN pg_namespace pn ON pn.oid = p.relnamespace
+		WHERE c.relispartition
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		ORDER BY n.nspname, p.relname, c.relname`},
+	{"trigger", func(g *SchemaGroup) *[]SchemaObject { return &g.Triggers }, `
+		SELECT n.nspname, t.tgname, c.relname, COALESCE(obj_description(t.oid, 'pg_trigger'), '')
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE NOT t.tgisinternal
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		ORDER BY n.nspname, c.relname, t.tgname`},
+	{"policy", func(g *SchemaGroup) *[]SchemaObject { return &g.Policies }, `
+		SELECT n.nspname, pol.polname, c.relname, COALESCE(obj_description(pol.oid, 'pg_policy'), '')
+		FROM pg_policy pol
+		JOIN pg_class c ON c.oid = pol.polrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		ORDER BY n.nspname, c.relname, pol.polname`},
+	{"extension", func(g *SchemaGroup) *[]SchemaObject { return &g.Extensions }, `
+		SELECT n.nspname, e.extname, '', COALESCE(obj_description(e.oid, 'pg_extension'), '')
+		FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		ORDER BY n.nspname, e.extname`},
+	{"publication", func(g *SchemaGroup) *[]SchemaObject { return &g.Publications }, `
+		SELECT DISTINCT pt.schemaname, p.pubname, '', COALESCE(obj_description(p.oid, 'pg_publication'), '')
+		FROM pg_publication p
+		JOIN pg_publication_tables pt ON pt.pubname = p.pubname
+		ORDER BY 1, 2`},
+	{"subscription", func(g *SchemaGroup) *[]SchemaObject { return &g.Subscriptions }, `
+		SELECT DISTINCT n.nspname, s.subname, '', COALESCE(shobj_description(s.oid, 'pg_subscription'), '')
+		FROM pg_subscription s
+		JOIN pg_subscription_rel sr ON sr.srsubid = s.oid
+		JOIN pg_class c ON c.oid = sr.srrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE s.subdbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+		ORDER BY 1, 2`},
+}
+
+// moreObjects adds the objectCategories to the groups. Like the other
+// categories, one the server cannot list is left empty.
+func (c *Client) moreObjects(ensureGroup func(schema string) *SchemaGroup) {
+	for _, cat := range objectCategories {
+		rows, err := c.db.Query(cat.query)
+		if err != nil {
+			continue
+		}
+		for rows.Next() {
+			obj := SchemaObject{Type: cat.kind}
+			if err := rows.Scan(&obj.Schema, &obj.Name, &obj.Parent, &obj.Comment); err != nil {
+				continue
+			}
+			list := cat.group(ensureGroup(obj.Schema))
+			*list = append(*list, obj)
+		}
+		rows.Close()
+	}
+}
+
+type Trigger struct {
+	Name     string
+	Timing   string   // "BEFORE", "AFTER" or "INSTEAD OF"
+	Events   []string // "INSERT", "UPDATE", "DELETE" and "TRUNCATE"
+	Level    string   // "ROW" or "STATEMENT"
+	Function string
+	// Enabled is "origin" (the default), "replica", "always" or "disabled",
+	// following session_replication_role.
+	Enabled    string
+	Definition string
+	Comment    string
+}
+
+// Trigger type bits of pg_trigger.tgtype.
+const (
+	triggerRow      = 1 << 0
+	triggerBefore   = 1 << 1
+	triggerInsert   = 1 << 2
+	triggerDelete   = 1 << 3
+	triggerUpdate   = 1 << 4
+	triggerTruncate = 1 << 5
+	triggerInstead  = 1 << 6
+)
+
+func (c *Client) TableTriggers(ctx context.Context, table string) ([]Trigger, error) {
+	schema, name := splitTableName(table)
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT t.tgname, t.tgtype, t.tgfoid::regproc::text,
+			CASE t.tgenabled WHEN 'O' THEN 'origin' WHEN 'R' THEN 'replica' WHEN 'A' THEN 'always' ELSE 'disabled' END,
+			pg_get_triggerdef(t.oid, true), COALESCE(obj_description(t.oid, 'pg_trigger'), '')
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2 AND NOT t.tgisinternal
+		ORDER BY t.tgname`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	triggers := []Trigger{}
+	for rows.Next() {
+		var t Trigger
+		var bits int
+		if err := rows.Scan(&t.Name, &bits, &t.Function, &t.Enabled, &t.Definition, &t.Comment); err != nil {
+			return nil, err
+		}
+		t.Timing, t.Level, t.Events = triggerType(bits)
+		triggers = append(triggers, t)
+	}
+	return triggers, rows.Err()
+}
+
+// triggerType decodes pg_trigger.tgtype.
+func triggerType(bits int) (timing, level string, events []string) {
+	switch {
+	case bits&triggerBefore != 0:
+		timing = "BEFORE"
+	case bits&triggerInstead != 0:
+		timing = "INSTEAD OF"
+	default:
+		timing = "AFTER"
+	}
+	level = "STATEMENT"
+	if bits&triggerRow != 0 {
+		level = "ROW"
+	}
+	events = []string{}
+	for _, e := range []struct {
+		bit  int
+		name string
+	}{{triggerInsert, "INSERT"}, {triggerUpdate, "UPDATE"}, {triggerDelete, "DELETE"}, {triggerTruncate, "TRUNCATE"}} {
+		if bits&e.bit != 0 {
+			events = append(events, e.name)
+		}
+	}
+	return timing, level, events
+}
+
+// Policy is a row-level security policy.
+type Policy struct {
+	Name       string
+	Command    string // "ALL", "SELECT", "INSERT", "UPDATE" or "DELETE"
+	Permissive bool
+	Roles      []string
+	Using      string
+	WithCheck  string
+}
+
+// TablePolicies returns the row-level security policies of a table, and
+// whether row-level security is enabled and forced on it.
+func (c *Client) TablePolicies(ctx context.Context, table string) (policies []Policy, enabled, forced bool, err error) {
+	schema, name := splitTableName(table)
+	err = c.db.QueryRowContext(ctx, `
+		SELECT c.relrowsecurity, c.relforcerowsecurity
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2`, schema, name).Scan(&enabled, &forced)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, false, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT policyname, cmd, permissive = 'PERMISSIVE', roles::text[],
+			COALESCE(qual, ''), COALESCE(with_check, '')
+		FROM pg_policies
+		WHERE schemaname = $1 AND tablename = $2
+		ORDER BY policyname`, schema, name)
+	if err != nil {
+		return nil, false, false, err
+	}
+	defer rows.Close()
+	policies = []Policy{}
+	for rows.Next() {
+		var p Policy
+		if err := rows.Scan(&p.Name, &p.Command, &p.Permissive, pq.Array(&p.Roles), &p.Using, &p.WithCheck); err != nil {
+			return nil, false, false, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, enabled, forced, rows.Err()
+}
+
+// PartitionNode is a table in a partition or inheritance hierarchy.
+type PartitionNode struct {
+	Schema string
+	Name   string
+	Bound  string // partition bound, e.g. "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')"
+	Key    string // partition key of a partitioned table, e.g. "RANGE (created_at)"
+	// Parent is the table this one is a partition of, set on the root only
+	// when it is itself a partition.
+	Parent   string
+	Children []*PartitionNode
+}
+
+// PartitionTree returns a table with its partitions, or its inheritance
+// children, recursively.
+func (c *Client) PartitionTree(ctx context.Context, table string) (*PartitionNode, error) {
+	schema, name := splitTableName(table)
+	rows, err := c.db.QueryContext(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT c.oid, 0::oid AS parent, 0 AS depth
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p', 'f')
+			UNION ALL
+			SELECT i.inhrelid, i.inhparent, tree.depth + 1
+			FROM tree
+			JOIN pg_inherits i ON i.inhparent = tree.oid
+		)
+		SELECT tree.oid, tree.parent, n.nspname, c.relname,
+			COALESCE(pg_get_expr(c.relpartbound, c.oid), ''),
+			CASE WHEN c.relkind = 'p' THEN pg_get_partkeydef(c.oid) ELSE '' END,
+			CASE WHEN tree.depth = 0 THEN COALESCE((SELECT pn.nspname || '.' || p.relname
+				FROM pg_inherits i
+				JOIN pg_class p ON p.oid = i.inhparent
+				JOIN pg_namespace pn ON pn.oid = p.relnamespace
+				WHERE i.inhrelid = c.oid AND c.relispartition), '') ELSE '' END
+		FROM tree
+		JOIN pg_class c ON c.oid = tree.oid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		ORDER BY tree.depth, n.nspname, c.relname`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var root *PartitionNode
+	nodes := make(map[int64]*PartitionNode)
+	for rows.Next() {
+		var oid, parent int64
+		node := &PartitionNode{Children: []*PartitionNode{}}
+		if err := rows.Scan(&oid, &parent, &node.Schema, &node.Name, &node.Bound, &node.Key, &node.Parent); err != nil {
+			return nil, err
+		}
+		nodes[oid] = node
+		if p, ok := nodes[parent]; ok {
+			p.Children = append(p.Children, node)
+		} else if root == nil {
+			root = node
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, ErrObjectNotFound
+	}
+	return root, nil
+}
+
+type Extension struct {
+	Name           string
+	Schema         string
+	Version        string
+	DefaultVersion string // version CREATE EXTENSION installs now; differs after an upgrade of the package
+	Relocatable    bool
+	Comment        string
+}
+
+func (c *Client) Extension(ctx context.Context, name string) (*Extension, error) {
+	e := &Extension{}
+	err := c.db.QueryRowContext(ctx, `
+		SELECT e.extname, n.nspname, e.extversion, COALESCE(a.default_version, ''),
+			e.extrelocatable, COALESCE(obj_description(e.oid, 'pg_extension'), '')
+		FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		LEFT JOIN pg_available_extensions a ON a.name = e.extname
+		WHERE e.extname = $1`, name).Scan(
+		&e.Name, &e.Schema, &e.Version, &e.DefaultVersion, &e.Relocatable, &e.Comment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+type Domain struct {
+	Name        string
+	Schema      string
+	BaseType    string
+	NotNull     bool
+	Default     string
+	Collation   string
+	Constraints []TableConstraint // CHECK constraints
+	Comment     string
+}
+
+func (c *Client) Domain(ctx context.Context, schema, name string) (*Domain, error) {
+	d := &Domain{Constraints: []TableConstraint{}}
+	var oid int64
+	err := c.db.QueryRowContext(ctx, `
+		SELECT t.oid, t.typname, n.nspname, format_type(t.typbasetype, t.typtypmod), t.typnotnull,
+			COALESCE(t.typdefault, ''), COALESCE(co.collname, ''),
+			COALESCE(obj_description(t.oid, 'pg_type'), '')
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_collation co ON co.oid = t.typcollation AND t.typcollation <> (
+			SELECT b.typcollation FROM pg_type b WHERE b.oid = t.typbasetype)
+		WHERE n.nspname = $1 AND t.typname = $2 AND t.typtype = 'd'`, schema, name).Scan(
+		&oid, &d.Name, &d.Schema, &d.BaseType, &d.NotNull, &d.Default, &d.Collation, &d.Comment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT conname, contype::text, pg_get_constraintdef(oid, true)
+		FROM pg_constraint
+		WHERE contypid = $1 AND contype = 'c'
+		ORDER BY conname`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var con TableConstraint
+		if err := rows.Scan(&con.Name, &con.Type, &con.Definition); err != nil {
+			return nil, err
+		}
+		d.Constraints = append(d.Constraints, con)
+	}
+	return d, rows.Err()
+}
+
+// TypeDetail describes an enum, composite or range type.
+type TypeDetail struct {
+	Name       string
+	Schema     string
+	Kind       string          // "enum", "composite" or "range"
+	Labels     []string        // enum labels in order
+	Attributes []TypeAttribute // composite attributes in order
+	Subtype    string          // element type of a range
+	Multirange string          // multirange type of a range, PostgreSQL 14 and later
+	Comment    string
+}
+
+type TypeAttribute struct {
+	Name string
+	Type string
+}
+
+func (c *Client) TypeDetail(ctx context.Context, schema, name string) (*TypeDetail, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	multirange := "''"
+	if version >= 140000 {
+		multirange = "COALESCE(r.rngmultitypid::regtype::text, '')"
+	}
+
+	td := &TypeDetail{Labels: []string{}, Attributes: []TypeAttribute{}}
+	var oid, relid int64
+	err = c.db.QueryRowContext(ctx, `
+		SELECT t.oid, t.typname, n.nspname,
+			CASE t.typtype WHEN 'e' THEN 'enum' WHEN 'r' THEN 'range' ELSE 'composite' END,
+			t.typrelid, COALESCE(r.rngsubtype::regtype::text, ''), `+multirange+`,
+			COALESCE(obj_description(t.oid, 'pg_type'), '')
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_class c ON c.oid = t.typrelid
+		LEFT JOIN pg_range r ON r.rngtypid = t.oid
+		WHERE n.nspname = $1 AND t.typname = $2
+			AND (t.typtype IN ('e', 'r') OR t.typtype = 'c' AND c.relkind = 'c')`, schema, name).Scan(
+		&oid, &td.Name, &td.Schema, &td.Kind, &relid, &td.Subtype, &td.Multirange, &td.Comment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch td.Kind {
+	case "enum":
+		err = c.db.QueryRowContext(ctx, `
+			SELECT COALESCE(array_agg(enumlabel ORDER BY enumsortorder), '{}')
+			FROM pg_enum WHERE enumtypid = $1`, oid).Scan(pq.Array(&td.Labels))
+		return td, err
+	case "composite":
+		rows, err := c.db.QueryContext(ctx, `
+			SELECT attname, format_type(atttypid, atttypmod)
+			FROM pg_attribute
+			WHERE attrelid = $1 AND attnum > 0 AND NOT attisdropped
+			ORDER BY attnum`, relid)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var a TypeAttribute
+			if err := rows.Scan(&a.Name, &a.Type); err != nil {
+				return nil, err
+			}
+			td.Attributes = append(td.Attributes, a)
+		}
+		return td, rows.Err()
+	}
+	return td, nil
+}
+
+type ForeignTable struct {
+	Name    string
+	Schema  string
+	Server  string
+	Wrapper string   // foreign-data wrapper of the server
+	Options []string // "key=value"
+	Comment string
+}
+
+func (c *Client) ForeignTable(ctx context.Context, table string) (*ForeignTable, error) {
+	schema, name := splitTableName(table)
+	ft := &ForeignTable{}
+	err := c.db.QueryRowContext(ctx, `
+		SELECT c.relname, n.nspname, s.srvname, w.fdwname, COALESCE(f.ftoptions, '{}'),
+			COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_foreign_table f
+		JOIN pg_class c ON c.oid = f.ftrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_foreign_server s ON s.oid = f.ftserver
+		JOIN pg_foreign_data_wrapper w ON w.oid = s.srvfdw
+		WHERE n.nspname = $1 AND c.relname = $2`, schema, name).Scan(
+		&ft.Name, &ft.Schema, &ft.Server, &ft.Wrapper, pq.Array(&ft.Options), &ft.Comment)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ft, nil
+}
+
+type Publication struct {
+	Name      string
+	Owner     string
+	AllTables bool
+	// Insert, Update, Delete and Truncate are the operations published.
+	Insert, Update, Delete, Truncate bool
+	Tables                           []string // schema-qualified
+}
+
+func (c *Client) Publication(ctx context.Context, name string) (*Publication, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	truncate := "false"
+	if version >= 110000 {
+		truncate = "p.pubtruncate"
+	}
+
+	p := &Publication{}
+	err = c.db.QueryRowContext(ctx, `
+		SELECT p.pubname, pg_get_userbyid(p.pubowner), p.puballtables,
+			p.pubinsert, p.pubupdate, p.pubdelete, `+truncate+`,
+			ARRAY(SELECT pt.schemaname || '.' || pt.tablename FROM pg_publication_tables pt
+				WHERE pt.pubname = p.pubname ORDER BY 1)
+		FROM pg_publication p
+		WHERE p.pubname = $1`, name).Scan(
+		&p.Name, &p.Owner, &p.AllTables, &p.Insert, &p.Update, &p.Delete, &p.Truncate, pq.Array(&p.Tables))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+type Subscription struct {
+	Name         string
+	Owner        string
+	Enabled      bool
+	Publications []string
+	SlotName     string
+	Tables       []SubscriptionTable
+}
+
+// SubscriptionTable is a table a subscription replicates into, with its
+// synchronization state: "init", "data copy", "copied", "synchronized" or
+// "ready".
+type SubscriptionTable struct {
+	Table string
+	State string
+}
+
+// Subscription describes a subscription of the current database. Its
+// connection string is left out, as it may hold a password.
+func (c *Client) Subscription(ctx context.Context, name string) (*Subscription, error) {
+	s := &Subscription{Tables: []SubscriptionTable{}}
+	var oid int64
+	err := c.db.QueryRowContext(ctx, `
+		SELECT s.oid, s.subname, pg_get_userbyid(s.subowner), s.subenabled,
+			s.subpublications, COALESCE(s.subslotname, '')
+		FROM pg_subscription s
+		WHERE s.subname = $1 AND s.subdbid = (SELECT oid FROM pg_database WHERE datname = current_database())`,
+		name).Scan(&oid, &s.Name, &s.Owner, &s.Enabled, pq.Array(&s.Publications), &s.SlotName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT n.nspname || '.' || c.relname,
+			CASE sr.srsubstate WHEN 'i' THEN 'init' WHEN 'd' THEN 'data copy' WHEN 'f' THEN 'copied'
+				WHEN 's' THEN 'synchronized' ELSE 'ready' END
+		FROM pg_subscription_rel sr
+		JOIN pg_class c ON c.oid = sr.srrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE sr.srsubid = $1
+		ORDER BY 1`, oid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t SubscriptionTable
+		if err := rows.Scan(&t.Table, &t.State); err != nil {
+			return nil, err
+		}
+		s.Tables = append(s.Tables, t)
+	}
+	return s, rows.Err()
+}
@@ -0,0 +1,352 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MisestimateFactor is how far the planner's row estimate may be from the
+// actual row count, in either direction, before a node is flagged.
+const MisestimateFactor = 10
+
+// slowestNodes is how many nodes a Plan lists by exclusive time.
+const slowestNodes = 3
+
+// ExplainOptions selects what EXPLAIN measures.
+type ExplainOptions struct {
+	// Analyze runs the statement to collect actual row counts, timing and
+	// buffer usage.
+	Analyze bool
+	// Rollback runs an analyzed statement inside RollbackAfter, so any
+	// changes it makes are undone.
+	Rollback bool
+}
+
+// Plan is a parsed EXPLAIN (FORMAT JSON) result.
+type Plan struct {
+	Root        *PlanNode
+	Analyzed    bool
+	RolledBack  bool // the statement ran in a transaction that was rolled back
+	PlanningMs  *float64
+	ExecutionMs *float64
+	Settings    map[string]string // non-default planner settings
+	// Slowest lists the IDs of the nodes with the highest exclusive time,
+	// slowest first. It is empty unless the plan was analyzed.
+	Slowest []int
+	// Raw is the EXPLAIN output the plan was parsed from, for storage.
+	Raw json.RawMessage
+}
+
+// PlanNode is one node of a plan tree. Actual* fields, buffers and derived
+// timings are only set when the plan was analyzed.
+type PlanNode struct {
+	ID                 int // pre-order index in the tree, starting at 0
+	NodeType           string
+	ParentRelationship string
+	Relation           string
+	Schema             string
+	Alias              string
+	Index              string
+	JoinType           string
+	Strategy           string
+	Output             []string
+	// Conditions holds the node's filter and join conditions, keyed by kind,
+	// e.g. "Filter", "Index Cond" or "Hash Cond".
+	Conditions map[string]string
+
+	StartupCost float64
+	TotalCost   float64
+	PlanRows    float64
+	PlanWidth   int
+
+	ActualStartupMs     *float64
+	ActualTotalMs       *float64
+	ActualRows          *float64
+	ActualLoops         *float64
+	RowsRemovedByFilter *float64
+	Buffers             *PlanBuffers
+	IOReadMs            *float64
+	IOWriteMs           *float64
+
+	// InclusiveMs is the node's total time across all loops, and ExclusiveMs
+	// the part not spent in its children.
+	InclusiveMs *float64
+	ExclusiveMs *float64
+	// RowsRatio is actual rows divided by estimated rows, per loop: above 1
+	// the planner underestimated, below 1 it overestimated.
+	RowsRatio    *float64
+	Misestimated bool // RowsRatio is off by MisestimateFactor or more
+
+	// Extra holds the remaining properties EXPLAIN reported for the node.
+	Extra map[string]any
+
+	Children []*PlanNode
+}
+
+// PlanBuffers are block counts from EXPLAIN (BUFFERS).
+type PlanBuffers struct {
+	SharedHit     int64
+	SharedRead    int64
+	SharedDirtied int64
+	SharedWritten int64
+	LocalHit      int64
+	LocalRead     int64
+	LocalDirtied  int64
+	LocalWritten  int64
+	TempRead      int64
+	TempWritten   int64
+}
+
+// Explain runs EXPLAIN with VERBOSE, SETTINGS and FORMAT JSON, plus ANALYZE
+// and BUFFERS when requested, on stmt with optional bind arguments. With
+// opts.Analyze the statement really executes, and its changes persist unless
+// opts.Rollback is set.
+func (s *Session) Explain(ctx context.Context, stmt string, opts ExplainOptions, args ...any) (*Plan, error) {
+	var version int
+	err := s.conn.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::int").Scan(&version)
+	if err != nil {
+		return nil, err
+	}
+	options := []string{"VERBOSE"}
+	if opts.Analyze {
+		options = append(options, "ANALYZE", "BUFFERS")
+	} else if version >= 130000 {
+		// Planning buffers; before 13 BUFFERS required ANALYZE.
+		options = append(options, "BUFFERS")
+	}
+	if version >= 120000 {
+		options = append(options, "SETTINGS")
+	}
+	options = append(options, "FORMAT JSON")
+
+	var raw []byte
+	query := "EXPLAIN (" + strings.Join(options, ", ") + ") " + stmt
+	explain := func() error {
+		return s.conn.QueryRowContext(ctx, query, args...).Scan(&raw)
+	}
+	rollback := opts.Analyze && opts.Rollback
+	if rollback {
+		err = s.RollbackAfter(ctx, explain)
+	} else {
+		err = explain()
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan, err := ParsePlan(raw)
+	if err != nil {
+		return nil, err
+	}
+	plan.Analyzed, plan.RolledBack = opts.Analyze, rollback
+	return plan, nil
+}
+
+// ParsePlan parses the output of EXPLAIN (FORMAT JSON) and computes the
+// derived timings and estimate ratios of each node.
+func ParsePlan(raw []byte) (*Plan, error) {
+	var out []struct {
+		Plan          json.RawMessage   `json:"Plan"`
+		PlanningTime  *float64          `json:"Planning Time"`
+		ExecutionTime *float64          `json:"Execution Time"`
+		Settings      map[string]string `json:"Settings"`
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("parse plan: %w", err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty plan")
+	}
+	plan := &Plan{
+		PlanningMs: out[0].PlanningTime, ExecutionMs: out[0].ExecutionTime,
+		Settings: out[0].Settings, Slowest: []int{}, Raw: raw,
+	}
+	if plan.Settings == nil {
+		plan.Settings = map[string]string{}
+	}
+	id := 0
+	root, err := parsePlanNode(out[0].Plan, &id)
+	if err != nil {
+		return nil, err
+	}
+	plan.Root = root
+
+	var nodes []*PlanNode
+	walkPlan(root, func(n *PlanNode) {
+		if n.ExclusiveMs != nil {
+			nodes = append(nodes, n)
+		}
+	})
+	sort.SliceStable(nodes, func(i, j int) bool { return *nodes[i].ExclusiveMs > *nodes[j].ExclusiveMs })
+	for i := 0; i < len(nodes) && i < slowestNodes; i++ {
+		plan.Slowest = append(plan.Slowest, nodes[i].ID)
+	}
+	return plan, nil
+}
+
+// planNodeJSON holds the node properties PlanNode models directly.
+type planNodeJSON struct {
+	NodeType            string            `json:"Node Type"`
+	ParentRelationship  string            `json:"Parent Relationship"`
+	RelationName        string            `json:"Relation Name"`
+	Schema              string            `json:"Schema"`
+	Alias               string            `json:"Alias"`
+	IndexName           string            `json:"Index Name"`
+	JoinType            string            `json:"Join Type"`
+	Strategy            string            `json:"Strategy"`
+	Output              []string          `json:"Output"`
+	StartupCost         float64           `json:"Startup Cost"`
+	TotalCost           float64           `json:"Total Cost"`
+	PlanRows            float64           `json:"Plan Rows"`
+	PlanWidth           int               `json:"Plan Width"`
+	ActualStartupTime   *float64          `json:"Actual Startup Time"`
+	ActualTotalTime     *float64          `json:"Actual Total Time"`
+	ActualRows          *float64          `json:"Actual Rows"`
+	ActualLoops         *float64          `json:"Actual Loops"`
+	RowsRemovedByFilter *float64          `json:"Rows Removed by Filter"`
+	IOReadTime          *float64          `json:"I/O Read Time"`
+	IOWriteTime         *float64          `json:"I/O Write Time"`
+	SharedIOReadTime    *float64          `json:"Shared I/O Read Time"`
+	SharedIOWriteTime   *float64          `json:"Shared I/O Write Time"`
+	SharedHitBlocks     *int64            `json:"Shared Hit Blocks"`
+	SharedReadBlocks    int64             `json:"Shared Read Blocks"`
+	SharedDirtiedBlocks int64             `json:"Shared Dirtied Blocks"`
+	SharedWrittenBlocks int64             `json:"Shared Written Blocks"`
+	LocalHitBlocks      int64             `json:"Local Hit Blocks"`
+	LocalReadBlocks     int64             `json:"Local Read Blocks"`
+	LocalDirtiedBlocks  int64             `json:"Local Dirtied Blocks"`
+	LocalWrittenBlocks  int64             `json:"Local Written Blocks"`
+	TempReadBlocks      int64             `json:"Temp Read Blocks"`
+	TempWrittenBlocks   int64             `json:"Temp Written Blocks"`
+	Plans               []json.RawMessage `json:"Plans"`
+}
+
+// planNodeKeys are the properties decoded into planNodeJSON, which are left
+// out of PlanNode.Extra.
+var planNodeKeys = map[string]bool{
+	"Node Type": true, "Parent Relationship": true, "Relation Name": true,
+	"Schema": true, "Alias": true, "Index Name": true, "Join Type": true,
+	"Strategy": true, "Output": true, "Startup Cost": true, "Total Cost": true,
+	"Plan Rows": true, "Plan Width": true, "Actual Startup Time": true,
+	"Actual Total Time": true, "Actual Rows": true, "Actual Loops": true,
+	"Rows Removed by Filter": true, "I/O Read Time": true, "I/O Write Time": true,
+	"Shared I/O Read Time": true, "Shared I/O Write Time": true,
+	"Shared Hit Blocks": true, "Shared Read Blocks": true,
+	"Shared Dirtied Blocks": true, "Shared Written Blocks": true,
+	"Local Hit Blocks": true, "Local Read Blocks": true,
+	"Local Dirtied Blocks": true, "Local Written Blocks": true,
+	"Temp Read Blocks": true, "Temp Written Blocks": true, "Plans": true,
+}
+
+// planConditions are the string properties collected into
+// PlanNode.Conditions.
+var planConditions = []string{
+	"Filter", "Index Cond", "Recheck Cond", "Join Filter", "Hash Cond",
+	"Merge Cond", "TID Cond", "One-Time Filter",
+}
+
+func parsePlanNode(raw json.RawMessage, id *int) (*PlanNode, error) {
+	var pj planNodeJSON
+	if err := json.Unmarshal(raw, &pj); err != nil {
+		return nil, fmt.Errorf("parse plan node: %w", err)
+	}
+	var props map[string]any
+	if err := json.Unmarshal(raw, &props); err != nil {
+		return nil, fmt.Errorf("parse plan node: %w", err)
+	}
+
+	n := &PlanNode{
+		ID: *id, NodeType: pj.NodeType, ParentRelationship: pj.ParentRelationship,
+		Relation: pj.RelationName, Schema: pj.Schema, Alias: pj.Alias,
+		Index: pj.IndexName, JoinType: pj.JoinType, Strategy: pj.Strategy,
+		Output: pj.Output, Conditions: map[string]string{},
+		StartupCost: pj.StartupCost, TotalCost: pj.TotalCost,
+		PlanRows: pj.PlanRows, PlanWidth: pj.PlanWidth,
+		ActualStartupMs: pj.ActualStartupTime, ActualTotalMs: pj.ActualTotalTime,
+		ActualRows: pj.ActualRows, ActualLoops: pj.ActualLoops,
+		RowsRemovedByFilter: pj.RowsRemovedByFilter,
+		IOReadMs:            firstFloat(pj.IOReadTime, pj.SharedIOReadTime),
+		IOWriteMs:           firstFloat(pj.IOWriteTime, pj.SharedIOWriteTime),
+		Extra:               map[string]any{},
+		Children:            []*PlanNode{},
+	}
+	*id++
+	if pj.SharedHitBlocks != nil {
+		n.Buffers = &PlanBuffers{
+			SharedHit: *pj.SharedHitBlocks, SharedRead: pj.SharedReadBlocks,
+			SharedDirtied: pj.SharedDirtiedBlocks, SharedWritten: pj.SharedWrittenBlocks,
+			LocalHit: pj.LocalHitBlocks, LocalRead: pj.LocalReadBlocks,
+			LocalDirtied: pj.LocalDirtiedBlocks, LocalWritten: pj.LocalWrittenBlocks,
+			TempRead: pj.TempReadBlocks, TempWritten: pj.TempWrittenBlocks,
+		}
+	}
+	for _, key := range planConditions {
+		if v, ok := props[key].(string); ok {
+			n.Conditions[key] = v
+			delete(props, key)
+		}
+	}
+	for k, v := range props {
+		if !planNodeKeys[k] {
+			n.Extra[k] = v
+		}
+	}
+
+	for _, child := range pj.Plans {
+		c, err := parsePlanNode(child, id)
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, c)
+	}
+	n.derive()
+	return n, nil
+}
+
+// derive computes the node's inclusive and exclusive time and its row
+// estimate ratio. Children must already be derived.
+func (n *PlanNode) derive() {
+	if n.ActualTotalMs == nil || n.ActualLoops == nil {
+		return
+	}
+	loops := *n.ActualLoops
+	inclusive := *n.ActualTotalMs * loops
+	exclusive := inclusive
+	for _, c := range n.Children {
+		if c.InclusiveMs != nil {
+			exclusive -= *c.InclusiveMs
+		}
+	}
+	// Parallel workers and InitPlans can make children add up to more than
+	// their parent.
+	exclusive = max(exclusive, 0)
+	n.InclusiveMs, n.ExclusiveMs = &inclusive, &exclusive
+
+	if n.ActualRows == nil || loops == 0 {
+		return
+	}
+	// The planner never estimates fewer than one row.
+	ratio := *n.ActualRows / max(n.PlanRows, 1)
+	n.RowsRatio = &ratio
+	n.Misestimated = ratio >= MisestimateFactor || (ratio > 0 && ratio <= 1.0/MisestimateFactor) ||
+		(ratio == 0 && n.PlanRows >= MisestimateFactor)
+}
+
+// walkPlan calls fn for n and each of its descendants, in pre-order.
+func walkPlan(n *PlanNode, fn func(*PlanNode)) {
+	fn(n)
+	for _, c := range n.Children {
+		walkPlan(c, fn)
+	}
+}
+
+func firstFloat(vals ...*float64) *float64 {
+	for _, v := range vals {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package client
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// loadPlan parses an EXPLAIN (FORMAT JSON) fixture from testdata.
+func loadPlan(t *testing.T, name string) *Plan {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := ParsePlan(raw)
+	if err != nil {
+		t.Fatalf("ParsePlan(%s): %v", name, err)
+	}
+	return plan
+}
+
+// planNodes returns a plan's nodes indexed by ID.
+func planNodes(p *Plan) []*PlanNode {
+	var nodes []*PlanNode
+	walkPlan(p.Root, func(n *PlanNode) { nodes = append(nodes, n) })
+	return nodes
+}
+
+func approx(p *float64, want float64) bool {
+	return p != nil && math.Abs(*p-want) < 1e-9
+}
+
+func TestParsePlanAnalyzed(t *testing.T) {
+	plan := loadPlan(t, "plan_hash_join.json")
+
+	if !approx(plan.PlanningMs, 0.284) || !approx(plan.ExecutionMs, 45.731) {
+		t.Errorf("planning, execution = %v, %v", plan.PlanningMs, plan.ExecutionMs)
+	}
+	if want := map[string]string{"work_mem": "16MB", "random_page_cost": "1.1"}; !reflect.DeepEqual(plan.Settings, want) {
+		t.Errorf("Settings = %v, want %v", plan.Settings, want)
+	}
+	if len(plan.Raw) == 0 {
+		t.Error("Raw is empty")
+	}
+
+	nodes := planNodes(plan)
+	wantNodes := []struct {
+		nodeType, parent, relation, alias string
+		inclusive, exclusive              float64
+	}{
+		{"Hash Join", "", "", "", 45.2, 4.6},
+		{"Seq Scan", "Outer", "orders", "o", 38.5, 38.5},
+		{"Hash", "Inner", "", "", 2.1, 0.9},
+		{"Seq Scan", "Outer", "customers", "c", 1.2, 1.2},
+	}
+	if len(nodes) != len(wantNodes) {
+		t.Fatalf("%d nodes, want %d", len(nodes), len(wantNodes))
+	}
+	for i, want := range wantNodes {
+		n := nodes[i]
+		if n.ID != i || n.NodeType != want.nodeType || n.ParentRelationship != want.parent ||
+			n.Relation != want.relation || n.Alias != want.alias {
+			t.Errorf("node %d = %d %q %q %q %q, want %+v", i, n.ID, n.NodeType, n.ParentRelationship, n.Relation, n.Alias, want)
+		}
+		if !approx(n.InclusiveMs, want.inclusive) || !approx(n.ExclusiveMs, want.exclusive) {
+			t.Errorf("node %d inclusive, exclusive = %v, %v, want %v, %v", i, *n.InclusiveMs, *n.ExclusiveMs, want.inclusive, want.exclusive)
+		}
+	}
+	if want := []int{1, 0, 3}; !reflect.DeepEqual(plan.Slowest, want) {
+		t.Errorf("Slowest = %v, want %v", plan.Slowest, want)
+	}
+
+	root := nodes[0]
+	if root.JoinType != "Inner" || root.StartupCost != 15.25 || root.TotalCost != 1098.75 || root.PlanRows != 980 || root.PlanWidth != 44 {
+		t.Errorf("root = %+v", root)
+	}
+	if want := map[string]string{"Hash Cond": "(o.customer_id = c.id)"}; !reflect.DeepEqual(root.Conditions, want) {
+		t.Errorf("root Conditions = %v, want %v", root.Conditions, want)
+	}
+	if want := []string{"o.id", "o.total", "c.name"}; !reflect.DeepEqual(root.Output, want) {
+		t.Errorf("root Output = %v, want %v", root.Output, want)
+	}
+	if want := (&PlanBuffers{SharedHit: 412, SharedRead: 35}); !reflect.DeepEqual(root.Buffers, want) {
+		t.Errorf("root Buffers = %+v, want %+v", root.Buffers, want)
+	}
+	if !approx(root.IOReadMs, 0.612) || !approx(root.IOWriteMs, 0) {
+		t.Errorf("root I/O = %v, %v", root.IOReadMs, root.IOWriteMs)
+	}
+	wantExtra := map[string]any{"Parallel Aware": false, "Async Capable": false, "Inner Unique": true}
+	if !reflect.DeepEqual(root.Extra, wantExtra) {
+		t.Errorf("root Extra = %v, want %v", root.Extra, wantExtra)
+	}
+
+	orders := nodes[1]
+	if orders.Schema != "public" || orders.Conditions["Filter"] != "(o.status = 'open'::text)" || !approx(orders.RowsRemovedByFilter, 49000) {
+		t.Errorf("orders scan = %+v", orders)
+	}
+	if _, ok := orders.Extra["Filter"]; ok {
+		t.Error("Filter is repeated in Extra")
+	}
+	if hash := nodes[2]; hash.Extra["Peak Memory Usage"] != float64(38) || hash.Extra["Hash Batches"] != float64(1) {
+		t.Errorf("hash Extra = %v", hash.Extra)
+	}
+	if !approx(root.RowsRatio, 1000.0/980) {
+		t.Errorf("root ratio = %v", *root.RowsRatio)
+	}
+	for _, n := range nodes {
+		if n.Misestimated {
+			t.Errorf("node %d misestimated with ratio %v", n.ID, *n.RowsRatio)
+		}
+	}
+}
+
+func TestParsePlanLoops(t *testing.T) {
+	plan := loadPlan(t, "plan_nested_loop.json")
+	nodes := planNodes(plan)
+
+	// The inner index scan runs once per outer row; its time is per loop.
+	inner := nodes[2]
+	if inner.Index != "customers_pkey" || inner.Conditions["Index Cond"] != "(c.id = o.customer_id)" {
+		t.Errorf("inner scan = %+v", inner)
+	}
+	if !approx(inner.InclusiveMs, 2) || !approx(inner.ExclusiveMs, 2) {
+		t.Errorf("inner inclusive, exclusive = %v, %v, want 2, 2", *inner.InclusiveMs, *inner.ExclusiveMs)
+	}
+	if !approx(inner.RowsRatio, 1) || inner.Misestimated {
+		t.Errorf("inner ratio %v misestimated %v: rows are per loop", *inner.RowsRatio, inner.Misestimated)
+	}
+	if root := nodes[0]; !approx(root.ExclusiveMs, 0.6) || !approx(root.RowsRatio, 100) || !root.Misestimated {
+		t.Errorf("root exclusive %v ratio %v misestimated %v", *root.ExclusiveMs, *root.RowsRatio, root.Misestimated)
+	}
+	if want := []int{2, 1, 0}; !reflect.DeepEqual(plan.Slowest, want) {
+		t.Errorf("Slowest = %v, want %v", plan.Slowest, want)
+	}
+}
+
+func TestParsePlanEstimateOnly(t *testing.T) {
+	plan := loadPlan(t, "plan_estimate.json")
+
+	if plan.ExecutionMs != nil || !approx(plan.PlanningMs, 0.102) {
+		t.Errorf("planning, execution = %v, %v", plan.PlanningMs, plan.ExecutionMs)
+	}
+	if plan.Slowest == nil || len(plan.Slowest) != 0 {
+		t.Errorf("Slowest = %#v, want empty", plan.Slowest)
+	}
+	if plan.Settings == nil || len(plan.Settings) != 0 {
+		t.Errorf("Settings = %#v, want empty", plan.Settings)
+	}
+	nodes := planNodes(plan)
+	if len(nodes) != 3 || nodes[0].NodeType != "Limit" || nodes[1].NodeType != "Sort" || nodes[2].Relation != "orders" {
+		t.Fatalf("nodes = %v", nodes)
+	}
+	for _, n := range nodes {
+		if n.ActualTotalMs != nil || n.InclusiveMs != nil || n.ExclusiveMs != nil || n.RowsRatio != nil || n.Buffers != nil || n.Misestimated {
+			t.Errorf("node %d has analyze fields: %+v", n.ID, n)
+		}
+	}
+	if want := []any{"orders.total DESC"}; !reflect.DeepEqual(nodes[1].Extra["Sort Key"], want) {
+		t.Errorf("Sort Key = %v, want %v", nodes[1].Extra["Sort Key"], want)
+	}
+	if len(nodes[2].Children) != 0 || nodes[2].Children == nil {
+		t.Errorf("leaf Children = %#v, want empty", nodes[2].Children)
+	}
+}
+
+func TestParsePlanSharedIOTime(t *testing.T) {
+	// PostgreSQL 17 splits I/O timing into shared and local.
+	raw := `[{"Plan": {"Node Type": "Seq Scan", "Actual Total Time": 1, "Actual Loops": 1,
+		"Shared Hit Blocks": 1, "Shared I/O Read Time": 0.5, "Shared I/O Write Time": 0.25,
+		"Local I/O Read Time": 0, "Local I/O Write Time": 0}}]`
+	plan, err := ParsePlan([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !approx(plan.Root.IOReadMs, 0.5) || !approx(plan.Root.IOWriteMs, 0.25) {
+		t.Errorf("I/O = %v, %v", plan.Root.IOReadMs, plan.Root.IOWriteMs)
+	}
+}
+
+func TestParsePlanErrors(t *testing.T) {
+	for _, raw := range []string{"", "{", "[]", `{"Plan": {}}`, `[{"Plan": {"Plans": [1]}}]`} {
+		if _, err := ParsePlan([]byte(raw)); err == nil {
+			t.Errorf("ParsePlan(%q) succeeded", raw)
+		}
+	}
+}
+
+func TestMisestimated(t *testing.T) {
+	tests := []struct {
+		planRows, actualRows float64
+		want                 bool
+	}{
+		{100, 100, false},
+		{100, 999, false},
+		{100, 1000, true},
+		{100, 11, false},
+		{100, 10, true},
+		{100, 0, true},
+		{5, 0, false},      // a handful of expected rows finding none is normal
+		{0.4, 5, false},    // estimates are at least one row
+		{1, 10, true},      // ...so the ratio is still computed against one
+		{1000, 99.5, true}, // per-loop averages can be fractional
+	}
+	for _, tt := range tests {
+		one, total := 1.0, 1.0
+		n := &PlanNode{PlanRows: tt.planRows, ActualRows: &tt.actualRows, ActualLoops: &one, ActualTotalMs: &total}
+		n.derive()
+		if n.Misestimated != tt.want {
+			t.Errorf("plan %v actual %v: misestimated = %v, want %v", tt.planRows, tt.actualRows, n.Misestimated, tt.want)
+		}
+	}
+}
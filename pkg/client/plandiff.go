@@ -0,0 +1,233 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NodeChange is how a plan node differs between two plans.
+type NodeChange string
+
+const (
+	NodeUnchanged NodeChange = "unchanged"
+	NodeChanged   NodeChange = "changed"
+	NodeAdded     NodeChange = "added"   // only in the after plan
+	NodeRemoved   NodeChange = "removed" // only in the before plan
+)
+
+// PlanDiff is a node-level comparison of two plans.
+type PlanDiff struct {
+	Before, After *Plan
+	Nodes         []NodeDiff // in before-plan order, then added nodes
+	// TotalCostDelta is the change in the root node's estimated cost, and
+	// ExecutionMsDelta the change in execution time when both were analyzed.
+	TotalCostDelta   float64
+	ExecutionMsDelta *float64
+}
+
+// NodeDiff pairs a node of the before plan with its counterpart in the after
+// plan. Either ID is nil when the node has no counterpart.
+type NodeDiff struct {
+	BeforeID *int
+	AfterID  *int
+	Change   NodeChange
+	// Details describe each difference, e.g. "Seq Scan → Index Scan on orders"
+	// or "join strategy Hash Join → Nested Loop".
+	Details []string
+	// ScanChanged and JoinChanged flag a different scan method on the same
+	// relation and a different join strategy.
+	ScanChanged bool
+	JoinChanged bool
+	// CostDelta is the change in the node's estimated total cost, and
+	// TimeDelta the change in its inclusive time when both were analyzed.
+	CostDelta *float64
+	TimeDelta *float64
+}
+
+// passThroughNodes wrap a single child without changing what it reads, so a
+// plan that adds or drops one (e.g. a Hash under a Hash Join) is compared by
+// its child.
+var passThroughNodes = map[string]bool{
+	"Hash": true, "Materialize": true, "Memoize": true, "Sort": true,
+	"Incremental Sort": true, "Gather": true, "Gather Merge": true,
+	"Limit": true, "Result": true, "Unique": true,
+}
+
+var joinNodes = map[string]bool{
+	"Nested Loop": true, "Hash Join": true, "Merge Join": true,
+}
+
+// ComparePlans matches the nodes of two plans and reports what changed.
+// Nodes are paired top-down: a child is matched to the counterpart that
+// reads the most of the same relations, and pass-through nodes present in
+// only one plan are skipped over.
+func ComparePlans(before, after *Plan) *PlanDiff {
+	d := &PlanDiff{
+		Before: before, After: after,
+		TotalCostDelta: after.Root.TotalCost - before.Root.TotalCost,
+	}
+	if before.ExecutionMs != nil && after.ExecutionMs != nil {
+		delta := *after.ExecutionMs - *before.ExecutionMs
+		d.ExecutionMsDelta = &delta
+	}
+	d.matchNodes(before.Root, after.Root)
+	sort.SliceStable(d.Nodes, func(i, j int) bool {
+		return nodeOrder(d.Nodes[i]) < nodeOrder(d.Nodes[j])
+	})
+	return d
+}
+
+// nodeOrder sorts diffs by before-plan node, with added nodes last in
+// after-plan order.
+func nodeOrder(n NodeDiff) int {
+	if n.BeforeID != nil {
+		return *n.BeforeID
+	}
+	return 1<<30 + *n.AfterID
+}
+
+func (d *PlanDiff) matchNodes(b, a *PlanNode) {
+	if b.NodeType != a.NodeType && !sameOperation(b, a) {
+		switch {
+		case passThroughNodes[b.NodeType] && len(b.Children) == 1:
+			d.Nodes = append(d.Nodes, NodeDiff{BeforeID: &b.ID, Change: NodeRemoved})
+			d.matchNodes(b.Children[0], a)
+			return
+		case passThroughNodes[a.NodeType] && len(a.Children) == 1:
+			d.Nodes = append(d.Nodes, NodeDiff{AfterID: &a.ID, Change: NodeAdded})
+			d.matchNodes(b, a.Children[0])
+			return
+		}
+	}
+	d.Nodes = append(d.Nodes, diffNode(b, a))
+	d.matchChildren(b.Children, a.Children)
+}
+
+// sameOperation reports whether two nodes of different types do the same
+// job: a scan of the same relation, or a join.
+func sameOperation(b, a *PlanNode) bool {
+	if b.Relation != "" && b.Relation == a.Relation && b.Schema == a.Schema {
+		return true
+	}
+	return joinNodes[b.NodeType] && joinNodes[a.NodeType]
+}
+
+// matchChildren pairs two child lists greedily by similarity. Unpaired
+// subtrees are reported as removed or added.
+func (d *PlanDiff) matchChildren(before, after []*PlanNode) {
+	type pair struct {
+		bi, ai int
+		score  float64
+	}
+	var pairs []pair
+	for bi, b := range before {
+		for ai, a := range after {
+			score := relationOverlap(b, a) * 2
+			if b.NodeType == a.NodeType {
+				score++
+			}
+			if bi == ai {
+				score += 0.5
+			}
+			pairs = append(pairs, pair{bi, ai, score})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].score > pairs[j].score })
+
+	matchedB := make([]bool, len(before))
+	matchedA := make([]bool, len(after))
+	for _, p := range pairs {
+		if matchedB[p.bi] || matchedA[p.ai] || p.score < 1 {
+			continue
+		}
+		matchedB[p.bi], matchedA[p.ai] = true, true
+		d.matchNodes(before[p.bi], after[p.ai])
+	}
+	for i, b := range before {
+		if !matchedB[i] {
+			walkPlan(b, func(n *PlanNode) {
+				d.Nodes = append(d.Nodes, NodeDiff{BeforeID: &n.ID, Change: NodeRemoved})
+			})
+		}
+	}
+	for i, a := range after {
+		if !matchedA[i] {
+			walkPlan(a, func(n *PlanNode) {
+				d.Nodes = append(d.Nodes, NodeDiff{AfterID: &n.ID, Change: NodeAdded})
+			})
+		}
+	}
+}
+
+// relationOverlap is the Jaccard similarity of the relations two subtrees
+// read: 1 if they read the same ones, 0 if none in common.
+func relationOverlap(b, a *PlanNode) float64 {
+	rb, ra := subtreeRelations(b), subtreeRelations(a)
+	if len(rb) == 0 && len(ra) == 0 {
+		return 0
+	}
+	common := 0
+	for r := range rb {
+		if ra[r] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(rb)+len(ra)-common)
+}
+
+func subtreeRelations(n *PlanNode) map[string]bool {
+	rels := map[string]bool{}
+	walkPlan(n, func(n *PlanNode) {
+		if n.Relation != "" {
+			rels[n.Schema+"."+n.Relation] = true
+		}
+	})
+	return rels
+}
+
+// diffNode compares two matched nodes.
+func diffNode(b, a *PlanNode) NodeDiff {
+	nd := NodeDiff{BeforeID: &b.ID, AfterID: &a.ID, Change: NodeUnchanged}
+	cost := a.TotalCost - b.TotalCost
+	nd.CostDelta = &cost
+	if b.InclusiveMs != nil && a.InclusiveMs != nil {
+		t := *a.InclusiveMs - *b.InclusiveMs
+		nd.TimeDelta = &t
+	}
+
+	if b.NodeType != a.NodeType {
+		switch {
+		case b.Relation != "" && b.Relation == a.Relation:
+			nd.ScanChanged = true
+			nd.Details = append(nd.Details, fmt.Sprintf("%s → %s on %s", b.NodeType, a.NodeType, a.Relation))
+		case joinNodes[b.NodeType] && joinNodes[a.NodeType]:
+			nd.JoinChanged = true
+			nd.Details = append(nd.Details, fmt.Sprintf("join strategy %s → %s", b.NodeType, a.NodeType))
+		default:
+			nd.Details = append(nd.Details, fmt.Sprintf("%s → %s", b.NodeType, a.NodeType))
+		}
+	}
+	if b.Index != a.Index {
+		nd.ScanChanged = nd.ScanChanged || b.Relation == a.Relation
+		nd.Details = append(nd.Details, fmt.Sprintf("index %s → %s", orNone(b.Index), orNone(a.Index)))
+	}
+	if b.JoinType != a.JoinType {
+		nd.Details = append(nd.Details, fmt.Sprintf("join type %s → %s", orNone(b.JoinType), orNone(a.JoinType)))
+	}
+	if b.Strategy != a.Strategy {
+		nd.Details = append(nd.Details, fmt.Sprintf("strategy %s → %s", orNone(b.Strategy), orNone(a.Strategy)))
+	}
+	if len(nd.Details) > 0 {
+		nd.Change = NodeChanged
+	} else {
+		nd.Details = []string{}
+	}
+	return nd
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
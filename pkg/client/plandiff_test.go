@@ -0,0 +1,147 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComparePlansIndexAdded(t *testing.T) {
+	// The same join before and after an index on orders.status: the hash join
+	// over two sequential scans becomes a nested loop over index scans.
+	before := loadPlan(t, "plan_hash_join.json")
+	after := loadPlan(t, "plan_nested_loop.json")
+	d := ComparePlans(before, after)
+
+	if !approx(&d.TotalCostDelta, 95.40-1098.75) || !approx(d.ExecutionMsDelta, 3.712-45.731) {
+		t.Errorf("cost delta %v, execution delta %v", d.TotalCostDelta, d.ExecutionMsDelta)
+	}
+
+	type node struct {
+		before, after any // node IDs, nil when absent
+		change        NodeChange
+		details       []string
+		scan, join    bool
+	}
+	want := []node{
+		{0, 0, NodeChanged, []string{"join strategy Hash Join → Nested Loop"}, false, true},
+		{1, 1, NodeChanged, []string{"Seq Scan → Index Scan on orders", "index none → orders_status_idx"}, true, false},
+		{2, nil, NodeRemoved, nil, false, false},
+		{3, 2, NodeChanged, []string{"Seq Scan → Index Scan on customers", "index none → customers_pkey"}, true, false},
+	}
+	got := make([]node, len(d.Nodes))
+	for i, n := range d.Nodes {
+		got[i] = node{intOrNil(n.BeforeID), intOrNil(n.AfterID), n.Change, n.Details, n.ScanChanged, n.JoinChanged}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("nodes\n got %+v\nwant %+v", got, want)
+	}
+
+	root := d.Nodes[0]
+	if !approx(root.CostDelta, 95.40-1098.75) || !approx(root.TimeDelta, 3.5-45.2) {
+		t.Errorf("root cost delta %v, time delta %v", *root.CostDelta, *root.TimeDelta)
+	}
+	if customers := d.Nodes[3]; !approx(customers.TimeDelta, 2-1.2) {
+		t.Errorf("customers time delta = %v, want the inner scan's time across all loops", *customers.TimeDelta)
+	}
+	if d.Nodes[2].CostDelta != nil || d.Nodes[2].TimeDelta != nil {
+		t.Error("removed node has deltas")
+	}
+}
+
+func TestComparePlansUnchanged(t *testing.T) {
+	plan := loadPlan(t, "plan_hash_join.json")
+	d := ComparePlans(plan, plan)
+
+	if d.TotalCostDelta != 0 || !approx(d.ExecutionMsDelta, 0) || len(d.Nodes) != 4 {
+		t.Fatalf("diff = %+v", d)
+	}
+	for i, n := range d.Nodes {
+		if *n.BeforeID != i || *n.AfterID != i || n.Change != NodeUnchanged || n.Details == nil || len(n.Details) != 0 {
+			t.Errorf("node %d = %+v", i, n)
+		}
+	}
+}
+
+func TestComparePlansEstimateOnly(t *testing.T) {
+	before := loadPlan(t, "plan_estimate.json")
+	after := loadPlan(t, "plan_hash_join.json")
+	d := ComparePlans(before, after)
+
+	if d.ExecutionMsDelta != nil {
+		t.Errorf("ExecutionMsDelta = %v, want nil when only one plan was analyzed", *d.ExecutionMsDelta)
+	}
+	for _, n := range d.Nodes {
+		if n.TimeDelta != nil {
+			t.Errorf("node %+v has a time delta", n)
+		}
+	}
+}
+
+func TestComparePlansPassThrough(t *testing.T) {
+	scan := `{"Node Type": "Seq Scan", "Relation Name": "orders", "Schema": "public", "Total Cost": 10}`
+	sorted := `{"Node Type": "Sort", "Total Cost": 20, "Plans": [` + scan + `]}`
+	appendPlan := func(children ...string) string {
+		s := `{"Node Type": "Append", "Total Cost": 30, "Plans": [`
+		for i, c := range children {
+			if i > 0 {
+				s += ", "
+			}
+			s += c
+		}
+		return s + "]}"
+	}
+	other := `{"Node Type": "Seq Scan", "Relation Name": "archive", "Schema": "public", "Total Cost": 5}`
+
+	tests := []struct {
+		name          string
+		before, after string
+		want          [][3]any // before ID, after ID, change
+	}{
+		{
+			"sort added above a scan",
+			scan, sorted,
+			[][3]any{{0, 1, NodeUnchanged}, {nil, 0, NodeAdded}},
+		},
+		{
+			"sort removed above a scan",
+			sorted, scan,
+			[][3]any{{0, nil, NodeRemoved}, {1, 0, NodeUnchanged}},
+		},
+		{
+			"child subtree added",
+			appendPlan(scan), appendPlan(scan, other),
+			[][3]any{{0, 0, NodeUnchanged}, {1, 1, NodeUnchanged}, {nil, 2, NodeAdded}},
+		},
+		{
+			"children matched by relation, not position",
+			appendPlan(other, sorted), appendPlan(scan, other),
+			[][3]any{{0, 0, NodeUnchanged}, {1, 2, NodeUnchanged}, {2, nil, NodeRemoved}, {3, 1, NodeUnchanged}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, err := ParsePlan([]byte(`[{"Plan": ` + tt.before + `}]`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := ParsePlan([]byte(`[{"Plan": ` + tt.after + `}]`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got [][3]any
+			for _, n := range ComparePlans(before, after).Nodes {
+				got = append(got, [3]any{intOrNil(n.BeforeID), intOrNil(n.AfterID), n.Change})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nodes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func intOrNil(p *int) any {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
@@ -0,0 +1,292 @@
+package client
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lib/pq"
+)
+
+// Relationship is a foreign key between two tables.
+type Relationship struct {
+	Name          string
+	SourceSchema  string
+	SourceTable   string
+	SourceColumns []string
+	TargetSchema  string
+	TargetTable   string
+	TargetColumns []string
+	OnDelete      string // "NO ACTION", "RESTRICT", "CASCADE", "SET NULL" or "SET DEFAULT"
+	OnUpdate      string
+	// Indexed reports whether the key columns of an index on the source
+	// table start with the source columns, so that deletes and updates on
+	// the target can find the referencing rows without scanning the source.
+	Indexed bool
+	// Unique reports whether the source columns are unique, making the
+	// relationship one-to-one.
+	Unique bool
+}
+
+// GraphTable is a table in a relationship graph.
+type GraphTable struct {
+	Schema  string
+	Name    string
+	Columns []GraphColumn
+}
+
+type GraphColumn struct {
+	Name       string
+	Type       string
+	Nullable   bool
+	PrimaryKey bool
+	ForeignKey bool
+}
+
+// RelationshipGraph is a set of tables and the foreign keys between them.
+type RelationshipGraph struct {
+	Tables        []GraphTable
+	Relationships []Relationship
+}
+
+// Relationships returns every foreign key in the database. Foreign keys
+// that partitions inherit from their parent are left out.
+func (c *Client) Relationships(ctx context.Context) ([]Relationship, error) {
+	version, err := c.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inherited, keyColumns := "", "ix.indnatts"
+	if version >= 110000 {
+		inherited, keyColumns = "AND con.conparentid = 0", "ix.indnkeyatts"
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT con.conname, sn.nspname, s.relname,
+			ARRAY(SELECT a.attname
+				FROM unnest(con.conkey) WITH ORDINALITY k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+				ORDER BY k.ord),
+			tn.nspname, t.relname,
+			ARRAY(SELECT a.attname
+				FROM unnest(con.confkey) WITH ORDINALITY k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+				ORDER BY k.ord),
+			`+fkAction("con.confdeltype")+`, `+fkAction("con.confupdtype")+`,
+			EXISTS (SELECT 1 FROM pg_index ix
+				WHERE ix.indrelid = con.conrelid AND ix.indpred IS NULL
+					AND (string_to_array(ix.indkey::text, ' ')::int2[])[1:least(cardinality(con.conkey), `+keyColumns+`)] @> con.conkey),
+			EXISTS (SELECT 1 FROM pg_index ix
+				WHERE ix.indrelid = con.conrelid AND ix.indisunique AND ix.indpred IS NULL
+					AND (string_to_array(ix.indkey::text, ' ')::int2[])[1:`+keyColumns+`] <@ con.conkey)
+		FROM pg_constraint con
+		JOIN pg_class s ON s.oid = con.conrelid
+		JOIN pg_namespace sn ON sn.oid = s.relnamespace
+		JOIN pg_class t ON t.oid = con.confrelid
+		JOIN pg_namespace tn ON tn.oid = t.relnamespace
+		WHERE con.contype = 'f' `+inherited+`
+		ORDER BY sn.nspname, s.relname, con.conname`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rels := []Relationship{}
+	for rows.Next() {
+		var r Relationship
+		if err := rows.Scan(&r.Name, &r.SourceSchema, &r.SourceTable, pq.Array(&r.SourceColumns),
+			&r.TargetSchema, &r.TargetTable, pq.Array(&r.TargetColumns),
+			&r.OnDelete, &r.OnUpdate, &r.Indexed, &r.Unique); err != nil {
+			return nil, err
+		}
+		rels = append(rels, r)
+	}
+	return rels, rows.Err()
+}
+
+func fkAction(column string) string {
+	return `CASE ` + column + ` WHEN 'r' THEN 'RESTRICT' WHEN 'c' THEN 'CASCADE'
+		WHEN 'n' THEN 'SET NULL' WHEN 'd' THEN 'SET DEFAULT' ELSE 'NO ACTION' END`
+}
+
+// SchemaGraph returns the tables of schema with the foreign keys between
+// them and to and from tables in other schemas, which are included too.
+func (c *Client) SchemaGraph(ctx context.Context, schema string) (*RelationshipGraph, error) {
+	rels, err := c.Relationships(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := c.schemaTables(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+	inSchema := make(map[tableKey]bool, len(tables))
+	for _, t := range tables {
+		inSchema[t] = true
+	}
+	var graph []Relationship
+	for _, r := range rels {
+		if inSchema[r.source()] || inSchema[r.target()] {
+			graph = append(graph, r)
+		}
+	}
+	return c.relationshipGraph(ctx, tables, graph)
+}
+
+// NeighbourhoodGraph returns the tables within hops foreign keys of a
+// table, in either direction, and the foreign keys between them.
+func (c *Client) NeighbourhoodGraph(ctx context.Context, schema, table string, hops int) (*RelationshipGraph, error) {
+	var exists bool
+	err := c.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p'))`, schema, table).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrObjectNotFound
+	}
+	rels, err := c.Relationships(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables, graph := neighbourhood(rels, tableKey{schema, table}, hops)
+	return c.relationshipGraph(ctx, tables, graph)
+}
+
+type tableKey struct{ schema, name string }
+
+func (r Relationship) source() tableKey { return tableKey{r.SourceSchema, r.SourceTable} }
+func (r Relationship) target() tableKey { return tableKey{r.TargetSchema, r.TargetTable} }
+
+// neighbourhood walks rels breadth first from start, returning the tables
+// reached within hops and the relationships among them.
+func neighbourhood(rels []Relationship, start tableKey, hops int) ([]tableKey, []Relationship) {
+	reached := map[tableKey]bool{start: true}
+	tables := []tableKey{start}
+	frontier := []tableKey{start}
+	for hop := 0; hop < hops && len(frontier) > 0; hop++ {
+		var next []tableKey
+		for _, t := range frontier {
+			for _, r := range rels {
+				var other tableKey
+				switch t {
+				case r.source():
+					other = r.target()
+				case r.target():
+					other = r.source()
+				default:
+					continue
+				}
+				if !reached[other] {
+					reached[other] = true
+					tables = append(tables, other)
+					next = append(next, other)
+				}
+			}
+		}
+		frontier = next
+	}
+	var graph []Relationship
+	for _, r := range rels {
+		if reached[r.source()] && reached[r.target()] {
+			graph = append(graph, r)
+		}
+	}
+	return tables, graph
+}
+
+// schemaTables lists the tables of schema, leaving out partitions.
+func (c *Client) schemaTables(ctx context.Context, schema string) ([]tableKey, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT c.relname FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+		ORDER BY c.relname`, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tables []tableKey
+	for rows.Next() {
+		t := tableKey{schema: schema}
+		if err := rows.Scan(&t.name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+	return tables, rows.Err()
+}
+
+// relationshipGraph builds a graph of the given tables and relationships,
+// adding the tables at the far end of a relationship and loading the
+// columns of each.
+func (c *Client) relationshipGraph(ctx context.Context, tables []tableKey, rels []Relationship) (*RelationshipGraph, error) {
+	seen := make(map[tableKey]bool)
+	var schemas, names []string
+	add := func(t tableKey) {
+		if !seen[t] {
+			seen[t] = true
+			schemas, names = append(schemas, t.schema), append(names, t.name)
+		}
+	}
+	for _, t := range tables {
+		add(t)
+	}
+	fkColumns := make(map[tableKey]map[string]bool)
+	for _, r := range rels {
+		add(r.source())
+		add(r.target())
+		if fkColumns[r.source()] == nil {
+			fkColumns[r.source()] = make(map[string]bool)
+		}
+		for _, col := range r.SourceColumns {
+			fkColumns[r.source()][col] = true
+		}
+	}
+
+	graph := &RelationshipGraph{Tables: []GraphTable{}, Relationships: rels}
+	if graph.Relationships == nil {
+		graph.Relationships = []Relationship{}
+	}
+	byKey := make(map[tableKey]*GraphTable, len(names))
+	for i := range names {
+		graph.Tables = append(graph.Tables, GraphTable{Schema: schemas[i], Name: names[i], Columns: []GraphColumn{}})
+	}
+	sort.Slice(graph.Tables, func(i, j int) bool {
+		a, b := graph.Tables[i], graph.Tables[j]
+		return a.Schema < b.Schema || a.Schema == b.Schema && a.Name < b.Name
+	})
+	for i := range graph.Tables {
+		byKey[tableKey{graph.Tables[i].Schema, graph.Tables[i].Name}] = &graph.Tables[i]
+	}
+	if len(names) == 0 {
+		return graph, nil
+	}
+
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT n.nspname, t.relname, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+			EXISTS (SELECT 1 FROM pg_index ix
+				WHERE ix.indrelid = t.oid AND ix.indisprimary AND a.attnum = ANY(ix.indkey))
+		FROM unnest($1::text[], $2::text[]) AS want(schema, name)
+		JOIN pg_namespace n ON n.nspname = want.schema
+		JOIN pg_class t ON t.relnamespace = n.oid AND t.relname = want.name
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, pq.Array(schemas), pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t tableKey
+		var col GraphColumn
+		if err := rows.Scan(&t.schema, &t.name, &col.Name, &col.Type, &col.Nullable, &col.PrimaryKey); err != nil {
+			return nil, err
+		}
+		col.ForeignKey = fkColumns[t][col.Name]
+		if g, ok := byKey[t]; ok {
+			g.Columns = append(g.Columns, col)
+		}
+	}
+	return graph, rows.Err()
+}
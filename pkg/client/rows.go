@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Rows is a forward-only cursor over a query's result set. It lets callers
+// process arbitrarily large results one row at a time instead of buffering
+// them in a QueryResult.
+type Rows struct {
+	Columns     []string
+	ColumnTypes []string
+
+	rows  *sql.Rows
+	start time.Time
+	tag   *CommandTag
+
+	// stop cancels the statement on the server when the rows are closed
+	// before the last one (see StopEarly).
+	stop      func() bool
+	exhausted bool
+	stopped   bool
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Conn, so pooled and pinned
+// connections share the same row handling.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// QueryRows executes query and returns a cursor over its rows.
+// The caller must Close the returned Rows.
+func (c *Client) QueryRows(ctx context.Context, query string) (*Rows, error) {
+	return queryRows(ctx, c.db, query)
+}
+
+func queryRows(ctx context.Context, q queryer, query string, args ...any) (*Rows, error) {
+	start := time.Now()
+	ctx, tag := withCommandTag(ctx)
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	r := &Rows{
+		Columns:     make([]string, len(colTypes)),
+		ColumnTypes: make([]string, len(colTypes)),
+		rows:        rows,
+		start:       start,
+		tag:         tag,
+	}
+	for i, ct := range colTypes {
+		r.Columns[i] = ct.Name()
+		r.ColumnTypes[i] = ct.DatabaseTypeName()
+	}
+	return r, nil
+}
+
+// Next advances to the next row, returning false when the result set is
+// exhausted or an error occurred (see Err).
+func (r *Rows) Next() bool {
+	if r.rows.Next() {
+		return true
+	}
+	r.exhausted = true
+	return false
+}
+
+// StopEarly makes Close, when called before the last row has been read,
+// cancel the statement on the server with cancel instead of reading the
+// remaining rows off the wire only to discard them. The command tag of a
+// stopped statement is unknown. Cancelling rolls back whatever the statement
+// did and aborts an open transaction block, so only use it for plain reads
+// outside one.
+func (r *Rows) StopEarly(cancel func() bool) {
+	r.stop = cancel
+}
+
+// Values scans the current row into a freshly allocated slice, decoding each
+// cell according to its column type (see decodeValue).
+func (r *Rows) Values() ([]any, error) {
+	vals := make([]any, len(r.Columns))
+	ptrs := make([]any, len(r.Columns))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err := r.rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+	for i, v := range vals {
+		vals[i] = decodeValue(r.ColumnTypes[i], v)
+	}
+	return vals, nil
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (r *Rows) Err() error {
+	return r.rows.Err()
+}
+
+// Close releases the underlying connection.
+func (r *Rows) Close() error {
+	if r.stop != nil && !r.exhausted && !r.stopped {
+		r.stopped = r.stop()
+		if r.stopped {
+			// The server reports the cancellation as an error, which is
+			// the expected outcome.
+			r.rows.Close()
+			return nil
+		}
+	}
+	return r.rows.Close()
+}
+
+// DurationMs returns the time elapsed since the query was sent.
+func (r *Rows) DurationMs() int64 {
+	return time.Since(r.start).Milliseconds()
+}
+
+// CommandTag returns the statement's command tag. It is only known once the
+// rows have been closed, explicitly or by reading past the last row, and
+// never for a statement stopped early.
+func (r *Rows) CommandTag() CommandTag {
+	if r.stopped {
+		return CommandTag{}
+	}
+	return *r.tag
+}
+
+// Collect buffers at most maxRows rows (all if maxRows is 0) and closes the
+// rows.
+func (r *Rows) Collect(maxRows int) (*QueryResult, error) {
+	return collectRows(r, maxRows)
+}
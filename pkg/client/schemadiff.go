@@ -0,0 +1,482 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// DiffObject is the kind of object a SchemaChange is about.
+type DiffObject string
+
+const (
+	ObjectTable            DiffObject = "table"
+	ObjectColumn           DiffObject = "column"
+	ObjectIndex            DiffObject = "index"
+	ObjectConstraint       DiffObject = "constraint"
+	ObjectView             DiffObject = "view"
+	ObjectMaterializedView DiffObject = "materialized_view"
+	ObjectFunction         DiffObject = "function"
+	ObjectEnum             DiffObject = "enum"
+)
+
+// SchemaChangeKind says whether an object was added, removed or changed.
+type SchemaChangeKind string
+
+const (
+	SchemaAdded   SchemaChangeKind = "added"   // only in the target
+	SchemaRemoved SchemaChangeKind = "removed" // only in the source
+	SchemaChanged SchemaChangeKind = "changed"
+)
+
+// SchemaChange is one difference between two catalogs.
+type SchemaChange struct {
+	Object DiffObject
+	Table  string // table of a column, index or constraint
+	Name   string // for a function, its signature
+	Change SchemaChangeKind
+	// Before and After are the object's definition in the source and the
+	// target, empty where it does not exist.
+	Before, After string
+	// Details describe what changed in a changed object, e.g.
+	// "type: integer -> bigint".
+	Details []string
+	// SQL are the statements that apply the change to the source.
+	SQL   []string
+	steps []migrationStep
+}
+
+type migrationStep struct {
+	phase int
+	sql   string
+}
+
+// The phases of a migration script. Statements run phase by phase so that
+// objects are dropped before what they depend on and created after it.
+// Functions are created before tables, whose defaults, checks, generated
+// columns and indexes may call them, and dropped after them.
+const (
+	phaseEnums = iota
+	phaseCreateFunctions
+	phaseDropViews
+	phaseDropForeignKeys
+	phaseDropConstraints // and indexes
+	phaseDropTables
+	phaseCreateTables
+	phaseColumns
+	phaseAddConstraints // and indexes
+	phaseAddForeignKeys
+	phaseCreateViews
+	phaseDropFunctions
+	phaseDropEnums
+)
+
+// skipFunctionBodyChecks precedes created functions in a migration script,
+// as pg_dump does, since a SQL function's body may refer to tables that are
+// created later in the script.
+const skipFunctionBodyChecks = "SET check_function_bodies = false;"
+
+func (c *SchemaChange) add(phase int, format string, args ...any) {
+	sql := fmt.Sprintf(format, args...)
+	c.SQL = append(c.SQL, sql)
+	c.steps = append(c.steps, migrationStep{phase, sql})
+}
+
+// SchemaDiff is the difference between a source and a target catalog.
+type SchemaDiff struct {
+	Schema  string
+	Changes []SchemaChange
+}
+
+// MigrationScript returns the statements that turn the source schema into
+// the target, one per line. Views are dropped and recreated when they
+// change, so views depending on them must be too, and changes the catalog
+// does not capture, such as view dependencies on altered columns, may need
+// editing by hand.
+func (d *SchemaDiff) MigrationScript() string {
+	var steps []migrationStep
+	for _, c := range d.Changes {
+		steps = append(steps, c.steps...)
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].phase < steps[j].phase })
+	lines := make([]string, 0, len(steps)+1)
+	for i, s := range steps {
+		if s.phase == phaseCreateFunctions && (i == 0 || steps[i-1].phase != phaseCreateFunctions) {
+			lines = append(lines, skipFunctionBodyChecks)
+		}
+		lines = append(lines, s.sql)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DiffCatalogs compares two catalogs of the same schema. Objects are
+// matched by name, so a renamed object shows as removed and added.
+func DiffCatalogs(source, target *Catalog) *SchemaDiff {
+	d := &SchemaDiff{Schema: source.Schema, Changes: []SchemaChange{}}
+	schema := quoteIdent(source.Schema)
+	d.diffEnums(schema, source.Enums, target.Enums)
+	d.diffTables(schema, source.Tables, target.Tables)
+	d.diffViews(schema, source.Views, target.Views)
+	d.diffFunctions(schema, source.Functions, target.Functions)
+	return d
+}
+
+func (d *SchemaDiff) diffEnums(schema string, source, target []CatalogEnum) {
+	before := make(map[string]CatalogEnum, len(source))
+	for _, e := range source {
+		before[e.Name] = e
+	}
+	for _, e := range target {
+		name := schema + "." + quoteIdent(e.Name)
+		b, ok := before[e.Name]
+		delete(before, e.Name)
+		if !ok {
+			c := SchemaChange{Object: ObjectEnum, Name: e.Name, Change: SchemaAdded, After: enumLabels(e.Labels)}
+			c.add(phaseEnums, "CREATE TYPE %s AS ENUM (%s);", name, enumLabels(e.Labels))
+			d.Changes = append(d.Changes, c)
+			continue
+		}
+		if equalStrings(b.Labels, e.Labels) {
+			continue
+		}
+		c := SchemaChange{Object: ObjectEnum, Name: e.Name, Change: SchemaChanged,
+			Before: enumLabels(b.Labels), After: enumLabels(e.Labels)}
+		if added, ok := addedLabels(b.Labels, e.Labels); ok {
+			for _, l := range added {
+				c.Details = append(c.Details, "label added: "+l.label)
+				c.add(phaseEnums, "ALTER TYPE %s ADD VALUE %s%s;", name, quoteLiteral(l.label), l.position)
+			}
+		} else {
+			// Labels cannot be removed or reordered in place.
+			c.Details = append(c.Details, "labels removed or reordered")
+			c.add(phaseEnums, "-- %s lost or reordered labels and must be recreated: %s", name, enumLabels(e.Labels))
+		}
+		d.Changes = append(d.Changes, c)
+	}
+	for _, e := range source {
+		if _, ok := before[e.Name]; ok {
+			c := SchemaChange{Object: ObjectEnum, Name: e.Name, Change: SchemaRemoved, Before: enumLabels(e.Labels)}
+			c.add(phaseDropEnums, "DROP TYPE %s.%s;", schema, quoteIdent(e.Name))
+			d.Changes = append(d.Changes, c)
+		}
+	}
+}
+
+type enumLabel struct {
+	label    string
+	position string // " BEFORE 'x'", " AFTER 'x'" or empty
+}
+
+// addedLabels returns the labels of after missing from before, with where
+// to add each, or false if before is not a subsequence of after.
+func addedLabels(before, after []string) ([]enumLabel, bool) {
+	var added []enumLabel
+	i := 0
+	for j, l := range after {
+		if i < len(before) && before[i] == l {
+			i++
+			continue
+		}
+		position := ""
+		if j > 0 {
+			position = " AFTER " + quoteLiteral(after[j-1])
+		} else if len(after) > 1 {
+			position = " BEFORE " + quoteLiteral(after[1])
+		}
+		added = append(added, enumLabel{l, position})
+	}
+	return added, i == len(before)
+}
+
+func enumLabels(labels []string) string {
+	quoted := make([]string, len(labels))
+	for i, l := range labels {
+		quoted[i] = quoteLiteral(l)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (d *SchemaDiff) diffTables(schema string, source, target []CatalogTable) {
+	before := make(map[string]CatalogTable, len(source))
+	for _, t := range source {
+		before[t.Name] = t
+	}
+	for _, t := range target {
+		b, ok := before[t.Name]
+		delete(before, t.Name)
+		if ok {
+			d.diffTable(schema, b, t)
+			continue
+		}
+		table := schema + "." + quoteIdent(t.Name)
+		c := SchemaChange{Object: ObjectTable, Name: t.Name, Change: SchemaAdded}
+		cols := make([]string, len(t.Columns))
+		for i, col := range t.Columns {
+			cols[i] = "    " + col.definition()
+		}
+		c.add(phaseCreateTables, "CREATE TABLE %s (\n%s\n);", table, strings.Join(cols, ",\n"))
+		for _, con := range t.Constraints {
+			c.add(constraintPhase(con, true), "ALTER TABLE %s ADD CONSTRAINT %s %s;", table, quoteIdent(con.Name), con.Definition)
+		}
+		for _, idx := range t.Indexes {
+			c.add(phaseAddConstraints, "%s;", idx.Definition)
+		}
+		c.After = strings.Join(c.SQL, "\n")
+		d.Changes = append(d.Changes, c)
+	}
+	for _, t := range source {
+		if _, ok := before[t.Name]; ok {
+			c := SchemaChange{Object: ObjectTable, Name: t.Name, Change: SchemaRemoved}
+			c.add(phaseDropTables, "DROP TABLE %s.%s;", schema, quoteIdent(t.Name))
+			d.Changes = append(d.Changes, c)
+		}
+	}
+}
+
+func (d *SchemaDiff) diffTable(schema string, source, target CatalogTable) {
+	table := schema + "." + quoteIdent(target.Name)
+
+	before := make(map[string]CatalogColumn, len(source.Columns))
+	for _, col := range source.Columns {
+		before[col.Name] = col
+	}
+	for _, col := range target.Columns {
+		b, ok := before[col.Name]
+		delete(before, col.Name)
+		c := SchemaChange{Object: ObjectColumn, Table: target.Name, Name: col.Name, After: col.definition()}
+		if !ok {
+			c.Change = SchemaAdded
+			c.add(phaseColumns, "ALTER TABLE %s ADD COLUMN %s;", table, col.definition())
+			d.Changes = append(d.Changes, c)
+			continue
+		}
+		c.Change, c.Before = SchemaChanged, b.definition()
+		diffColumn(&c, table, b, col)
+		if len(c.Details) > 0 {
+			d.Changes = append(d.Changes, c)
+		}
+	}
+	for _, col := range source.Columns {
+		if _, ok := before[col.Name]; ok {
+			c := SchemaChange{Object: ObjectColumn, Table: target.Name, Name: col.Name,
+				Change: SchemaRemoved, Before: col.definition()}
+			c.add(phaseColumns, "ALTER TABLE %s DROP COLUMN %s;", table, quoteIdent(col.Name))
+			d.Changes = append(d.Changes, c)
+		}
+	}
+
+	cons := make(map[string]CatalogConstraint, len(source.Constraints))
+	for _, con := range source.Constraints {
+		cons[con.Name] = con
+	}
+	for _, con := range target.Constraints {
+		b, ok := cons[con.Name]
+		delete(cons, con.Name)
+		if ok && b.Type == con.Type && b.Definition == con.Definition {
+			continue
+		}
+		c := SchemaChange{Object: ObjectConstraint, Table: target.Name, Name: con.Name,
+			Change: SchemaAdded, After: con.Definition}
+		if ok {
+			c.Change, c.Before = SchemaChanged, b.Definition
+			c.add(constraintPhase(b, false), "ALTER TABLE %s DROP CONSTRAINT %s;", table, quoteIdent(b.Name))
+		}
+		c.add(constraintPhase(con, true), "ALTER TABLE %s ADD CONSTRAINT %s %s;", table, quoteIdent(con.Name), con.Definition)
+		d.Changes = append(d.Changes, c)
+	}
+	for _, con := range source.Constraints {
+		if _, ok := cons[con.Name]; ok {
+			c := SchemaChange{Object: ObjectConstraint, Table: target.Name, Name: con.Name,
+				Change: SchemaRemoved, Before: con.Definition}
+			c.add(constraintPhase(con, false), "ALTER TABLE %s DROP CONSTRAINT %s;", table, quoteIdent(con.Name))
+			d.Changes = append(d.Changes, c)
+		}
+	}
+
+	idxs := make(map[string]CatalogIndex, len(source.Indexes))
+	for _, idx := range source.Indexes {
+		idxs[idx.Name] = idx
+	}
+	for _, idx := range target.Indexes {
+		b, ok := idxs[idx.Name]
+		delete(idxs, idx.Name)
+		if ok && b.Definition == idx.Definition {
+			continue
+		}
+		c := SchemaChange{Object: ObjectIndex, Table: target.Name, Name: idx.Name,
+			Change: SchemaAdded, After: idx.Definition}
+		if ok {
+			c.Change, c.Before = SchemaChanged, b.Definition
+			c.add(phaseDropConstraints, "DROP INDEX %s.%s;", schema, quoteIdent(b.Name))
+		}
+		c.add(phaseAddConstraints, "%s;", idx.Definition)
+		d.Changes = append(d.Changes, c)
+	}
+	for _, idx := range source.Indexes {
+		if _, ok := idxs[idx.Name]; ok {
+			c := SchemaChange{Object: ObjectIndex, Table: target.Name, Name: idx.Name,
+				Change: SchemaRemoved, Before: idx.Definition}
+			c.add(phaseDropConstraints, "DROP INDEX %s.%s;", schema, quoteIdent(idx.Name))
+			d.Changes = append(d.Changes, c)
+		}
+	}
+}
+
+// diffColumn records the differences between two versions of a column.
+func diffColumn(c *SchemaChange, table string, before, after CatalogColumn) {
+	alter := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", table, quoteIdent(after.Name))
+	if before.Generated != after.Generated {
+		// A generation expression cannot be changed in place before
+		// PostgreSQL 17, nor added to an existing column.
+		c.Details = append(c.Details, fmt.Sprintf("generated: %s -> %s", orNone(before.Generated), orNone(after.Generated)))
+		c.add(phaseColumns, "ALTER TABLE %s DROP COLUMN %s;", table, quoteIdent(after.Name))
+		c.add(phaseColumns, "ALTER TABLE %s ADD COLUMN %s;", table, after.definition())
+		return
+	}
+	if before.Type != after.Type {
+		c.Details = append(c.Details, fmt.Sprintf("type: %s -> %s", before.Type, after.Type))
+		c.add(phaseColumns, "%s TYPE %s USING %s::%s;", alter, after.Type, quoteIdent(after.Name), after.Type)
+	}
+	if before.Identity != after.Identity {
+		c.Details = append(c.Details, fmt.Sprintf("identity: %s -> %s", orNone(before.Identity), orNone(after.Identity)))
+		switch {
+		case after.Identity == "":
+			c.add(phaseColumns, "%s DROP IDENTITY;", alter)
+		case before.Identity == "":
+			if before.Default != "" {
+				c.add(phaseColumns, "%s DROP DEFAULT;", alter)
+			}
+			c.add(phaseColumns, "%s ADD GENERATED %s AS IDENTITY;", alter, after.Identity)
+		default:
+			c.add(phaseColumns, "%s SET GENERATED %s;", alter, after.Identity)
+		}
+	}
+	if before.Default != after.Default && after.Identity == "" {
+		c.Details = append(c.Details, fmt.Sprintf("default: %s -> %s", orNone(before.Default), orNone(after.Default)))
+		if after.Default == "" {
+			c.add(phaseColumns, "%s DROP DEFAULT;", alter)
+		} else {
+			c.add(phaseColumns, "%s SET DEFAULT %s;", alter, after.Default)
+		}
+	}
+	if before.Nullable != after.Nullable {
+		if after.Nullable {
+			c.Details = append(c.Details, "nullable: no -> yes")
+			c.add(phaseColumns, "%s DROP NOT NULL;", alter)
+		} else {
+			c.Details = append(c.Details, "nullable: yes -> no")
+			c.add(phaseColumns, "%s SET NOT NULL;", alter)
+		}
+	}
+}
+
+// constraintPhase is the phase a constraint is added or dropped in. Foreign
+// keys are dropped first and added last, as they depend on the unique
+// constraints of other tables.
+func constraintPhase(con CatalogConstraint, add bool) int {
+	switch {
+	case con.Type == "f" && add:
+		return phaseAddForeignKeys
+	case con.Type == "f":
+		return phaseDropForeignKeys
+	case add:
+		return phaseAddConstraints
+	default:
+		return phaseDropConstraints
+	}
+}
+
+func (col CatalogColumn) definition() string {
+	var b strings.Builder
+	b.WriteString(quoteIdent(col.Name) + " " + col.Type)
+	switch {
+	case col.Generated != "":
+		b.WriteString(" GENERATED ALWAYS AS (" + col.Generated + ") STORED")
+	case col.Identity != "":
+		b.WriteString(" GENERATED " + col.Identity + " AS IDENTITY")
+	case col.Default != "":
+		b.WriteString(" DEFAULT " + col.Default)
+	}
+	if !col.Nullable {
+		b.WriteString(" NOT NULL")
+	}
+	return b.String()
+}
+
+func (d *SchemaDiff) diffViews(schema string, source, target []CatalogView) {
+	before := make(map[string]CatalogView, len(source))
+	for _, v := range source {
+		before[v.Name] = v
+	}
+	for _, v := range target {
+		b, ok := before[v.Name]
+		delete(before, v.Name)
+		if ok && b.Materialized == v.Materialized && b.Definition == v.Definition {
+			continue
+		}
+		c := SchemaChange{Object: v.object(), Name: v.Name, Change: SchemaAdded, After: v.Definition}
+		if ok {
+			c.Change, c.Before = SchemaChanged, b.Definition
+			c.add(phaseDropViews, "DROP %s %s.%s;", b.keyword(), schema, quoteIdent(b.Name))
+		}
+		query := strings.TrimSuffix(strings.TrimSpace(v.Definition), ";")
+		c.add(phaseCreateViews, "CREATE %s %s.%s AS\n%s;", v.keyword(), schema, quoteIdent(v.Name), query)
+		d.Changes = append(d.Changes, c)
+	}
+	for _, v := range source {
+		if _, ok := before[v.Name]; ok {
+			c := SchemaChange{Object: v.object(), Name: v.Name, Change: SchemaRemoved, Before: v.Definition}
+			c.add(phaseDropViews, "DROP %s %s.%s;", v.keyword(), schema, quoteIdent(v.Name))
+			d.Changes = append(d.Changes, c)
+		}
+	}
+}
+
+func (v CatalogView) object() DiffObject {
+	if v.Materialized {
+		return ObjectMaterializedView
+	}
+	return ObjectView
+}
+
+func (v CatalogView) keyword() string {
+	if v.Materialized {
+		return "MATERIALIZED VIEW"
+	}
+	return "VIEW"
+}
+
+func (d *SchemaDiff) diffFunctions(schema string, source, target []CatalogFunction) {
+	before := make(map[string]CatalogFunction, len(source))
+	for _, f := range source {
+		before[f.Signature()] = f
+	}
+	for _, f := range target {
+		b, ok := before[f.Signature()]
+		delete(before, f.Signature())
+		if ok && b.Definition == f.Definition {
+			continue
+		}
+		c := SchemaChange{Object: ObjectFunction, Name: f.Signature(), Change: SchemaAdded, After: f.Definition}
+		if ok {
+			c.Change, c.Before = SchemaChanged, b.Definition
+		}
+		// pg_get_functiondef already reads CREATE OR REPLACE, which fails if
+		// the return type changed.
+		c.add(phaseCreateFunctions, "%s;", strings.TrimSpace(f.Definition))
+		d.Changes = append(d.Changes, c)
+	}
+	for _, f := range source {
+		if _, ok := before[f.Signature()]; ok {
+			c := SchemaChange{Object: ObjectFunction, Name: f.Signature(), Change: SchemaRemoved, Before: f.Definition}
+			c.add(phaseDropFunctions, "DROP %s %s.%s(%s);", strings.ToUpper(f.Kind), schema, quoteIdent(f.Name), f.Arguments)
+			d.Changes = append(d.Changes, c)
+		}
+	}
+}
+
+func quoteLiteral(s string) string {
+	return strings.TrimSpace(pq.QuoteLiteral(s))
+}
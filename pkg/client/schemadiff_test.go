@@ -0,0 +1,66 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMigrationScriptFunctionOrder(t *testing.T) {
+	oldFn := CatalogFunction{Name: "old_fn", Arguments: "", Kind: "function",
+		Definition: "CREATE OR REPLACE FUNCTION public.old_fn() RETURNS int LANGUAGE sql AS $$ SELECT 1 $$"}
+	newFn := CatalogFunction{Name: "next_code", Arguments: "", Kind: "function",
+		Definition: "CREATE OR REPLACE FUNCTION public.next_code() RETURNS text LANGUAGE sql AS $$ SELECT max(code) FROM codes $$"}
+	source := &Catalog{
+		Schema: "public",
+		Tables: []CatalogTable{{
+			Name:    "legacy",
+			Columns: []CatalogColumn{{Name: "n", Type: "integer", Default: "old_fn()"}},
+		}},
+		Functions: []CatalogFunction{oldFn},
+	}
+	target := &Catalog{
+		Schema: "public",
+		Tables: []CatalogTable{{
+			Name:    "codes",
+			Columns: []CatalogColumn{{Name: "code", Type: "text", Default: "next_code()"}},
+		}},
+		Functions: []CatalogFunction{newFn},
+	}
+
+	script := DiffCatalogs(source, target).MigrationScript()
+	lines := strings.Split(script, "\n")
+	index := func(prefix string) int {
+		for i, l := range lines {
+			if strings.HasPrefix(l, prefix) {
+				return i
+			}
+		}
+		t.Fatalf("no line starting with %q in\n%s", prefix, script)
+		return -1
+	}
+
+	skip := index(skipFunctionBodyChecks)
+	create := index("CREATE OR REPLACE FUNCTION public.next_code")
+	table := index("CREATE TABLE")
+	dropTable := index("DROP TABLE")
+	dropFn := index("DROP FUNCTION")
+	if !(skip < create && create < table) {
+		t.Errorf("function not created before the table using it:\n%s", script)
+	}
+	if dropFn < dropTable {
+		t.Errorf("function dropped before the table using it:\n%s", script)
+	}
+	if n := strings.Count(script, skipFunctionBodyChecks); n != 1 {
+		t.Errorf("%q appears %d times", skipFunctionBodyChecks, n)
+	}
+}
+
+func TestMigrationScriptWithoutNewFunctions(t *testing.T) {
+	source := &Catalog{Schema: "public"}
+	target := &Catalog{Schema: "public", Tables: []CatalogTable{{
+		Name: "t", Columns: []CatalogColumn{{Name: "id", Type: "integer"}},
+	}}}
+	if script := DiffCatalogs(source, target).MigrationScript(); strings.Contains(script, skipFunctionBodyChecks) {
+		t.Errorf("script without functions turns off body checks:\n%s", script)
+	}
+}
@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Session is a single backend connection taken out of the pool, so that
+// consecutive queries (e.g. BEGIN, then statements, then COMMIT) run on the
+// same server session. It counts against the pool's open connection limit
+// until closed.
+type Session struct {
+	conn    *sql.Conn
+	client  *Client
+	opts    Options
+	pid     int
+	pinned  bool      // holds one of the client's pin tokens
+	release sync.Once // returns the pin token
+}
+
+// cancelStatementTimeout bounds CancelStatement, which needs a free pool
+// connection.
+const cancelStatementTimeout = 2 * time.Second
+
+// Session reserves a connection from the pool for the duration of a request,
+// waiting for one to be released if all are in use, until ctx is done.
+func (c *Client) Session(ctx context.Context) (*Session, error) {
+	conn, err := c.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reserve connection: %w", err)
+	}
+	return &Session{conn: conn, client: c, opts: c.opts}, nil
+}
+
+// PinnedSession reserves a connection to be held across requests, e.g. by a
+// tab with an open transaction. Pinned connections could otherwise hold the
+// whole pool indefinitely, so rather than wait it returns
+// ErrTooManySessions when every connection that may be pinned is.
+func (c *Client) PinnedSession(ctx context.Context) (*Session, error) {
+	select {
+	case c.pins <- struct{}{}:
+	default:
+		return nil, ErrTooManySessions
+	}
+	sess, err := c.Session(ctx)
+	if err != nil {
+		<-c.pins
+		return nil, err
+	}
+	sess.pinned = true
+	return sess, nil
+}
+
+// close returns the connection to the pool and frees its pin token, if any.
+func (s *Session) close() error {
+	err := s.conn.Close()
+	if s.pinned {
+		s.release.Do(func() { <-s.client.pins })
+	}
+	return err
+}
+
+// ReadOnly reports whether the session's client was opened in read-only mode.
+func (s *Session) ReadOnly() bool {
+	return s.opts.ReadOnly
+}
+
+// BackendPID returns the server process ID serving the session, for use
+// with pg_cancel_backend. It is looked up once per pool connection and
+// remembered with it.
+func (s *Session) BackendPID(ctx context.Context) (int, error) {
+	if s.pid != 0 {
+		return s.pid, nil
+	}
+	s.conn.Raw(func(dc any) error {
+		if tc, ok := dc.(*tagConn); ok {
+			s.pid = tc.pid
+		}
+		return nil
+	})
+	if s.pid != 0 {
+		return s.pid, nil
+	}
+	if err := s.conn.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&s.pid); err != nil {
+		return 0, err
+	}
+	s.conn.Raw(func(dc any) error {
+		if tc, ok := dc.(*tagConn); ok {
+			tc.pid = s.pid
+		}
+		return nil
+	})
+	return s.pid, nil
+}
+
+// CancelStatement asks the server, over another pool connection, to cancel
+// the statement running on the session. It needs the backend PID to have
+// been looked up with BackendPID, and reports whether the signal was sent.
+// Suitable as the cancel func of Rows.StopEarly.
+func (s *Session) CancelStatement() bool {
+	if s.pid == 0 {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cancelStatementTimeout)
+	defer cancel()
+	ok, err := s.client.CancelBackend(ctx, s.pid)
+	return err == nil && ok
+}
+
+// Exec runs a statement that returns no rows.
+func (s *Session) Exec(ctx context.Context, query string) error {
+	_, err := s.conn.ExecContext(ctx, query)
+	return err
+}
+
+// QueryRows executes query on the session with optional bind arguments and
+// returns a cursor over its rows. The caller must Close the returned Rows.
+func (s *Session) QueryRows(ctx context.Context, query string, args ...any) (*Rows, error) {
+	return queryRows(ctx, s.conn, query, args...)
+}
+
+// QueryWithLimit executes query on the session with optional bind arguments
+// and buffers at most maxRows rows, setting Truncated when the result set had
+// more.
+func (s *Session) QueryWithLimit(ctx context.Context, query string, maxRows int, args ...any) (*QueryResult, error) {
+	rows, err := s.QueryRows(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows.Collect(maxRows)
+}
+
+// TxStatus is the transaction state of a session.
+type TxStatus string
+
+const (
+	TxIdle   TxStatus = "idle"
+	TxActive TxStatus = "in_transaction"
+	TxFailed TxStatus = "failed" // aborted by an error, awaiting ROLLBACK
+)
+
+// Status reports whether the session has an open transaction block. Outside
+// a block every statement is its own transaction, so now() equals
+// statement_timestamp(); inside one, now() is fixed at the block's start. In
+// an aborted block the probe itself fails with SQLSTATE 25P02.
+func (s *Session) Status(ctx context.Context) (TxStatus, error) {
+	var inTx bool
+	err := s.conn.QueryRowContext(ctx, "SELECT now() <> statement_timestamp()").Scan(&inTx)
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &pqErr) && pqErr.Code == "25P02":
+		return TxFailed, nil
+	case err != nil:
+		return "", err
+	case inTx:
+		return TxActive, nil
+	}
+	return TxIdle, nil
+}
+
+// Release returns the connection to the pool as is. Use it only when no
+// transaction can be open; otherwise use Close.
+func (s *Session) Release() error {
+	return s.close()
+}
+
+// Close rolls back any open transaction and returns the connection to the
+// pool. If the rollback fails the connection is discarded instead, so a
+// half-finished transaction never leaks into another query.
+func (s *Session) Close() error {
+	if _, err := s.conn.ExecContext(context.Background(), "ROLLBACK"); err != nil {
+		s.discard()
+	}
+	return s.close()
+}
+
+// RollbackAfter runs fn in a transaction that is then rolled back, so
+// nothing fn changes persists. Inside an open transaction a savepoint is
+// used instead, leaving the transaction as it was. If the rollback fails the
+// connection is discarded, which ends the transaction on the server.
+func (s *Session) RollbackAfter(ctx context.Context, fn func() error) error {
+	status, err := s.Status(ctx)
+	if err != nil {
+		return err
+	}
+	begin, rollback := "BEGIN", "ROLLBACK"
+	switch status {
+	case TxActive:
+		begin = "SAVEPOINT pglet_rollback"
+		rollback = "ROLLBACK TO SAVEPOINT pglet_rollback; RELEASE SAVEPOINT pglet_rollback"
+	case TxFailed:
+		// Every statement fails until the transaction ends, so fn cannot
+		// change anything.
+		return fn()
+	}
+	if err := s.Exec(ctx, begin); err != nil {
+		return err
+	}
+	fnErr := fn()
+	// Roll back even if fn was cancelled.
+	if err := s.Exec(context.WithoutCancel(ctx), rollback); err != nil {
+		s.discard()
+		return errors.Join(fnErr, fmt.Errorf("roll back: %w", err))
+	}
+	return fnErr
+}
+
+// discard marks the connection broken so the pool closes it instead of
+// reusing it.
+func (s *Session) discard() {
+	s.conn.Raw(func(any) error { return driver.ErrBadConn })
+}
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StatementOrder is the ranking of a statement statistics list.
+type StatementOrder string
+
+const (
+	OrderTotalTime StatementOrder = "total_time"
+	OrderMeanTime  StatementOrder = "mean_time"
+	OrderCalls     StatementOrder = "calls"
+	OrderRows      StatementOrder = "rows"
+	OrderHitRatio  StatementOrder = "hit_ratio" // lowest first
+)
+
+// StatementStat is the pg_stat_statements counters of one normalized query,
+// summed over top-level and nested execution.
+type StatementStat struct {
+	QueryID        int64
+	User           string
+	Query          string
+	Calls          int64
+	TotalMs        float64
+	Rows           int64
+	SharedBlksHit  int64
+	SharedBlksRead int64
+}
+
+// MeanMs is the average execution time per call.
+func (s StatementStat) MeanMs() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalMs / float64(s.Calls)
+}
+
+// HitRatio is the fraction of shared blocks found in the buffer cache, or
+// false if the statement read no shared blocks.
+func (s StatementStat) HitRatio() (float64, bool) {
+	total := s.SharedBlksHit + s.SharedBlksRead
+	if total == 0 {
+		return 0, false
+	}
+	return float64(s.SharedBlksHit) / float64(total), true
+}
+
+// ErrNoStatStatements is returned when the pg_stat_statements extension is
+// not installed in the current database.
+var ErrNoStatStatements = errors.New("pg_stat_statements is not installed in this database")
+
+// StatementStats returns the pg_stat_statements counters of every query run
+// in the current database, in no particular order.
+func (c *Client) StatementStats(ctx context.Context) ([]StatementStat, error) {
+	version, schema, err := c.statStatements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Version 1.8 (PostgreSQL 13) split planning from execution time and
+	// renamed total_time to total_exec_time.
+	totalTime := "total_time"
+	if extensionAtLeast(version, 1, 8) {
+		totalTime = "total_exec_time"
+	}
+
+	rows, err := c.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT COALESCE(s.queryid, 0), COALESCE(s.userid::regrole::text, ''), min(s.query),
+			sum(s.calls)::bigint, sum(s.%s)::float8, sum(s.rows)::bigint,
+			sum(s.shared_blks_hit)::bigint, sum(s.shared_blks_read)::bigint
+		FROM %s.pg_stat_statements s
+		WHERE s.dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+		GROUP BY 1, 2`, totalTime, quoteIdent(schema)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []StatementStat
+	for rows.Next() {
+		var s StatementStat
+		if err := rows.Scan(&s.QueryID, &s.User, &s.Query, &s.Calls, &s.TotalMs, &s.Rows,
+			&s.SharedBlksHit, &s.SharedBlksRead); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// ResetStatementStats discards all pg_stat_statements counters.
+func (c *Client) ResetStatementStats(ctx context.Context) error {
+	_, schema, err := c.statStatements(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.ExecContext(ctx, fmt.Sprintf("SELECT %s.pg_stat_statements_reset()", quoteIdent(schema)))
+	return err
+}
+
+// statStatements returns the installed version of pg_stat_statements and
+// the schema it is installed in, which need not be on the search path.
+func (c *Client) statStatements(ctx context.Context) (version, schema string, err error) {
+	err = c.db.QueryRowContext(ctx, `
+		SELECT e.extversion, n.nspname FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname = 'pg_stat_statements'`).Scan(&version, &schema)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", ErrNoStatStatements
+	}
+	return version, schema, err
+}
+
+// extensionAtLeast reports whether an extension version such as "1.10" is
+// at least major.minor.
+func extensionAtLeast(version string, major, minor int) bool {
+	majStr, minStr, _ := strings.Cut(version, ".")
+	ma, _ := strconv.Atoi(majStr)
+	mi, _ := strconv.Atoi(minStr)
+	return ma > major || ma == major && mi >= minor
+}
+
+// SortStatements orders stats by order, highest first except for
+// OrderHitRatio, which puts the lowest ratio first and statements that read
+// no shared blocks last.
+func SortStatements(stats []StatementStat, order StatementOrder) {
+	less := func(a, b StatementStat) bool { return a.TotalMs > b.TotalMs }
+	switch order {
+	case OrderMeanTime:
+		less = func(a, b StatementStat) bool { return a.MeanMs() > b.MeanMs() }
+	case OrderCalls:
+		less = func(a, b StatementStat) bool { return a.Calls > b.Calls }
+	case OrderRows:
+		less = func(a, b StatementStat) bool { return a.Rows > b.Rows }
+	case OrderHitRatio:
+		less = func(a, b StatementStat) bool {
+			ra, oka := a.HitRatio()
+			rb, okb := b.HitRatio()
+			if oka != okb {
+				return oka
+			}
+			return ra < rb
+		}
+	}
+	sort.SliceStable(stats, func(i, j int) bool { return less(stats[i], stats[j]) })
+}
+
+// StatementDeltas returns the change in each statement's counters between
+// two sets of stats taken from the same database, omitting statements that
+// were not called in between. A statement whose calls went down had its
+// counters reset in between, so its later counters are the delta.
+func StatementDeltas(before, after []StatementStat) []StatementStat {
+	type key struct {
+		id   int64
+		user string
+	}
+	prev := make(map[key]StatementStat, len(before))
+	for _, s := range before {
+		prev[key{s.QueryID, s.User}] = s
+	}
+	deltas := []StatementStat{}
+	for _, s := range after {
+		if p, ok := prev[key{s.QueryID, s.User}]; ok && s.Calls >= p.Calls {
+			s.Calls -= p.Calls
+			s.TotalMs -= p.TotalMs
+			s.Rows -= p.Rows
+			s.SharedBlksHit -= p.SharedBlksHit
+			s.SharedBlksRead -= p.SharedBlksRead
+		}
+		if s.Calls > 0 {
+			deltas = append(deltas, s)
+		}
+	}
+	return deltas
+}
@@ -0,0 +1,72 @@
+// Package export encodes query result sets into downloadable file formats.
+// Every format writes rows as they arrive so exports of any size run in
+// bounded memory.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Writer encodes a result set in one format, one row at a time.
+type Writer interface {
+	WriteHeader(columns, columnTypes []string) error
+	WriteRow(row []any) error
+	Close() error
+}
+
+// Options configures format-specific output.
+type Options struct {
+	// Table is the target table name used by the SQL INSERT format.
+	Table string
+}
+
+// Format describes an export format.
+type Format struct {
+	ContentType string
+	Extension   string
+	New         func(w io.Writer, opts Options) Writer
+}
+
+// Filename returns the download filename for the format.
+func (f Format) Filename() string {
+	return "export." + f.Extension
+}
+
+var formats = map[string]Format{
+	"csv":      {"text/csv", "csv", newCSVWriter},
+	"json":     {"application/json", "json", newJSONWriter},
+	"ndjson":   {"application/x-ndjson", "ndjson", newNDJSONWriter},
+	"markdown": {"text/markdown; charset=utf-8", "md", newMarkdownWriter},
+	"sql":      {"application/sql", "sql", newSQLWriter},
+	"xlsx":     {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx", newXLSXWriter},
+	"parquet":  {"application/vnd.apache.parquet", "parquet", newParquetWriter},
+}
+
+// Lookup returns the format registered under name.
+func Lookup(name string) (Format, bool) {
+	f, ok := formats[name]
+	return f, ok
+}
+
+// formatText renders a cell as text for text-based formats. JSON values and
+// arrays are rendered as JSON.
+func formatText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.RawMessage:
+		return string(t)
+	case []any:
+		b, _ := json.Marshal(t)
+		return string(b)
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
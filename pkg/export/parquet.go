@@ -0,0 +1,423 @@
+package export
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parquet physical types.
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetFloat     = 4
+	parquetDouble    = 5
+	parquetByteArray = 6
+)
+
+// Parquet converted (logical) types; parquetNoConversion means none.
+const (
+	parquetNoConversion   = -1
+	parquetUTF8           = 0
+	parquetDate           = 6
+	parquetTimestampMicro = 10
+	parquetInt16          = 16
+	parquetJSON           = 19
+)
+
+const (
+	parquetMagic         = "PAR1"
+	parquetOptional      = 1 // FieldRepetitionType.OPTIONAL
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+	parquetRowGroupRows  = 8192
+	parquetRowGroupBytes = 32 << 20
+	parquetPageTypeData  = 0
+	parquetCodecNone     = 0
+	parquetFormatVersion = 1
+	parquetCreatedBy     = "pglet"
+)
+
+// parquetWriter writes an uncompressed Parquet file. Rows are buffered per
+// row group (bounded by row count and size), and each column chunk is written
+// as a single PLAIN-encoded data page with RLE definition levels. Column
+// types are derived from the PostgreSQL column type names.
+type parquetWriter struct {
+	w       io.Writer
+	offset  int64
+	columns []*parquetColumn
+	groups  []parquetRowGroup
+	rows    int   // rows buffered in the current group
+	bytes   int   // approximate bytes buffered in the current group
+	total   int64 // rows written
+}
+
+type parquetColumn struct {
+	name      string
+	physical  int32
+	converted int32
+	values    []any
+}
+
+type parquetRowGroup struct {
+	numRows    int64
+	totalBytes int64
+	chunks     []parquetChunk
+}
+
+type parquetChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+func newParquetWriter(w io.Writer, _ Options) Writer {
+	return &parquetWriter{w: w}
+}
+
+func (p *parquetWriter) write(b []byte) error {
+	n, err := p.w.Write(b)
+	p.offset += int64(n)
+	return err
+}
+
+func (p *parquetWriter) WriteHeader(columns, columnTypes []string) error {
+	seen := make(map[string]int)
+	for i, name := range columns {
+		// Parquet readers reject duplicate column names, e.g. from SELECT 1, 1.
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		colType := ""
+		if i < len(columnTypes) {
+			colType = columnTypes[i]
+		}
+		physical, converted := parquetType(colType)
+		p.columns = append(p.columns, &parquetColumn{name: name, physical: physical, converted: converted})
+	}
+	return p.write([]byte(parquetMagic))
+}
+
+// parquetType maps a PostgreSQL type name to Parquet physical and converted types.
+func parquetType(columnType string) (physical, converted int32) {
+	switch strings.ToUpper(columnType) {
+	case "BOOL":
+		return parquetBoolean, parquetNoConversion
+	case "INT2":
+		return parquetInt32, parquetInt16
+	case "INT4", "OID":
+		return parquetInt32, parquetNoConversion
+	case "INT8":
+		return parquetInt64, parquetNoConversion
+	case "FLOAT4":
+		return parquetFloat, parquetNoConversion
+	case "FLOAT8":
+		return parquetDouble, parquetNoConversion
+	case "DATE":
+		return parquetInt32, parquetDate
+	case "TIMESTAMP", "TIMESTAMPTZ":
+		return parquetInt64, parquetTimestampMicro
+	case "JSON", "JSONB":
+		return parquetByteArray, parquetJSON
+	case "BYTEA":
+		return parquetByteArray, parquetNoConversion
+	default:
+		// numeric is kept as its exact decimal text
+		return parquetByteArray, parquetUTF8
+	}
+}
+
+func (p *parquetWriter) WriteRow(row []any) error {
+	for i, col := range p.columns {
+		var v any
+		if i < len(row) {
+			v = parquetValue(col, row[i])
+		}
+		col.values = append(col.values, v)
+		switch t := v.(type) {
+		case string:
+			p.bytes += len(t) + 4
+		case []byte:
+			p.bytes += len(t) + 4
+		default:
+			p.bytes += 8
+		}
+	}
+	p.rows++
+	if p.rows >= parquetRowGroupRows || p.bytes >= parquetRowGroupBytes {
+		return p.flushRowGroup()
+	}
+	return nil
+}
+
+// parquetValue converts a scanned cell to the Go type matching the column's
+// physical type, or nil when it cannot be represented.
+func parquetValue(col *parquetColumn, v any) any {
+	if v == nil {
+		return nil
+	}
+	switch col.physical {
+	case parquetBoolean:
+		switch t := v.(type) {
+		case bool:
+			return t
+		case string:
+			if b, err := strconv.ParseBool(t); err == nil {
+				return b
+			}
+		}
+	case parquetInt32:
+		if col.converted == parquetDate {
+			if t, ok := v.(time.Time); ok {
+				return int32(t.Unix() / 86400)
+			}
+			if t, err := time.Parse("2006-01-02", formatText(v)); err == nil {
+				return int32(t.Unix() / 86400)
+			}
+			return nil
+		}
+		if n, ok := toInt64(v); ok {
+			return int32(n)
+		}
+	case parquetInt64:
+		if col.converted == parquetTimestampMicro {
+			if t, ok := v.(time.Time); ok {
+				return t.UnixMicro()
+			}
+			if t, ok := parseTimestamp(formatText(v)); ok {
+				return t.UnixMicro()
+			}
+			return nil
+		}
+		if n, ok := toInt64(v); ok {
+			return n
+		}
+	case parquetFloat, parquetDouble:
+		switch t := v.(type) {
+		case float64:
+			return t
+		case float32:
+			return float64(t)
+		case int64:
+			return float64(t)
+		}
+		if f, err := strconv.ParseFloat(formatText(v), 64); err == nil {
+			return f
+		}
+	default:
+		switch t := v.(type) {
+		case []byte:
+			return t
+		case string:
+			// bytea arrives as PostgreSQL's \x hex text; store the raw bytes.
+			if col.converted == parquetNoConversion && strings.HasPrefix(t, `\x`) {
+				if b, err := hex.DecodeString(t[2:]); err == nil {
+					return b
+				}
+			}
+		}
+		return formatText(v)
+	}
+	return nil
+}
+
+// parseTimestamp parses an ISO-8601 timestamp with or without a zone offset.
+func parseTimestamp(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func toInt64(v any) (int64, bool) {
+	switch t := v.(type) {
+	case int64:
+		return t, true
+	case int:
+		return int64(t), true
+	case int32:
+		return int64(t), true
+	case float64:
+		return int64(t), true
+	}
+	n, err := strconv.ParseInt(formatText(v), 10, 64)
+	return n, err == nil
+}
+
+// flushRowGroup writes the buffered rows as one row group.
+func (p *parquetWriter) flushRowGroup() error {
+	if p.rows == 0 {
+		return nil
+	}
+	group := parquetRowGroup{numRows: int64(p.rows)}
+	for _, col := range p.columns {
+		page := encodeParquetPage(col)
+		chunk := parquetChunk{offset: p.offset, size: int64(len(page)), numValues: int64(len(col.values))}
+		if err := p.write(page); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.totalBytes += chunk.size
+		col.values = col.values[:0]
+	}
+	p.groups = append(p.groups, group)
+	p.total += int64(p.rows)
+	p.rows = 0
+	p.bytes = 0
+	return nil
+}
+
+// encodeParquetPage encodes a column's buffered values as a data page
+// (header included).
+func encodeParquetPage(col *parquetColumn) []byte {
+	// Definition levels: one bit-packed run of width 1, 1 = present.
+	groups := (len(col.values) + 7) / 8
+	levels := binary.AppendUvarint(nil, uint64(groups<<1|1))
+	bits := make([]byte, groups)
+	for i, v := range col.values {
+		if v != nil {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	levels = append(levels, bits...)
+
+	data := binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+	data = append(data, levels...)
+	data = appendParquetValues(data, col)
+
+	var t thriftWriter
+	t.beginStruct()
+	t.i32(1, parquetPageTypeData)
+	t.i32(2, int32(len(data)))
+	t.i32(3, int32(len(data)))
+	t.structField(5)
+	t.i32(1, int32(len(col.values)))
+	t.i32(2, parquetEncodingPlain)
+	t.i32(3, parquetEncodingRLE)
+	t.i32(4, parquetEncodingRLE)
+	t.endStruct()
+	t.endStruct()
+
+	return append(t.buf, data...)
+}
+
+// appendParquetValues PLAIN-encodes the non-null values of col.
+func appendParquetValues(buf []byte, col *parquetColumn) []byte {
+	if col.physical == parquetBoolean {
+		var bits []byte
+		n := 0
+		for _, v := range col.values {
+			if v == nil {
+				continue
+			}
+			if n%8 == 0 {
+				bits = append(bits, 0)
+			}
+			if v.(bool) {
+				bits[n/8] |= 1 << (n % 8)
+			}
+			n++
+		}
+		return append(buf, bits...)
+	}
+	for _, v := range col.values {
+		switch t := v.(type) {
+		case nil:
+		case int32:
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(t))
+		case int64:
+			buf = binary.LittleEndian.AppendUint64(buf, uint64(t))
+		case float64:
+			if col.physical == parquetFloat {
+				buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(t)))
+			} else {
+				buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(t))
+			}
+		case string:
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(t)))
+			buf = append(buf, t...)
+		case []byte:
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(t)))
+			buf = append(buf, t...)
+		}
+	}
+	return buf
+}
+
+func (p *parquetWriter) Close() error {
+	if err := p.flushRowGroup(); err != nil {
+		return err
+	}
+	footer := p.footer()
+	if err := p.write(footer); err != nil {
+		return err
+	}
+	tail := binary.LittleEndian.AppendUint32(nil, uint32(len(footer)))
+	return p.write(append(tail, parquetMagic...))
+}
+
+// footer serialises the FileMetaData struct.
+func (p *parquetWriter) footer() []byte {
+	var t thriftWriter
+	t.beginStruct()
+	t.i32(1, parquetFormatVersion)
+
+	t.listField(2, thriftStruct, len(p.columns)+1)
+	t.beginStruct()
+	t.str(4, "schema")
+	t.i32(5, int32(len(p.columns)))
+	t.endStruct()
+	for _, col := range p.columns {
+		t.beginStruct()
+		t.i32(1, col.physical)
+		t.i32(3, parquetOptional)
+		t.str(4, col.name)
+		if col.converted != parquetNoConversion {
+			t.i32(6, col.converted)
+		}
+		t.endStruct()
+	}
+
+	t.i64(3, p.total)
+
+	t.listField(4, thriftStruct, len(p.groups))
+	for _, g := range p.groups {
+		t.beginStruct()
+		t.listField(1, thriftStruct, len(g.chunks))
+		for i, c := range g.chunks {
+			col := p.columns[i]
+			t.beginStruct()
+			t.i64(2, c.offset)
+			t.structField(3)
+			t.i32(1, col.physical)
+			t.listField(2, thriftI32, 2)
+			t.listI32(parquetEncodingPlain)
+			t.listI32(parquetEncodingRLE)
+			t.listField(3, thriftBinary, 1)
+			t.listString(col.name)
+			t.i32(4, parquetCodecNone)
+			t.i64(5, c.numValues)
+			t.i64(6, c.size)
+			t.i64(7, c.size)
+			t.i64(9, c.offset)
+			t.endStruct()
+			t.endStruct()
+		}
+		t.i64(2, g.totalBytes)
+		t.i64(3, g.numRows)
+		t.endStruct()
+	}
+
+	t.str(6, parquetCreatedBy)
+	t.endStruct()
+	return t.buf
+}
@@ -0,0 +1,268 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// parquetColumns and parquetRows cover every type mapping, with cells as
+// client.Rows.Values returns them.
+var (
+	parquetColumns = []string{"id", "name", "ok", "data", "doc", "at", "day", "id", "small", "ratio"}
+	parquetTypes   = []string{"INT8", "TEXT", "BOOL", "BYTEA", "JSONB", "TIMESTAMPTZ", "DATE", "INT4", "INT2", "FLOAT8"}
+	parquetRows    = [][]any{
+		{int64(1), "alice", true, `\xdead00`, json.RawMessage(`{"a":1}`), "2024-01-02T03:04:05.000006Z", "2024-01-02", int64(7), int64(3), 1.5},
+		{nil, "bob", false, nil, nil, nil, nil, nil, nil, nil},
+		{int64(-5), nil, true, `\x`, json.RawMessage(`[]`), "2024-01-02T03:04:05", "1970-01-02", int64(-1), int64(0), float64(2)},
+	}
+)
+
+func writeParquet(t *testing.T, columns, types []string, rows [][]any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := newParquetWriter(&buf, Options{})
+	if err := w.WriteHeader(columns, types); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParquetGolden(t *testing.T) {
+	got := writeParquet(t, parquetColumns, parquetTypes, parquetRows)
+	golden := filepath.Join("testdata", "types.parquet")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s; run go test -update to rewrite it after checking the change", golden)
+	}
+}
+
+func TestParquetFooter(t *testing.T) {
+	file := writeParquet(t, parquetColumns, parquetTypes, parquetRows)
+	meta := parquetFooter(t, file)
+
+	if meta[1] != int64(parquetFormatVersion) || meta[3] != int64(3) || meta[6] != parquetCreatedBy {
+		t.Errorf("version, num_rows, created_by = %v, %v, %v", meta[1], meta[3], meta[6])
+	}
+
+	schema := meta[2].([]any)
+	if root := schema[0].(thriftStructValue); root[4] != "schema" || root[5] != int64(len(parquetColumns)) {
+		t.Errorf("schema root = %v", root)
+	}
+	wantSchema := []struct {
+		name      string
+		physical  int64
+		converted any // nil when absent
+	}{
+		{"id", parquetInt64, nil},
+		{"name", parquetByteArray, int64(parquetUTF8)},
+		{"ok", parquetBoolean, nil},
+		{"data", parquetByteArray, nil},
+		{"doc", parquetByteArray, int64(parquetJSON)},
+		{"at", parquetInt64, int64(parquetTimestampMicro)},
+		{"day", parquetInt32, int64(parquetDate)},
+		{"id_2", parquetInt32, nil},
+		{"small", parquetInt32, int64(parquetInt16)},
+		{"ratio", parquetDouble, nil},
+	}
+	if len(schema) != len(wantSchema)+1 {
+		t.Fatalf("schema has %d elements, want %d", len(schema), len(wantSchema)+1)
+	}
+	for i, want := range wantSchema {
+		el := schema[i+1].(thriftStructValue)
+		if el[4] != want.name || el[1] != want.physical || el[3] != int64(parquetOptional) || el[6] != want.converted {
+			t.Errorf("schema[%d] = %v, want %+v", i+1, el, want)
+		}
+	}
+
+	groups := meta[4].([]any)
+	if len(groups) != 1 {
+		t.Fatalf("%d row groups, want 1", len(groups))
+	}
+	group := groups[0].(thriftStructValue)
+	chunks := group[1].([]any)
+	if group[3] != int64(3) || len(chunks) != len(parquetColumns) {
+		t.Fatalf("row group = %v", group)
+	}
+	var total int64
+	for i, c := range chunks {
+		chunk := c.(thriftStructValue)
+		cm := chunk[3].(thriftStructValue)
+		name := wantSchema[i].name
+		if cm[1] != wantSchema[i].physical || !reflect.DeepEqual(cm[3], []any{name}) || cm[5] != int64(3) ||
+			cm[4] != int64(parquetCodecNone) || cm[9] != chunk[2] || cm[6] != cm[7] {
+			t.Errorf("column chunk %s = %v", name, cm)
+		}
+		total += cm[7].(int64)
+	}
+	if group[2] != total {
+		t.Errorf("total_byte_size = %v, want %d", group[2], total)
+	}
+}
+
+func TestParquetValues(t *testing.T) {
+	file := writeParquet(t, parquetColumns, parquetTypes, parquetRows)
+	meta := parquetFooter(t, file)
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC).UnixMicro()
+	day := int32(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+	want := [][]any{
+		{int64(1), nil, int64(-5)},
+		{"alice", "bob", nil},
+		{true, false, true},
+		{"\xde\xad\x00", nil, ""},
+		{`{"a":1}`, nil, `[]`},
+		{at, nil, at - 6},
+		{day, nil, int32(1)},
+		{int32(7), nil, int32(-1)},
+		{int32(3), nil, int32(0)},
+		{1.5, nil, float64(2)},
+	}
+	chunks := meta[4].([]any)[0].(thriftStructValue)[1].([]any)
+	for i, c := range chunks {
+		cm := c.(thriftStructValue)[3].(thriftStructValue)
+		got, err := readParquetChunk(file, cm)
+		if err != nil {
+			t.Fatalf("column %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("column %s = %#v, want %#v", parquetColumns[i], got, want[i])
+		}
+	}
+}
+
+func TestParquetRowGroups(t *testing.T) {
+	rows := make([][]any, parquetRowGroupRows+1)
+	for i := range rows {
+		rows[i] = []any{int64(i)}
+	}
+	file := writeParquet(t, []string{"n"}, []string{"INT4"}, rows)
+	meta := parquetFooter(t, file)
+
+	groups := meta[4].([]any)
+	if len(groups) != 2 || meta[3] != int64(len(rows)) {
+		t.Fatalf("%d row groups with %v rows, want 2 with %d", len(groups), meta[3], len(rows))
+	}
+	var values []any
+	for i, g := range groups {
+		group := g.(thriftStructValue)
+		if want := []int64{parquetRowGroupRows, 1}[i]; group[3] != want {
+			t.Errorf("row group %d has %v rows, want %d", i, group[3], want)
+		}
+		got, err := readParquetChunk(file, group[1].([]any)[0].(thriftStructValue)[3].(thriftStructValue))
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, got...)
+	}
+	if values[0] != int32(0) || values[len(values)-1] != int32(parquetRowGroupRows) {
+		t.Errorf("first and last values = %v, %v", values[0], values[len(values)-1])
+	}
+}
+
+// parquetFooter checks the file's framing and decodes its FileMetaData.
+func parquetFooter(t *testing.T, file []byte) thriftStructValue {
+	t.Helper()
+	if !bytes.HasPrefix(file, []byte(parquetMagic)) || !bytes.HasSuffix(file, []byte(parquetMagic)) {
+		t.Fatal("missing PAR1 magic")
+	}
+	n := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footer := file[len(file)-8-n : len(file)-8]
+	meta, read, err := decodeThriftStruct(footer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != n {
+		t.Fatalf("footer is %d bytes, decoded %d", n, read)
+	}
+	return meta
+}
+
+// readParquetChunk decodes the single data page of a column chunk,
+// returning one value per row and nil for nulls.
+func readParquetChunk(file []byte, cm thriftStructValue) ([]any, error) {
+	offset := cm[9].(int64)
+	header, n, err := decodeThriftStruct(file[offset:])
+	if err != nil {
+		return nil, err
+	}
+	if header[1] != int64(parquetPageTypeData) || n+int(header[3].(int64)) != int(cm[7].(int64)) {
+		return nil, fmt.Errorf("page header %v does not fill the chunk", header)
+	}
+	dph := header[5].(thriftStructValue)
+	numValues := int(dph[1].(int64))
+	if dph[2] != int64(parquetEncodingPlain) || dph[3] != int64(parquetEncodingRLE) {
+		return nil, fmt.Errorf("data page header = %v", dph)
+	}
+	page := file[offset+int64(n) : offset+cm[7].(int64)]
+
+	// Definition levels: a length prefix and one bit-packed run of width 1.
+	levelsLen := int(binary.LittleEndian.Uint32(page))
+	levels := page[4 : 4+levelsLen]
+	run, w := binary.Uvarint(levels)
+	if run&1 != 1 || int(run>>1) != (numValues+7)/8 || w+int(run>>1) != levelsLen {
+		return nil, fmt.Errorf("bad definition levels % x", levels)
+	}
+	bits := levels[w:]
+	values := page[4+levelsLen:]
+
+	physical := cm[1].(int64)
+	out := make([]any, numValues)
+	present := 0
+	for i := range out {
+		if bits[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		switch physical {
+		case parquetBoolean:
+			out[i] = values[present/8]&(1<<(present%8)) != 0
+		case parquetInt32:
+			out[i] = int32(binary.LittleEndian.Uint32(values))
+			values = values[4:]
+		case parquetInt64:
+			out[i] = int64(binary.LittleEndian.Uint64(values))
+			values = values[8:]
+		case parquetDouble:
+			out[i] = math.Float64frombits(binary.LittleEndian.Uint64(values))
+			values = values[8:]
+		case parquetByteArray:
+			size := int(binary.LittleEndian.Uint32(values))
+			out[i] = string(values[4 : 4+size])
+			values = values[4+size:]
+		}
+		present++
+	}
+	if physical == parquetBoolean {
+		values = values[(present+7)/8:]
+	}
+	if len(values) != 0 {
+		return nil, fmt.Errorf("%d trailing bytes after values", len(values))
+	}
+	return out, nil
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sqlWriter writes one INSERT statement per row.
+type sqlWriter struct {
+	w      io.Writer
+	prefix string
+}
+
+func newSQLWriter(w io.Writer, opts Options) Writer {
+	table := opts.Table
+	if table == "" {
+		table = "export"
+	}
+	return &sqlWriter{w: w, prefix: "INSERT INTO " + quoteQualified(table)}
+}
+
+func (e *sqlWriter) WriteHeader(columns, _ []string) error {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quoteIdent(c)
+	}
+	e.prefix += " (" + strings.Join(quoted, ", ") + ") VALUES ("
+	return nil
+}
+
+func (e *sqlWriter) WriteRow(row []any) error {
+	vals := make([]string, len(row))
+	for i, v := range row {
+		vals[i] = sqlLiteral(v)
+	}
+	_, err := io.WriteString(e.w, e.prefix+strings.Join(vals, ", ")+");\n")
+	return err
+}
+
+func (e *sqlWriter) Close() error {
+	return nil
+}
+
+// sqlLiteral renders v as a PostgreSQL literal. Anything that is not a plain
+// number or boolean becomes a quoted string literal, which PostgreSQL coerces
+// to the target column's type.
+func sqlLiteral(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if t {
+			return "TRUE"
+		}
+		return "FALSE"
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case int:
+		return strconv.Itoa(t)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return quoteLiteral(strconv.FormatFloat(t, 'g', -1, 64))
+		}
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	case time.Time:
+		return quoteLiteral(t.Format("2006-01-02 15:04:05.999999Z07:00"))
+	case string:
+		return quoteLiteral(t)
+	case json.RawMessage:
+		return quoteLiteral(string(t))
+	case []any:
+		return quoteLiteral(arrayLiteral(t))
+	default:
+		return quoteLiteral(fmt.Sprintf("%v", v))
+	}
+}
+
+// arrayLiteral renders elems in PostgreSQL array input syntax, e.g. {1,"a b",NULL}.
+func arrayLiteral(elems []any) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, e := range elems {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		switch t := e.(type) {
+		case nil:
+			b.WriteString("NULL")
+		case []any:
+			b.WriteString(arrayLiteral(t))
+		case bool:
+			if t {
+				b.WriteString("t")
+			} else {
+				b.WriteString("f")
+			}
+		case int64, float64:
+			b.WriteString(formatText(t))
+		default:
+			b.WriteByte('"')
+			b.WriteString(arrayEscaper.Replace(formatText(t)))
+			b.WriteByte('"')
+		}
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var arrayEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quoteLiteral quotes s as a standard-conforming string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// quoteIdent quotes a PostgreSQL identifier.
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quoteQualified quotes each dot-separated part of a possibly schema-qualified name.
+func quoteQualified(name string) string {
+	parts := strings.SplitN(name, ".", 2)
+	for i, p := range parts {
+		parts[i] = quoteIdent(p)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,144 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	cw *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, _ Options) Writer {
+	return &csvWriter{cw: csv.NewWriter(w)}
+}
+
+func (e *csvWriter) WriteHeader(columns, _ []string) error {
+	return e.cw.Write(columns)
+}
+
+func (e *csvWriter) WriteRow(row []any) error {
+	record := make([]string, len(row))
+	for i, v := range row {
+		record[i] = formatText(v)
+	}
+	return e.cw.Write(record)
+}
+
+func (e *csvWriter) Close() error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+// jsonWriter writes a JSON array of objects keyed by column name, emitting
+// each element as soon as its row arrives. With lines set it writes
+// newline-delimited JSON instead.
+type jsonWriter struct {
+	w       io.Writer
+	enc     *json.Encoder
+	lines   bool
+	columns []string
+	n       int
+}
+
+func newJSONWriter(w io.Writer, _ Options) Writer {
+	return &jsonWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+func newNDJSONWriter(w io.Writer, _ Options) Writer {
+	return &jsonWriter{w: w, enc: json.NewEncoder(w), lines: true}
+}
+
+func (e *jsonWriter) WriteHeader(columns, _ []string) error {
+	e.columns = columns
+	if e.lines {
+		return nil
+	}
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonWriter) WriteRow(row []any) error {
+	if e.n > 0 && !e.lines {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.n++
+	record := make(map[string]any, len(row))
+	for j, v := range row {
+		if j < len(e.columns) {
+			record[e.columns[j]] = v
+		}
+	}
+	return e.enc.Encode(record)
+}
+
+func (e *jsonWriter) Close() error {
+	if e.lines {
+		return nil
+	}
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+// markdownWriter writes a GitHub-flavored Markdown table.
+type markdownWriter struct {
+	w io.Writer
+}
+
+func newMarkdownWriter(w io.Writer, _ Options) Writer {
+	return &markdownWriter{w: w}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+func (e *markdownWriter) writeLine(cells []string) error {
+	var b strings.Builder
+	b.WriteString("|")
+	for _, c := range cells {
+		b.WriteString(" ")
+		b.WriteString(c)
+		b.WriteString(" |")
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *markdownWriter) WriteHeader(columns, _ []string) error {
+	header := make([]string, len(columns))
+	sep := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = markdownEscaper.Replace(c)
+		sep[i] = "---"
+	}
+	if err := e.writeLine(header); err != nil {
+		return err
+	}
+	return e.writeLine(sep)
+}
+
+func (e *markdownWriter) WriteRow(row []any) error {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		if v == nil {
+			cells[i] = "NULL"
+			continue
+		}
+		cells[i] = markdownEscaper.Replace(formatText(v))
+	}
+	return e.writeLine(cells)
+}
+
+func (e *markdownWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package export
+
+import "encoding/binary"
+
+// Thrift compact protocol type IDs used by Parquet metadata.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter is a minimal Thrift compact-protocol encoder, just enough to
+// serialise Parquet page headers and file metadata.
+type thriftWriter struct {
+	buf    []byte
+	lastID []int16 // last field ID per open struct
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	t.buf = binary.AppendUvarint(t.buf, v)
+}
+
+func (t *thriftWriter) zigzag(v int64) {
+	t.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := t.lastID[len(t.lastID)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.zigzag(int64(id))
+	}
+	t.lastID[len(t.lastID)-1] = id
+}
+
+// beginStruct starts a nested or top-level struct.
+func (t *thriftWriter) beginStruct() {
+	t.lastID = append(t.lastID, 0)
+}
+
+// endStruct writes the stop field and closes the innermost struct.
+func (t *thriftWriter) endStruct() {
+	t.buf = append(t.buf, 0)
+	t.lastID = t.lastID[:len(t.lastID)-1]
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.zigzag(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.zigzag(v)
+}
+
+func (t *thriftWriter) str(id int16, s string) {
+	t.fieldHeader(id, thriftBinary)
+	t.varint(uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
+
+// structField starts a struct-typed field; close it with endStruct.
+func (t *thriftWriter) structField(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.beginStruct()
+}
+
+// listField writes a list header for n elements of elemType.
+func (t *thriftWriter) listField(id int16, elemType byte, n int) {
+	t.fieldHeader(id, thriftList)
+	t.listHeader(elemType, n)
+}
+
+func (t *thriftWriter) listHeader(elemType byte, n int) {
+	if n < 15 {
+		t.buf = append(t.buf, byte(n)<<4|elemType)
+		return
+	}
+	t.buf = append(t.buf, 0xf0|elemType)
+	t.varint(uint64(n))
+}
+
+// listI32 writes a bare i32 list element.
+func (t *thriftWriter) listI32(v int32) {
+	t.zigzag(int64(v))
+}
+
+// listString writes a bare string list element.
+func (t *thriftWriter) listString(s string) {
+	t.varint(uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+)
+
+func TestThriftWriter(t *testing.T) {
+	var w thriftWriter
+	w.beginStruct()
+	w.i32(1, 1)       // short header: delta 1, type i32
+	w.str(4, "ab")    // short header: delta 3, type binary
+	w.i64(3, -1)      // negative delta: long header
+	w.i64(20, 300)    // delta > 15: long header
+	w.structField(21) // nested struct; field IDs restart at 0
+	w.i32(1, -2)
+	w.endStruct()
+	w.listField(22, thriftI32, 2)
+	w.listI32(0)
+	w.listI32(3)
+	w.listField(23, thriftBinary, 15) // 15 or more elements: long list header
+	for range 15 {
+		w.listString("")
+	}
+	w.endStruct()
+
+	want := []byte{
+		0x15, 0x02,
+		0x38, 0x02, 'a', 'b',
+		0x06, 0x06, 0x01,
+		0x06, 0x28, 0xd8, 0x04,
+		0x1c,
+		0x15, 0x03,
+		0x00,
+		0x19, 0x25, 0x00, 0x06,
+		0x19, 0xf8, 0x0f,
+	}
+	want = append(want, make([]byte, 15)...)
+	want = append(want, 0x00)
+	if !bytes.Equal(w.buf, want) {
+		t.Fatalf("encoded\n got % x\nwant % x", w.buf, want)
+	}
+
+	got, n, err := decodeThriftStruct(w.buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(w.buf) {
+		t.Errorf("decoded %d of %d bytes", n, len(w.buf))
+	}
+	if got[1] != int64(1) || got[4] != "ab" || got[3] != int64(-1) || got[20] != int64(300) {
+		t.Errorf("decoded fields = %v", got)
+	}
+	if nested := got[21].(thriftStructValue); nested[1] != int64(-2) {
+		t.Errorf("nested struct = %v", nested)
+	}
+	if list := got[22].([]any); len(list) != 2 || list[1] != int64(3) {
+		t.Errorf("i32 list = %v", list)
+	}
+	if list := got[23].([]any); len(list) != 15 {
+		t.Errorf("binary list has %d elements, want 15", len(list))
+	}
+}
+
+// thriftStructValue is a decoded Thrift struct keyed by field ID. Integers
+// decode as int64, binary fields as string, lists as []any.
+type thriftStructValue map[int16]any
+
+// decodeThriftStruct decodes a compact-protocol struct from the start of buf
+// and returns it with the number of bytes read. It is an independent reader
+// for checking thriftWriter output, not a general-purpose decoder.
+func decodeThriftStruct(buf []byte) (thriftStructValue, int, error) {
+	d := thriftDecoder{buf: buf}
+	s, err := d.structValue()
+	return s, d.pos, err
+}
+
+type thriftDecoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *thriftDecoder) byte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, fmt.Errorf("unexpected end of input at %d", d.pos)
+	}
+	d.pos++
+	return d.buf[d.pos-1], nil
+}
+
+func (d *thriftDecoder) varint() (uint64, error) {
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("bad varint at %d", d.pos)
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *thriftDecoder) zigzag() (int64, error) {
+	v, err := d.varint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (d *thriftDecoder) structValue() (thriftStructValue, error) {
+	s := make(thriftStructValue)
+	var last int16
+	for {
+		h, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if h == 0 {
+			return s, nil
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			n, err := d.zigzag()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(n)
+		}
+		if s[id], err = d.value(h & 0x0f); err != nil {
+			return nil, fmt.Errorf("field %d: %w", id, err)
+		}
+		last = id
+	}
+}
+
+func (d *thriftDecoder) value(typ byte) (any, error) {
+	switch typ {
+	case thriftI32, thriftI64:
+		return d.zigzag()
+	case thriftBinary:
+		n, err := d.varint()
+		if err != nil {
+			return nil, err
+		}
+		if d.pos+int(n) > len(d.buf) {
+			return nil, fmt.Errorf("binary of length %d overruns input", n)
+		}
+		d.pos += int(n)
+		return string(d.buf[d.pos-int(n) : d.pos]), nil
+	case thriftList:
+		h, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(h >> 4)
+		if n == 15 {
+			if n, err = d.varint(); err != nil {
+				return nil, err
+			}
+		}
+		list := make([]any, n)
+		for i := range list {
+			if list[i], err = d.value(h & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case thriftStruct:
+		return d.structValue()
+	}
+	return nil, fmt.Errorf("unsupported type %d", typ)
+}
@@ -0,0 +1,200 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xlsxWriter streams an Office Open XML workbook. Rows are written straight
+// into the sheet's zip entry using inline strings, so no shared-string table
+// has to be held in memory. A sheet holds at most maxRows rows, header
+// included; further rows continue on a new sheet that repeats the header.
+type xlsxWriter struct {
+	zw      *zip.Writer
+	sheet   io.Writer
+	sheets  int
+	header  []any
+	row     int
+	maxRows int
+	buf     bytes.Buffer
+}
+
+const (
+	xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border/></borders>` +
+		`<cellStyleXfs count="1"><xf/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf/><xf fontId="1" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`
+	xlsxSheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd   = `</sheetData></worksheet>`
+
+	// xlsxHeaderStyle is the cellXfs index of the bold header style.
+	xlsxHeaderStyle = 1
+
+	// xlsxMaxExactInt is the largest integer a double represents exactly.
+	xlsxMaxExactInt = 1 << 53
+
+	// xlsxMaxRows is the most rows a sheet may have in Excel.
+	xlsxMaxRows = 1 << 20
+)
+
+func newXLSXWriter(w io.Writer, _ Options) Writer {
+	return &xlsxWriter{zw: zip.NewWriter(w), maxRows: xlsxMaxRows}
+}
+
+func (e *xlsxWriter) WriteHeader(columns, _ []string) error {
+	e.header = make([]any, len(columns))
+	for i, c := range columns {
+		e.header[i] = c
+	}
+	return e.nextSheet()
+}
+
+// nextSheet ends the current sheet, if any, and starts the next one with the
+// header row.
+func (e *xlsxWriter) nextSheet() error {
+	if e.sheet != nil {
+		if _, err := io.WriteString(e.sheet, xlsxSheetEnd); err != nil {
+			return err
+		}
+	}
+	e.sheets++
+	sheet, err := e.zw.Create("xl/worksheets/sheet" + strconv.Itoa(e.sheets) + ".xml")
+	if err != nil {
+		return err
+	}
+	e.sheet = sheet
+	e.row = 0
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return err
+	}
+	return e.writeRow(e.header, xlsxHeaderStyle)
+}
+
+func (e *xlsxWriter) WriteRow(row []any) error {
+	if e.row >= e.maxRows {
+		if err := e.nextSheet(); err != nil {
+			return err
+		}
+	}
+	return e.writeRow(row, 0)
+}
+
+func (e *xlsxWriter) writeRow(row []any, style int) error {
+	e.row++
+	e.buf.Reset()
+	rowNum := strconv.Itoa(e.row)
+	e.buf.WriteString(`<row r="` + rowNum + `">`)
+	for i, v := range row {
+		if v == nil {
+			continue
+		}
+		e.buf.WriteString(`<c r="` + xlsxColumn(i) + rowNum + `"`)
+		if style != 0 {
+			e.buf.WriteString(` s="` + strconv.Itoa(style) + `"`)
+		}
+		switch t := v.(type) {
+		case bool:
+			b := "0"
+			if t {
+				b = "1"
+			}
+			e.buf.WriteString(` t="b"><v>` + b + `</v></c>`)
+		case int64:
+			// Spreadsheets hold numbers as doubles; larger integers would
+			// silently lose precision, so they are written as text.
+			if t > xlsxMaxExactInt || t < -xlsxMaxExactInt {
+				e.inlineString(strconv.FormatInt(t, 10))
+			} else {
+				e.buf.WriteString(`><v>` + strconv.FormatInt(t, 10) + `</v></c>`)
+			}
+		case int:
+			e.buf.WriteString(`><v>` + strconv.Itoa(t) + `</v></c>`)
+		case float64:
+			e.buf.WriteString(`><v>` + strconv.FormatFloat(t, 'g', -1, 64) + `</v></c>`)
+		case time.Time:
+			e.inlineString(t.Format("2006-01-02 15:04:05.999999Z07:00"))
+		default:
+			e.inlineString(formatText(v))
+		}
+	}
+	e.buf.WriteString(`</row>`)
+	_, err := e.sheet.Write(e.buf.Bytes())
+	return err
+}
+
+func (e *xlsxWriter) inlineString(s string) {
+	e.buf.WriteString(` t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(&e.buf, []byte(s))
+	e.buf.WriteString(`</t></is></c>`)
+}
+
+// Close ends the last sheet and writes the workbook parts that list the
+// sheets, now that their number is known.
+func (e *xlsxWriter) Close() error {
+	if e.sheet == nil {
+		return e.zw.Close()
+	}
+	if _, err := io.WriteString(e.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	var types, sheets, rels strings.Builder
+	types.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	rels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= e.sheets; i++ {
+		n := strconv.Itoa(i)
+		name := "Export"
+		if i > 1 {
+			name += " " + n
+		}
+		types.WriteString(`<Override PartName="/xl/worksheets/sheet` + n + `.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`)
+		sheets.WriteString(`<sheet name="` + name + `" sheetId="` + n + `" r:id="rId` + n + `"/>`)
+		rels.WriteString(`<Relationship Id="rId` + n + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet` + n + `.xml"/>`)
+	}
+	types.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`)
+	rels.WriteString(`<Relationship Id="rId` + strconv.Itoa(e.sheets+1) + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`)
+
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", types.String()},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	} {
+		f, err := e.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+	return e.zw.Close()
+}
+
+// xlsxColumn converts a zero-based column index to a spreadsheet column
+// name: 0 → A, 25 → Z, 26 → AA.
+func xlsxColumn(i int) string {
+	var name []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = append([]byte{byte('A' + (i-1)%26)}, name...)
+	}
+	return string(name)
+}
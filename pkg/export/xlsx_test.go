@@ -0,0 +1,147 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	w := newXLSXWriter(&buf, Options{})
+	if err := w.WriteHeader([]string{"id", "name", "ok", "ratio", "at", "doc"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]any{
+		{int64(1), "a < b & c", true, 1.5, time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC), json.RawMessage(`{"a":1}`)},
+		{int64(1 << 53), nil, false, float64(-2), "2024-01-02", []any{int64(1), "x"}},
+		{int64(1<<53 + 1), "  padded  ", nil, nil, nil, nil},
+		{int64(-1<<53 - 1), nil, nil, nil, nil, nil},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	names, parts := readZip(t, buf.Bytes())
+	wantNames := []string{
+		"xl/worksheets/sheet1.xml", "[Content_Types].xml", "_rels/.rels",
+		"xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("zip entries = %q, want %q", names, wantNames)
+	}
+
+	str := func(ref, s string) string {
+		return `<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">` + s + `</t></is></c>`
+	}
+	hdr := func(ref, s string) string {
+		return `<c r="` + ref + `" s="1" t="inlineStr"><is><t xml:space="preserve">` + s + `</t></is></c>`
+	}
+	want := xlsxSheetStart +
+		`<row r="1">` + hdr("A1", "id") + hdr("B1", "name") + hdr("C1", "ok") + hdr("D1", "ratio") + hdr("E1", "at") + hdr("F1", "doc") + `</row>` +
+		`<row r="2"><c r="A2"><v>1</v></c>` + str("B2", "a &lt; b &amp; c") + `<c r="C2" t="b"><v>1</v></c><c r="D2"><v>1.5</v></c>` +
+		str("E2", "2024-01-02 03:04:05.000006Z") + str("F2", `{&#34;a&#34;:1}`) + `</row>` +
+		`<row r="3"><c r="A3"><v>9007199254740992</v></c><c r="C3" t="b"><v>0</v></c><c r="D3"><v>-2</v></c>` +
+		str("E3", "2024-01-02") + str("F3", `[1,&#34;x&#34;]`) + `</row>` +
+		`<row r="4">` + str("A4", "9007199254740993") + str("B4", "  padded  ") + `</row>` +
+		`<row r="5">` + str("A5", "-9007199254740993") + `</row>` +
+		xlsxSheetEnd
+	if got := parts["xl/worksheets/sheet1.xml"]; got != want {
+		t.Errorf("sheet1.xml\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestXLSXSheets(t *testing.T) {
+	var buf bytes.Buffer
+	w := newXLSXWriter(&buf, Options{}).(*xlsxWriter)
+	w.maxRows = 3
+	if err := w.WriteHeader([]string{"n"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	for i := range 5 {
+		if err := w.WriteRow([]any{int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	_, parts := readZip(t, buf.Bytes())
+
+	// Each sheet starts with the header, then holds up to two rows.
+	hdr := `<row r="1"><c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">n</t></is></c></row>`
+	num := func(row, v string) string {
+		return `<row r="` + row + `"><c r="A` + row + `"><v>` + v + `</v></c></row>`
+	}
+	wantSheets := map[string]string{
+		"xl/worksheets/sheet1.xml": hdr + num("2", "0") + num("3", "1"),
+		"xl/worksheets/sheet2.xml": hdr + num("2", "2") + num("3", "3"),
+		"xl/worksheets/sheet3.xml": hdr + num("2", "4"),
+	}
+	for name, rows := range wantSheets {
+		if got, want := parts[name], xlsxSheetStart+rows+xlsxSheetEnd; got != want {
+			t.Errorf("%s\n got %s\nwant %s", name, got, want)
+		}
+	}
+
+	wantWorkbook := `<sheets><sheet name="Export" sheetId="1" r:id="rId1"/>` +
+		`<sheet name="Export 2" sheetId="2" r:id="rId2"/><sheet name="Export 3" sheetId="3" r:id="rId3"/></sheets>`
+	if !strings.Contains(parts["xl/workbook.xml"], wantWorkbook) {
+		t.Errorf("workbook.xml = %s", parts["xl/workbook.xml"])
+	}
+	wantRels := []string{
+		`Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet3.xml"`,
+		`Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"`,
+	}
+	for _, want := range wantRels {
+		if !strings.Contains(parts["xl/_rels/workbook.xml.rels"], want) {
+			t.Errorf("workbook.xml.rels lacks %s", want)
+		}
+	}
+	if !strings.Contains(parts["[Content_Types].xml"], `PartName="/xl/worksheets/sheet3.xml"`) {
+		t.Errorf("[Content_Types].xml lacks sheet3: %s", parts["[Content_Types].xml"])
+	}
+}
+
+// readZip returns the entry names of a zip file in order, and their contents.
+func readZip(t *testing.T, data []byte) ([]string, map[string]string) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[f.Name] = string(b)
+	}
+	return names, parts
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// ErrProfileNotFound is returned for a connection profile ID that does not
+// exist.
+var ErrProfileNotFound = errors.New("connection profile not found")
+
+// ConnectionProfile is a named, persisted set of connection parameters.
+// Password holds the plaintext password in memory only; on disk it is stored
+// AES-GCM encrypted in EncryptedPassword.
+type ConnectionProfile struct {
+	ID                string `json:"id"`
+	Label             string `json:"label"`
+	Color             string `json:"color"`
+	Host              string `json:"host"`
+	Port              int    `json:"port"`
+	User              string `json:"user"`
+	Database          string `json:"database"`
+	SSLMode           string `json:"sslmode"`
+	ReadOnly          bool   `json:"read_only"`
+	Password          string `json:"-"`
+	EncryptedPassword string `json:"password_enc,omitempty"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+// HasPassword reports whether a password is stored for the profile.
+func (p ConnectionProfile) HasPassword() bool {
+	return p.EncryptedPassword != ""
+}
+
+// ListConnectionProfiles returns all profiles sorted by label. Passwords are
+// not decrypted.
+func (r *Repository) ListConnectionProfiles() ([]ConnectionProfile, error) {
+	var result []ConnectionProfile
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketConnections).ForEach(func(k, v []byte) error {
+			var p ConnectionProfile
+			if err := json.Unmarshal(v, &p); err != nil {
+				return nil // skip corrupt entries
+			}
+			result = append(result, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list connection profiles: %w", err)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Label < result[j].Label
+	})
+	if result == nil {
+		result = []ConnectionProfile{}
+	}
+	return result, nil
+}
+
+// GetConnectionProfile returns the profile with its password decrypted.
+func (r *Repository) GetConnectionProfile(id string) (*ConnectionProfile, error) {
+	var p ConnectionProfile
+	err := r.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketConnections).Get([]byte(id))
+		if v == nil {
+			return fmt.Errorf("%w: %s", ErrProfileNotFound, id)
+		}
+		return json.Unmarshal(v, &p)
+	})
+	if err != nil {
+		return nil, err
+	}
+	password, err := r.decryptString(p.EncryptedPassword)
+	if err != nil {
+		return nil, fmt.Errorf("connection profile %s: %w", id, err)
+	}
+	p.Password = password
+	return &p, nil
+}
+
+func (r *Repository) CreateConnectionProfile(p ConnectionProfile) (*ConnectionProfile, error) {
+	enc, err := r.encryptString(p.Password)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt password: %w", err)
+	}
+	p.ID = newID()
+	p.EncryptedPassword = enc
+	now := nowUTC()
+	p.CreatedAt = now
+	p.UpdatedAt = now
+
+	err = r.db.Update(func(tx *bolt.Tx) error {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(bucketConnections).Put([]byte(p.ID), data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create connection profile: %w", err)
+	}
+	return &p, nil
+}
+
+// UpdateConnectionProfile overwrites the profile's fields. The stored password
+// is only replaced when setPassword is true.
+func (r *Repository) UpdateConnectionProfile(p ConnectionProfile, setPassword bool) error {
+	var enc string
+	if setPassword {
+		var err error
+		if enc, err = r.encryptString(p.Password); err != nil {
+			return fmt.Errorf("encrypt password: %w", err)
+		}
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketConnections)
+		v := b.Get([]byte(p.ID))
+		if v == nil {
+			return fmt.Errorf("%w: %s", ErrProfileNotFound, p.ID)
+		}
+		var existing ConnectionProfile
+		if err := json.Unmarshal(v, &existing); err != nil {
+			return err
+		}
+		existing.Label = p.Label
+		existing.Color = p.Color
+		existing.Host = p.Host
+		existing.Port = p.Port
+		existing.User = p.User
+		existing.Database = p.Database
+		existing.SSLMode = p.SSLMode
+		existing.ReadOnly = p.ReadOnly
+		if setPassword {
+			existing.EncryptedPassword = enc
+		}
+		existing.UpdatedAt = nowUTC()
+
+		data, err := json.Marshal(existing)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(p.ID), data)
+	})
+}
+
+func (r *Repository) DeleteConnectionProfile(id string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketConnections)
+		if b.Get([]byte(id)) == nil {
+			return fmt.Errorf("%w: %s", ErrProfileNotFound, id)
+		}
+		return b.Delete([]byte(id))
+	})
+}
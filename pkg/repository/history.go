@@ -11,6 +11,7 @@ import (
 type HistoryEntry struct {
 	ID         int    `json:"id"`
 	SQL        string `json:"sql"`
+	Connection string `json:"connection"`
 	Database   string `json:"database"`
 	DurationMs int64  `json:"duration_ms"`
 	RowCount   int    `json:"row_count"`
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// maxUnpinnedPlans is how many unpinned plans are kept; older ones are
+// dropped as new plans are recorded.
+const maxUnpinnedPlans = 100
+
+// ErrPlanNotFound is returned for a plan ID that does not exist.
+var ErrPlanNotFound = errors.New("plan not found")
+
+// StoredPlan is an EXPLAIN (FORMAT JSON) result kept for later comparison.
+type StoredPlan struct {
+	ID         int             `json:"id"`
+	SQL        string          `json:"sql"`
+	Connection string          `json:"connection"`
+	Database   string          `json:"database"`
+	Analyzed   bool            `json:"analyzed"`
+	Plan       json.RawMessage `json:"plan"` // raw EXPLAIN output
+	Pinned     bool            `json:"pinned"`
+	Label      string          `json:"label"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// AddPlan records a plan and returns its ID. The oldest unpinned plans are
+// dropped beyond maxUnpinnedPlans.
+func (r *Repository) AddPlan(p StoredPlan) (int, error) {
+	p.CreatedAt = nowUTC()
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketPlans)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		p.ID = int(seq)
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(seq), data); err != nil {
+			return err
+		}
+		return trimPlans(tx)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("add plan: %w", err)
+	}
+	return p.ID, nil
+}
+
+// trimPlans deletes the oldest unpinned plans beyond maxUnpinnedPlans. Pinned
+// plans are told apart by the pinned index, so no plan has to be decoded.
+func trimPlans(tx *bolt.Tx) error {
+	b, pinned := tx.Bucket(bucketPlans), tx.Bucket(bucketPinnedPlans)
+	var unpinned [][]byte
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if pinned.Get(k) == nil {
+			unpinned = append(unpinned, k)
+		}
+	}
+	for i := 0; i < len(unpinned)-maxUnpinnedPlans; i++ {
+		if err := b.Delete(unpinned[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexPinnedPlans creates the pinned index from the stored plans, for
+// databases written before the index existed.
+func indexPinnedPlans(tx *bolt.Tx) error {
+	pinned, err := tx.CreateBucket(bucketPinnedPlans)
+	if err != nil {
+		return err
+	}
+	b := tx.Bucket(bucketPlans)
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(func(k, v []byte) error {
+		var p StoredPlan
+		if err := json.Unmarshal(v, &p); err != nil || !p.Pinned {
+			return nil
+		}
+		return pinned.Put(k, nil)
+	})
+}
+
+// ListPlans returns stored plans, newest first, optionally only pinned ones.
+func (r *Repository) ListPlans(pinnedOnly bool) ([]StoredPlan, error) {
+	var result []StoredPlan
+	err := r.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketPlans)
+		var c *bolt.Cursor
+		if pinnedOnly {
+			c = tx.Bucket(bucketPinnedPlans).Cursor()
+		} else {
+			c = b.Cursor()
+		}
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			var p StoredPlan
+			if err := json.Unmarshal(b.Get(k), &p); err != nil {
+				continue
+			}
+			result = append(result, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list plans: %w", err)
+	}
+	if result == nil {
+		result = []StoredPlan{}
+	}
+	return result, nil
+}
+
+func (r *Repository) GetPlan(id int) (*StoredPlan, error) {
+	var p StoredPlan
+	err := r.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketPlans).Get(itob(uint64(id)))
+		if v == nil {
+			return fmt.Errorf("%w: %d", ErrPlanNotFound, id)
+		}
+		return json.Unmarshal(v, &p)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// PinPlan sets whether a plan is pinned, and its label. Pinned plans are
+// never dropped automatically.
+func (r *Repository) PinPlan(id int, pinned bool, label string) (*StoredPlan, error) {
+	var p StoredPlan
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketPlans)
+		v := b.Get(itob(uint64(id)))
+		if v == nil {
+			return fmt.Errorf("%w: %d", ErrPlanNotFound, id)
+		}
+		if err := json.Unmarshal(v, &p); err != nil {
+			return err
+		}
+		p.Pinned, p.Label = pinned, label
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		key := itob(uint64(id))
+		if err := b.Put(key, data); err != nil {
+			return err
+		}
+		index := tx.Bucket(bucketPinnedPlans)
+		if pinned {
+			err = index.Put(key, nil)
+		} else {
+			err = index.Delete(key)
+		}
+		if err != nil {
+			return err
+		}
+		return trimPlans(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *Repository) DeletePlan(id int) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		key := itob(uint64(id))
+		if err := tx.Bucket(bucketPinnedPlans).Delete(key); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketPlans).Delete(key)
+	})
+}
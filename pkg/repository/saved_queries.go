@@ -21,6 +21,16 @@ type SavedQuery struct {
 	Shared      bool   `json:"shared"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
+
+	Params []QueryParam `json:"params,omitempty"`
+}
+
+// QueryParam declares a bind parameter of a saved query, e.g. the shared
+// query file header "-- @param since timestamptz now() - interval '1 day'".
+type QueryParam struct {
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Default string `json:"default,omitempty"`
 }
 
 func newID() string {
@@ -110,6 +120,7 @@ func (r *Repository) UpdateSavedQuery(q SavedQuery) error {
 		existing.SQL = q.SQL
 		existing.Database = q.Database
 		existing.Tags = q.Tags
+		existing.Params = q.Params
 		existing.UpdatedAt = nowUTC()
 
 		data, err := json.Marshal(existing)
@@ -153,7 +164,7 @@ func (r *Repository) ListSharedQueries() ([]SavedQuery, error) {
 	return result, nil
 }
 
-func (r *Repository) UpsertSharedQuery(title, sql, description, database, tags string) error {
+func (r *Repository) UpsertSharedQuery(title, sql, description, database, tags string, params []QueryParam) error {
 	return r.db.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket(bucketSavedQueries)
 
@@ -181,6 +192,7 @@ func (r *Repository) UpsertSharedQuery(title, sql, description, database, tags s
 			q.Description = description
 			q.Database = database
 			q.Tags = tags
+			q.Params = params
 			q.UpdatedAt = now
 			data, err := json.Marshal(q)
 			if err != nil {
@@ -196,6 +208,7 @@ func (r *Repository) UpsertSharedQuery(title, sql, description, database, tags s
 			SQL:         sql,
 			Database:    database,
 			Tags:        tags,
+			Params:      params,
 			Shared:      true,
 			CreatedAt:   now,
 			UpdatedAt:   now,
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// maxSchemaSnapshots is how many schema snapshots are kept; the oldest are
+// dropped as new ones are taken.
+const maxSchemaSnapshots = 50
+
+// SchemaSnapshot is the structure of every schema of one database at a
+// point in time.
+type SchemaSnapshot struct {
+	ID         int      `json:"id"`
+	Connection string   `json:"connection"`
+	Database   string   `json:"database"`
+	Label      string   `json:"label"`
+	Schemas    []string `json:"schemas"`
+	// PreviousID is the snapshot of the same connection and database taken
+	// before this one, 0 if there is none, and Changes the number of
+	// differences from it.
+	PreviousID int `json:"previous_id,omitempty"`
+	Changes    int `json:"changes"`
+	// Catalogs is the JSON-encoded catalog of each schema, as the service
+	// layer reads it.
+	Catalogs  json.RawMessage `json:"catalogs,omitempty"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// AddSchemaSnapshot stores a snapshot and returns it with its ID and
+// creation time set. The oldest snapshots are dropped beyond
+// maxSchemaSnapshots.
+func (r *Repository) AddSchemaSnapshot(s SchemaSnapshot) (*SchemaSnapshot, error) {
+	s.CreatedAt = nowUTC()
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketSchemaSnapshots)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		s.ID = int(seq)
+		data, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(seq), data); err != nil {
+			return err
+		}
+		var keys [][]byte
+		b.ForEach(func(k, _ []byte) error {
+			keys = append(keys, k)
+			return nil
+		})
+		for i := 0; i < len(keys)-maxSchemaSnapshots; i++ {
+			if err := b.Delete(keys[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add schema snapshot: %w", err)
+	}
+	return &s, nil
+}
+
+// ListSchemaSnapshots returns snapshots newest first, without their
+// catalogs.
+func (r *Repository) ListSchemaSnapshots() ([]SchemaSnapshot, error) {
+	result := []SchemaSnapshot{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketSchemaSnapshots).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var s SchemaSnapshot
+			if err := json.Unmarshal(v, &s); err != nil {
+				continue
+			}
+			s.Catalogs = nil
+			result = append(result, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list schema snapshots: %w", err)
+	}
+	return result, nil
+}
+
+func (r *Repository) GetSchemaSnapshot(id int) (*SchemaSnapshot, error) {
+	var s SchemaSnapshot
+	err := r.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketSchemaSnapshots).Get(itob(uint64(id)))
+		if v == nil {
+			return fmt.Errorf("schema snapshot not found: %d", id)
+		}
+		return json.Unmarshal(v, &s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// LatestSchemaSnapshot returns the newest snapshot of a connection's
+// database with its catalogs, or nil if there is none.
+func (r *Repository) LatestSchemaSnapshot(connection, database string) (*SchemaSnapshot, error) {
+	var latest *SchemaSnapshot
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketSchemaSnapshots).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var s SchemaSnapshot
+			if err := json.Unmarshal(v, &s); err != nil {
+				continue
+			}
+			if s.Connection == connection && s.Database == database {
+				latest = &s
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("latest schema snapshot: %w", err)
+	}
+	return latest, nil
+}
+
+func (r *Repository) DeleteSchemaSnapshot(id int) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSchemaSnapshots).Delete(itob(uint64(id)))
+	})
+}
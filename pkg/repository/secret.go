@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	secretFileName = "secret.key"
+	secretSize     = 32
+	keyInfo        = "pglet connection passwords"
+)
+
+// loadSecretKey reads the local secret file at path, creating it with random
+// contents on first use, and derives the AES-256 key used for passwords.
+func loadSecretKey(path string) ([]byte, error) {
+	secret, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		secret = make([]byte, secretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("generate secret: %w", err)
+		}
+		if err := os.WriteFile(path, secret, 0600); err != nil {
+			return nil, fmt.Errorf("write secret: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("read secret: %w", err)
+	}
+	if len(secret) < secretSize {
+		return nil, fmt.Errorf("secret file %s is too short", path)
+	}
+	return hkdf.Key(sha256.New, secret, nil, keyInfo, 32)
+}
+
+// encryptString seals plaintext with AES-GCM and returns base64(nonce || ciphertext).
+func (r *Repository) encryptString(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	gcm, err := r.gcm()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptString reverses encryptString.
+func (r *Repository) decryptString(encoded string) (string, error) {
+	if encoded == "" {
+		return "", nil
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("decode ciphertext: %w", err)
+	}
+	gcm, err := r.gcm()
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func (r *Repository) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(r.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
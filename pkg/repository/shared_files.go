@@ -37,6 +37,9 @@ func (r *Repository) ExportSharedQueries(dir string) error {
 		if q.Tags != "" {
 			b.WriteString(fmt.Sprintf("-- @tags: %s\n", q.Tags))
 		}
+		for _, p := range q.Params {
+			b.WriteString(strings.TrimRight(fmt.Sprintf("-- @param %s %s %s", p.Name, p.Type, p.Default), " ") + "\n")
+		}
 		b.WriteString("\n")
 		b.WriteString(q.SQL)
 		if !strings.HasSuffix(q.SQL, "\n") {
@@ -76,19 +79,19 @@ func (r *Repository) ImportSharedQueries(dir string) error {
 			continue
 		}
 
-		title, description, database, tags, sql := parseSharedQueryFile(content)
+		title, description, database, tags, params, sql := parseSharedQueryFile(content)
 		if title == "" || sql == "" {
 			continue
 		}
 
-		if err := r.UpsertSharedQuery(title, sql, description, database, tags); err != nil {
+		if err := r.UpsertSharedQuery(title, sql, description, database, tags, params); err != nil {
 			return fmt.Errorf("import %s: %w", entry.Name(), err)
 		}
 	}
 	return nil
 }
 
-func parseSharedQueryFile(content string) (title, description, database, tags, sql string) {
+func parseSharedQueryFile(content string) (title, description, database, tags string, params []QueryParam, sql string) {
 	lines := strings.Split(content, "\n")
 	headerDone := false
 	var sqlLines []string
@@ -111,6 +114,10 @@ func parseSharedQueryFile(content string) (title, description, database, tags, s
 				}
 			} else if strings.HasPrefix(trimmed, "-- @tags:") {
 				tags = strings.TrimSpace(strings.TrimPrefix(trimmed, "-- @tags:"))
+			} else if strings.HasPrefix(trimmed, "-- @param ") {
+				if p, ok := parseParamHeader(strings.TrimPrefix(trimmed, "-- @param ")); ok {
+					params = append(params, p)
+				}
 			} else if !strings.HasPrefix(trimmed, "--") {
 				headerDone = true
 				sqlLines = append(sqlLines, line)
@@ -124,6 +131,21 @@ func parseSharedQueryFile(content string) (title, description, database, tags, s
 	return
 }
 
+// parseParamHeader parses the "name type default" part of a "-- @param"
+// header. The type is a single word (e.g. timestamptz rather than timestamp
+// with time zone) and optional when there is no default; the default is the
+// rest of the line, so it may contain spaces.
+func parseParamHeader(s string) (QueryParam, bool) {
+	name, rest, _ := strings.Cut(strings.TrimSpace(s), " ")
+	typ, def, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	p := QueryParam{
+		Name:    strings.TrimPrefix(name, ":"),
+		Type:    typ,
+		Default: strings.TrimSpace(def),
+	}
+	return p, p.Name != ""
+}
+
 func sanitizeFilename(s string) string {
 	// Replace non-alphanumeric chars with underscores
 	var b strings.Builder
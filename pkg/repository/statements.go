@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// maxStatementSnapshots is how many pg_stat_statements snapshots are kept;
+// the oldest are dropped as new ones are taken.
+const maxStatementSnapshots = 20
+
+// StatementSnapshot is the pg_stat_statements counters of one database at a
+// point in time.
+type StatementSnapshot struct {
+	ID         int                 `json:"id"`
+	Connection string              `json:"connection"`
+	Database   string              `json:"database"`
+	Label      string              `json:"label"`
+	Statements []StatementCounters `json:"statements,omitempty"`
+	CreatedAt  string              `json:"created_at"`
+}
+
+// StatementCounters is one normalized query's counters in a snapshot.
+type StatementCounters struct {
+	QueryID        int64   `json:"queryid"`
+	User           string  `json:"user"`
+	Query          string  `json:"query"`
+	Calls          int64   `json:"calls"`
+	TotalMs        float64 `json:"total_ms"`
+	Rows           int64   `json:"rows"`
+	SharedBlksHit  int64   `json:"shared_blks_hit"`
+	SharedBlksRead int64   `json:"shared_blks_read"`
+}
+
+// AddStatementSnapshot stores a snapshot and returns it with its ID and
+// creation time set. The oldest snapshots are dropped beyond
+// maxStatementSnapshots.
+func (r *Repository) AddStatementSnapshot(s StatementSnapshot) (*StatementSnapshot, error) {
+	s.CreatedAt = nowUTC()
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketStatements)
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		s.ID = int(seq)
+		data, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(itob(seq), data); err != nil {
+			return err
+		}
+		var keys [][]byte
+		b.ForEach(func(k, _ []byte) error {
+			keys = append(keys, k)
+			return nil
+		})
+		for i := 0; i < len(keys)-maxStatementSnapshots; i++ {
+			if err := b.Delete(keys[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add statement snapshot: %w", err)
+	}
+	return &s, nil
+}
+
+// ListStatementSnapshots returns snapshots newest first, without their
+// statements.
+func (r *Repository) ListStatementSnapshots() ([]StatementSnapshot, error) {
+	result := []StatementSnapshot{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketStatements).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var s StatementSnapshot
+			if err := json.Unmarshal(v, &s); err != nil {
+				continue
+			}
+			s.Statements = nil
+			result = append(result, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list statement snapshots: %w", err)
+	}
+	return result, nil
+}
+
+func (r *Repository) GetStatementSnapshot(id int) (*StatementSnapshot, error) {
+	var s StatementSnapshot
+	err := r.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketStatements).Get(itob(uint64(id)))
+		if v == nil {
+			return fmt.Errorf("statement snapshot not found: %d", id)
+		}
+		return json.Unmarshal(v, &s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *Repository) DeleteStatementSnapshot(id int) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketStatements).Delete(itob(uint64(id)))
+	})
+}
@@ -9,13 +9,19 @@ import (
 )
 
 var (
-	bucketSavedQueries = []byte("saved_queries")
-	bucketHistory      = []byte("history")
-	bucketTabs         = []byte("tabs")
+	bucketSavedQueries    = []byte("saved_queries")
+	bucketHistory         = []byte("history")
+	bucketTabs            = []byte("tabs")
+	bucketConnections     = []byte("connections")
+	bucketPlans           = []byte("plans")
+	bucketPinnedPlans     = []byte("pinned_plans")
+	bucketStatements      = []byte("statement_snapshots")
+	bucketSchemaSnapshots = []byte("schema_snapshots")
 )
 
 type Repository struct {
-	db *bolt.DB
+	db  *bolt.DB
+	key []byte // derived from the local secret file, encrypts stored passwords
 }
 
 func Open(path string) (*Repository, error) {
@@ -24,6 +30,11 @@ func Open(path string) (*Repository, error) {
 		return nil, fmt.Errorf("create repository dir: %w", err)
 	}
 
+	key, err := loadSecretKey(filepath.Join(dir, secretFileName))
+	if err != nil {
+		return nil, err
+	}
+
 	db, err := bolt.Open(path, 0600, nil)
 	if err != nil {
 		return nil, fmt.Errorf("open bbolt: %w", err)
@@ -31,7 +42,12 @@ func Open(path string) (*Repository, error) {
 
 	// Ensure buckets exist
 	err = db.Update(func(tx *bolt.Tx) error {
-		for _, b := range [][]byte{bucketSavedQueries, bucketHistory, bucketTabs} {
+		if tx.Bucket(bucketPinnedPlans) == nil {
+			if err := indexPinnedPlans(tx); err != nil {
+				return err
+			}
+		}
+		for _, b := range [][]byte{bucketSavedQueries, bucketHistory, bucketTabs, bucketConnections, bucketPlans, bucketStatements, bucketSchemaSnapshots} {
 			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
 				return err
 			}
@@ -43,7 +59,7 @@ func Open(path string) (*Repository, error) {
 		return nil, fmt.Errorf("init buckets: %w", err)
 	}
 
-	return &Repository{db: db}, nil
+	return &Repository{db: db, key: key}, nil
 }
 
 func (r *Repository) Close() error {
@@ -8,24 +8,24 @@ import (
 	"github.com/macleodmac/pglet/pkg/ai"
 )
 
-func (s *Service) GenerateSQL(ctx context.Context, prompt string, messages []ai.Message) (sql, explanation string, err error) {
+func (s *Service) GenerateSQL(ctx context.Context, connID, prompt string, messages []ai.Message) (sql, explanation string, err error) {
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
 	if apiKey == "" {
 		return "", "", ErrNoAPIKey
 	}
 
-	schema := s.buildSchema()
+	schema := s.buildSchema(connID)
 	aiClient := ai.NewClient(apiKey)
 	return aiClient.GenerateSQL(ctx, schema, prompt, messages)
 }
 
-func (s *Service) AISuggestions(ctx context.Context) ([]string, error) {
+func (s *Service) AISuggestions(ctx context.Context, connID string) ([]string, error) {
 	apiKey := os.Getenv("ANTHROPIC_API_KEY")
 	if apiKey == "" {
 		return nil, ErrNoAPIKey
 	}
 
-	cl, err := s.requireClient()
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
@@ -58,9 +58,9 @@ func (s *Service) AITabName(ctx context.Context, sql string) (string, error) {
 	return name, nil
 }
 
-func (s *Service) buildSchema() map[string][]ai.Column {
+func (s *Service) buildSchema(connID string) map[string][]ai.Column {
 	schema := make(map[string][]ai.Column)
-	cl := s.GetClient()
+	cl := s.GetClient(connID)
 	if cl == nil {
 		return schema
 	}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/macleodmac/pglet/pkg/client"
+)
+
+// ConfirmTokenTTL is how long a confirmation token stays valid.
+const ConfirmTokenTTL = 5 * time.Minute
+
+// DestructiveStatement describes a statement that needs confirmation before
+// it runs.
+type DestructiveStatement struct {
+	Statement     string
+	Verb          string // DROP, TRUNCATE, UPDATE or DELETE
+	Reason        string
+	Objects       []string
+	EstimatedRows *int64
+}
+
+// ConfirmationRequired is returned instead of running a query that contains
+// destructive statements. Resubmitting the same query with Token runs it.
+type ConfirmationRequired struct {
+	Token      string
+	ExpiresAt  time.Time
+	Statements []DestructiveStatement
+}
+
+func (e *ConfirmationRequired) Error() string {
+	verbs := make([]string, len(e.Statements))
+	for i, st := range e.Statements {
+		verbs[i] = st.Reason
+	}
+	return "confirmation required: " + strings.Join(verbs, ", ")
+}
+
+// checkDestructive returns a *ConfirmationRequired if query contains
+// destructive statements and token is not a valid confirmation for it.
+// params are the query's bind parameters, used for the row estimates.
+func (s *Service) checkDestructive(ctx context.Context, cl *client.Client, connID, query string, params map[string]ParamValue, token string) error {
+	stmts := destructiveStatements(query)
+	if len(stmts) == 0 || s.validConfirmToken(connID, query, token) {
+		return nil
+	}
+	for i := range stmts {
+		stmts[i].EstimatedRows = estimateAffectedRows(ctx, cl, stmts[i], params)
+	}
+	expires := time.Now().Add(ConfirmTokenTTL).UTC()
+	return &ConfirmationRequired{
+		Token:      s.confirmToken(connID, query, expires),
+		ExpiresAt:  expires,
+		Statements: stmts,
+	}
+}
+
+// estimateAffectedRows asks the planner how many rows an UPDATE or DELETE
+// would touch, and pg_class how many rows a dropped or truncated table holds.
+// The statement is bound first, as the planner rejects unbound placeholders.
+func estimateAffectedRows(ctx context.Context, cl *client.Client, st DestructiveStatement, params map[string]ParamValue) *int64 {
+	switch st.Verb {
+	case "UPDATE", "DELETE":
+		stmt, args, _, err := bindStatement(st.Statement, params)
+		if err != nil {
+			return nil
+		}
+		if n, err := cl.EstimateRows(ctx, stmt, args...); err == nil {
+			return &n
+		}
+	case "TRUNCATE", "DROP":
+		if st.Verb == "DROP" && st.Reason != "DROP TABLE" && st.Reason != "DROP MATERIALIZED VIEW" {
+			return nil
+		}
+		var total int64
+		found := false
+		for _, obj := range st.Objects {
+			if n, ok := cl.TableRowEstimate(ctx, obj); ok {
+				total += n
+				found = true
+			}
+		}
+		if found {
+			return &total
+		}
+	}
+	return nil
+}
+
+// confirmToken signs the connection, query and expiry time so a token only
+// confirms the exact text it was issued for.
+func (s *Service) confirmToken(connID, query string, expires time.Time) string {
+	buf := make([]byte, 8, 8+sha256.Size)
+	binary.BigEndian.PutUint64(buf, uint64(expires.Unix()))
+	buf = append(buf, s.confirmMAC(connID, query, buf[:8])...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func (s *Service) validConfirmToken(connID, query, token string) bool {
+	if token == "" {
+		return false
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) != 8+sha256.Size {
+		return false
+	}
+	if time.Now().Unix() > int64(binary.BigEndian.Uint64(raw[:8])) {
+		return false
+	}
+	return hmac.Equal(raw[8:], s.confirmMAC(connID, query, raw[:8]))
+}
+
+func (s *Service) confirmMAC(connID, query string, expiry []byte) []byte {
+	mac := hmac.New(sha256.New, s.confirmKey)
+	mac.Write(expiry)
+	mac.Write([]byte(connKey(connID)))
+	mac.Write([]byte{0})
+	mac.Write([]byte(query))
+	return mac.Sum(nil)
+}
+
+// destructiveStatements finds the statements in query that drop objects,
+// truncate tables, or update or delete every row of a table.
+func destructiveStatements(query string) []DestructiveStatement {
+	var result []DestructiveStatement
+	for _, stmt := range splitStatements(query) {
+		for _, tokens := range executedStatements(tokenizeSQL(stmt)) {
+			if st, ok := destructiveStatement(stmt, tokens); ok {
+				result = append(result, st)
+			}
+		}
+	}
+	return result
+}
+
+// destructiveStatement checks one executed statement of stmt, given its
+// tokens.
+func destructiveStatement(stmt string, tokens []sqlToken) (DestructiveStatement, bool) {
+	if len(tokens) == 0 || tokens[0].Kind != tokWord {
+		return DestructiveStatement{}, false
+	}
+	st := DestructiveStatement{Statement: stmt, Verb: tokens[0].upper()}
+	switch st.Verb {
+	case "DROP":
+		kind, names := dropTargets(tokens[1:])
+		st.Reason = "DROP " + kind
+		st.Objects = names
+	case "TRUNCATE":
+		st.Reason = "TRUNCATE"
+		st.Objects = nameList(skipWords(tokens[1:], "TABLE", "ONLY"))
+	case "UPDATE":
+		if hasTopLevelWord(tokens, "WHERE") {
+			return st, false
+		}
+		st.Reason = "UPDATE without WHERE"
+		st.Objects = firstName(skipWords(tokens[1:], "ONLY"))
+	case "DELETE":
+		if hasTopLevelWord(tokens, "WHERE") {
+			return st, false
+		}
+		st.Reason = "DELETE without WHERE"
+		st.Objects = firstName(skipWords(tokens[1:], "FROM", "ONLY"))
+	default:
+		return st, false
+	}
+	return st, true
+}
+
+// executedStatements returns the tokens of the statements that running a
+// statement executes: the statement itself, the one behind PREPARE ... AS or
+// EXPLAIN ANALYZE, and the data-modifying expressions of a leading WITH
+// clause along with the statement they precede. EXPLAIN without ANALYZE only
+// plans, so it executes nothing.
+func executedStatements(tokens []sqlToken) [][]sqlToken {
+	if len(tokens) == 0 || tokens[0].Kind != tokWord {
+		return nil
+	}
+	switch tokens[0].upper() {
+	case "PREPARE":
+		for i, t := range tokens {
+			if t.Kind == tokWord && t.upper() == "AS" {
+				return executedStatements(tokens[i+1:])
+			}
+		}
+		return nil
+	case "EXPLAIN":
+		analyze, rest := explainOptions(tokens[1:])
+		if !analyze {
+			return nil
+		}
+		return executedStatements(rest)
+	case "WITH":
+		main := mainStatement(tokens)
+		return append(cteStatements(tokens[:len(tokens)-len(main)]), main)
+	}
+	return [][]sqlToken{tokens}
+}
+
+// cteStatements returns the bodies of the INSERT, UPDATE, DELETE and MERGE
+// common table expressions in a WITH clause.
+func cteStatements(tokens []sqlToken) [][]sqlToken {
+	var result [][]sqlToken
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch t.Text {
+		case "(":
+			depth++
+			if depth == 1 {
+				start = i + 1
+			}
+		case ")":
+			depth--
+			if depth != 0 || start == i || tokens[start].Kind != tokWord {
+				continue
+			}
+			switch tokens[start].upper() {
+			case "INSERT", "UPDATE", "DELETE", "MERGE":
+				result = append(result, tokens[start:i])
+			}
+		}
+	}
+	return result
+}
+
+// mainStatement skips a leading WITH clause so a data-modifying statement
+// behind common table expressions is checked like a bare one.
+func mainStatement(tokens []sqlToken) []sqlToken {
+	if len(tokens) == 0 || tokens[0].upper() != "WITH" {
+		return tokens
+	}
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		case depth == 0 && t.Kind == tokWord:
+			switch t.upper() {
+			case "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE", "VALUES", "TABLE":
+				return tokens[i:]
+			}
+		}
+	}
+	return nil
+}
+
+// dropObjectWords are the words that make up the object type in a DROP
+// statement, e.g. MATERIALIZED VIEW or FOREIGN TABLE.
+var dropObjectWords = map[string]bool{
+	"TABLE": true, "VIEW": true, "MATERIALIZED": true, "INDEX": true, "SCHEMA": true,
+	"DATABASE": true, "FUNCTION": true, "PROCEDURE": true, "ROUTINE": true,
+	"AGGREGATE": true, "SEQUENCE": true, "TYPE": true, "DOMAIN": true,
+	"EXTENSION": true, "TRIGGER": true, "ROLE": true, "USER": true, "GROUP": true,
+	"FOREIGN": true, "DATA": true, "WRAPPER": true, "SERVER": true, "POLICY": true,
+	"RULE": true, "PUBLICATION": true, "SUBSCRIPTION": true, "TABLESPACE": true,
+	"OWNED": true, "EVENT": true, "STATISTICS": true, "COLLATION": true,
+	"CONVERSION": true, "LANGUAGE": true, "PROCEDURAL": true, "OPERATOR": true,
+	"CLASS": true, "FAMILY": true, "CAST": true, "TRANSFORM": true, "TEXT": true,
+	"SEARCH": true, "CONFIGURATION": true, "DICTIONARY": true, "PARSER": true,
+	"TEMPLATE": true, "ACCESS": true, "METHOD": true, "UNLOGGED": true,
+}
+
+// dropTargets returns the object type and names of a DROP statement, given
+// the tokens after DROP.
+func dropTargets(tokens []sqlToken) (string, []string) {
+	var kind []string
+	for len(tokens) > 0 && tokens[0].Kind == tokWord && dropObjectWords[tokens[0].upper()] {
+		kind = append(kind, tokens[0].upper())
+		tokens = tokens[1:]
+	}
+	tokens = skipWords(tokens, "CONCURRENTLY", "IF", "EXISTS")
+	return strings.Join(kind, " "), nameList(tokens)
+}
+
+// nameList reads a comma-separated list of possibly qualified names, stopping
+// at the first keyword that ends the list. Parenthesised argument lists, as
+// in DROP FUNCTION f(int), are skipped.
+func nameList(tokens []sqlToken) []string {
+	var names []string
+	var cur strings.Builder
+	depth := 0
+	flush := func() {
+		if cur.Len() > 0 {
+			names = append(names, cur.String())
+			cur.Reset()
+		}
+	}
+loop:
+	for _, t := range tokens {
+		switch {
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		case depth > 0:
+		case t.Text == ",":
+			flush()
+		case t.Text == "." || t.Kind == tokIdent:
+			cur.WriteString(t.Text)
+		case t.Kind == tokWord:
+			switch t.upper() {
+			case "CASCADE", "RESTRICT", "RESTART", "CONTINUE", "ON":
+				break loop
+			}
+			cur.WriteString(t.Text)
+		case t.Text == "*":
+		default:
+			break loop
+		}
+	}
+	flush()
+	return names
+}
+
+// firstName returns the table name at the start of tokens, as a one-element
+// list, ignoring any alias that follows it.
+func firstName(tokens []sqlToken) []string {
+	var cur strings.Builder
+	for _, t := range tokens {
+		if t.Text != "." && t.Kind != tokIdent && t.Kind != tokWord {
+			break
+		}
+		if cur.Len() > 0 && !strings.HasSuffix(cur.String(), ".") && t.Text != "." {
+			break
+		}
+		cur.WriteString(t.Text)
+	}
+	if cur.Len() == 0 {
+		return nil
+	}
+	return []string{cur.String()}
+}
+
+// skipWords drops leading word tokens that are in words.
+func skipWords(tokens []sqlToken, words ...string) []sqlToken {
+	for len(tokens) > 0 && tokens[0].Kind == tokWord {
+		found := false
+		for _, w := range words {
+			if tokens[0].upper() == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+		tokens = tokens[1:]
+	}
+	return tokens
+}
+
+// hasTopLevelWord reports whether word appears outside any parentheses.
+func hasTopLevelWord(tokens []sqlToken, word string) bool {
+	depth := 0
+	for _, t := range tokens {
+		switch {
+		case t.Text == "(":
+			depth++
+		case t.Text == ")":
+			depth--
+		case depth == 0 && t.Kind == tokWord && t.upper() == word:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDestructiveStatements(t *testing.T) {
+	type found struct {
+		Reason  string
+		Objects []string
+	}
+	tests := []struct {
+		name string
+		sql  string
+		want []found
+	}{
+		{"select", "select * from t", nil},
+
+		{"delete without where", "delete from t", []found{{"DELETE without WHERE", []string{"t"}}}},
+		{"delete only with alias", "delete from only s.t as x", []found{{"DELETE without WHERE", []string{"s.t"}}}},
+		{"delete with where", "delete from t where id = 1", nil},
+		{"delete using with where", "delete from t using u where t.id = u.id", nil},
+		{"delete with where in subquery only", "delete from t returning (select 1 where true)", []found{{"DELETE without WHERE", []string{"t"}}}},
+		{"update without where", "update t set a = 1", []found{{"UPDATE without WHERE", []string{"t"}}}},
+		{"update with where in subquery only", "update t set a = (select b from u where u.id = t.id)", []found{{"UPDATE without WHERE", []string{"t"}}}},
+		{"update with where in string", "update t set a = 'where'", []found{{"UPDATE without WHERE", []string{"t"}}}},
+		{"update with where", "update t set a = 1 where true", nil},
+
+		{"drop table", "drop table t", []found{{"DROP TABLE", []string{"t"}}}},
+		{"drop tables cascade", "drop table if exists a, s.b cascade", []found{{"DROP TABLE", []string{"a", "s.b"}}}},
+		{"drop quoted restrict", `drop view "My View" restrict`, []found{{"DROP VIEW", []string{`"My View"`}}}},
+		{"drop materialized view", "drop materialized view v", []found{{"DROP MATERIALIZED VIEW", []string{"v"}}}},
+		{"drop function cascade", "drop function f(int, text) cascade", []found{{"DROP FUNCTION", []string{"f"}}}},
+		{"drop index concurrently", "drop index concurrently if exists i", []found{{"DROP INDEX", []string{"i"}}}},
+		{"truncate", "truncate table only a, b restart identity", []found{{"TRUNCATE", []string{"a", "b"}}}},
+
+		{"CTE before delete", "with x as (select 1) delete from t", []found{{"DELETE without WHERE", []string{"t"}}}},
+		{"CTE before delete with where", "with x as (select 1) delete from t where id in (select * from x)", nil},
+		{"deleting CTE", "with d as (delete from t returning *) select * from d", []found{{"DELETE without WHERE", []string{"t"}}}},
+		{"deleting CTE with where", "with d as (delete from t where id = 1 returning *) select * from d", nil},
+		{
+			"updating CTE before delete",
+			"with u as materialized (update t set a = 1 returning *), x (n) as (select 1) delete from v",
+			[]found{{"UPDATE without WHERE", []string{"t"}}, {"DELETE without WHERE", []string{"v"}}},
+		},
+
+		{"explain", "explain delete from t", nil},
+		{"explain analyze", "explain analyze delete from t", []found{{"DELETE without WHERE", []string{"t"}}}},
+		{"explain (analyze)", "explain (analyze, buffers) update t set a = 1", []found{{"UPDATE without WHERE", []string{"t"}}}},
+		{"explain (analyze false)", "explain (analyze false) delete from t", nil},
+		{"explain analyze deleting CTE", "explain analyze with d as (delete from t returning *) select 1", []found{{"DELETE without WHERE", []string{"t"}}}},
+
+		{"prepare delete", "prepare p as delete from t", []found{{"DELETE without WHERE", []string{"t"}}}},
+		{"prepare delete with where", "prepare p (int) as delete from t where id = $1", nil},
+		{"prepare truncate", "prepare p as truncate t", []found{{"TRUNCATE", []string{"t"}}}},
+
+		{
+			"script",
+			"select 1; drop table a; delete from b where true; truncate c",
+			[]found{{"DROP TABLE", []string{"a"}}, {"TRUNCATE", []string{"c"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []found
+			for _, st := range destructiveStatements(tt.sql) {
+				got = append(got, found{st.Reason, st.Objects})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("destructiveStatements(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMainStatement(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"select 1", "select 1"},
+		{"with x as (select 1) select * from x", "select * from x"},
+		{"with x (a) as (select 1), y as (select 2) table y", "table y"},
+		{"with recursive r (n) as (select 1 union all select n + 1 from r) delete from t", "delete from t"},
+		{"with x as not materialized (select 1) update t set a = 1", "update t set a = 1"},
+		{"with d as (delete from t returning *) select * from d", "select * from d"},
+		{"with x as (select 1)", ""},
+	}
+	for _, tt := range tests {
+		var words []string
+		for _, tok := range mainStatement(tokenizeSQL(tt.sql)) {
+			words = append(words, tok.Text)
+		}
+		if got := strings.Join(words, " "); got != tt.want {
+			t.Errorf("mainStatement(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
@@ -2,25 +2,35 @@ package service
 
 import "github.com/macleodmac/pglet/pkg/client"
 
-func (s *Service) Connect(url string) (*client.ConnectionInfo, error) {
-	cl, err := client.New(url)
+// Connect opens a connection to url and registers it under id, replacing any
+// connection already registered under that ID.
+func (s *Service) Connect(id, url string) (*client.ConnectionInfo, error) {
+	return s.connect(id, url, false)
+}
+
+// connect is Connect with a per-connection read-only setting. The connection
+// is read-only if either readOnly or the service-wide ReadOnly flag is set.
+func (s *Service) connect(id, url string, readOnly bool) (*client.ConnectionInfo, error) {
+	cl, err := client.New(url, client.Options{ReadOnly: readOnly || s.ReadOnly, MaxConns: s.MaxConns})
 	if err != nil {
 		return nil, err
 	}
-	s.SwapClient(cl)
+	s.SwapClient(id, cl)
+	s.snapshotOnConnect(id, cl)
 	info, err := cl.Info()
 	if err != nil {
 		return nil, err
 	}
+	info.ID = connKey(id)
 	return info, nil
 }
 
-func (s *Service) Disconnect() {
-	s.SwapClient(nil)
+func (s *Service) Disconnect(id string) {
+	s.SwapClient(id, nil)
 }
 
-func (s *Service) SwitchDatabase(database string) (*client.ConnectionInfo, error) {
-	cl, err := s.requireClient()
+func (s *Service) SwitchDatabase(id, database string) (*client.ConnectionInfo, error) {
+	cl, err := s.requireClient(id)
 	if err != nil {
 		return nil, err
 	}
@@ -28,16 +38,18 @@ func (s *Service) SwitchDatabase(database string) (*client.ConnectionInfo, error
 	if err != nil {
 		return nil, err
 	}
-	s.SwapClient(newClient)
+	s.SwapClient(id, newClient)
+	s.snapshotOnConnect(id, newClient)
 	info, err := newClient.Info()
 	if err != nil {
 		return nil, err
 	}
+	info.ID = connKey(id)
 	return info, nil
 }
 
-func (s *Service) ConnectionInfo() (*client.ConnectionInfo, bool, error) {
-	cl := s.GetClient()
+func (s *Service) ConnectionInfo(id string) (*client.ConnectionInfo, bool, error) {
+	cl := s.GetClient(id)
 	if cl == nil {
 		return nil, false, nil
 	}
@@ -45,11 +57,30 @@ func (s *Service) ConnectionInfo() (*client.ConnectionInfo, bool, error) {
 	if err != nil {
 		return nil, true, err
 	}
+	info.ID = connKey(id)
 	return info, true, nil
 }
 
-func (s *Service) Databases() ([]string, error) {
-	cl, err := s.requireClient()
+// Connections returns connection info for every open connection. A
+// connection whose info cannot be read is listed with only its ID and the
+// error, so one unreachable server does not hide the others.
+func (s *Service) Connections() []client.ConnectionInfo {
+	var result []client.ConnectionInfo
+	for _, id := range s.ConnectionIDs() {
+		info, ok, err := s.ConnectionInfo(id)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			info = &client.ConnectionInfo{ID: connKey(id), Error: err.Error()}
+		}
+		result = append(result, *info)
+	}
+	return result
+}
+
+func (s *Service) Databases(id string) ([]string, error) {
+	cl, err := s.requireClient(id)
 	if err != nil {
 		return nil, err
 	}
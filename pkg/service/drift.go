@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/macleodmac/pglet/pkg/client"
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+// TakeSchemaSnapshot stores the structure of every schema in the
+// connection's database.
+func (s *Service) TakeSchemaSnapshot(ctx context.Context, connID, label string) (*repository.SchemaSnapshot, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return s.snapshotSchemas(ctx, connKey(connID), cl, label, false)
+}
+
+// snapshotOnConnect records the schemas of a newly opened connection in the
+// background if they changed since the last snapshot of its database. The
+// snapshot is tied to cl: it is skipped if cl is no longer registered under
+// id, and cancelled by SwapClient before cl is closed.
+func (s *Service) snapshotOnConnect(id string, cl *client.Client) {
+	if s.Repo == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	s.mu.Lock()
+	if s.clients[connKey(id)] != cl {
+		s.mu.Unlock()
+		cancel()
+		return
+	}
+	s.snapshots[cl] = cancel
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.snapshots, cl)
+			s.mu.Unlock()
+			cancel()
+		}()
+		_, err := s.snapshotSchemas(ctx, connKey(id), cl, "on connect", true)
+		if err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+			slog.Warn("schema snapshot failed", "connection", connKey(id), "err", err)
+		}
+	}()
+}
+
+// snapshotSchemas stores the catalogs of cl's database with the number of
+// changes since the previous snapshot of it. With onlyChanged, nothing is
+// stored if nothing changed, and nil is returned.
+func (s *Service) snapshotSchemas(ctx context.Context, connection string, cl *client.Client, label string, onlyChanged bool) (*repository.SchemaSnapshot, error) {
+	catalogs, err := readCatalogs(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(catalogs)
+	if err != nil {
+		return nil, err
+	}
+	snap := repository.SchemaSnapshot{
+		Connection: connection, Database: cl.Database(), Label: label,
+		Schemas: make([]string, len(catalogs)), Catalogs: data,
+	}
+	for i, cat := range catalogs {
+		snap.Schemas[i] = cat.Schema
+	}
+
+	prev, err := s.Repo.LatestSchemaSnapshot(connection, snap.Database)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		before, err := snapshotCatalogs(prev)
+		if err != nil {
+			return nil, err
+		}
+		snap.PreviousID = prev.ID
+		for _, d := range diffCatalogSets(before, catalogs) {
+			snap.Changes += len(d.Changes)
+		}
+		if onlyChanged && snap.Changes == 0 {
+			return nil, nil
+		}
+	}
+
+	created, err := s.Repo.AddSchemaSnapshot(snap)
+	if err != nil {
+		return nil, err
+	}
+	created.Catalogs = nil
+	return created, nil
+}
+
+func (s *Service) ListSchemaSnapshots() ([]repository.SchemaSnapshot, error) {
+	return s.Repo.ListSchemaSnapshots()
+}
+
+func (s *Service) DeleteSchemaSnapshot(id int) error {
+	return s.Repo.DeleteSchemaSnapshot(id)
+}
+
+// SchemaDrift is how the schemas of a database changed between two points.
+type SchemaDrift struct {
+	From *repository.SchemaSnapshot
+	To   *repository.SchemaSnapshot // nil when compared with the live database
+	// Diffs are the changes in each schema that changed, sorted by schema.
+	Diffs []*client.SchemaDiff
+}
+
+// CompareSchemaSnapshots returns the changes from snapshot fromID to
+// snapshot toID, or to the connection's current schemas if toID is nil. Both
+// sides must be of the same connection and database, or ErrSnapshotMismatch
+// is returned.
+func (s *Service) CompareSchemaSnapshots(ctx context.Context, connID string, fromID int, toID *int) (*SchemaDrift, error) {
+	from, err := s.Repo.GetSchemaSnapshot(fromID)
+	if err != nil {
+		return nil, err
+	}
+	drift := &SchemaDrift{From: from}
+	var cl *client.Client
+	connection, database := "", ""
+	if toID != nil {
+		if drift.To, err = s.Repo.GetSchemaSnapshot(*toID); err != nil {
+			return nil, err
+		}
+		connection, database = drift.To.Connection, drift.To.Database
+	} else {
+		if cl, err = s.requireClient(connID); err != nil {
+			return nil, err
+		}
+		connection, database = connKey(connID), cl.Database()
+	}
+	if from.Connection != connection || from.Database != database {
+		return nil, fmt.Errorf("%w: %s/%s and %s/%s", ErrSnapshotMismatch,
+			from.Connection, from.Database, connection, database)
+	}
+
+	before, err := snapshotCatalogs(from)
+	if err != nil {
+		return nil, err
+	}
+	var after []client.Catalog
+	if drift.To != nil {
+		if after, err = snapshotCatalogs(drift.To); err != nil {
+			return nil, err
+		}
+		drift.To.Catalogs = nil
+	} else if after, err = readCatalogs(ctx, cl); err != nil {
+		return nil, err
+	}
+	from.Catalogs = nil
+	drift.Diffs = diffCatalogSets(before, after)
+	return drift, nil
+}
+
+// readCatalogs reads every schema of cl's database except the system ones.
+func readCatalogs(ctx context.Context, cl *client.Client) ([]client.Catalog, error) {
+	schemas, err := cl.Schemas()
+	if err != nil {
+		return nil, err
+	}
+	catalogs := []client.Catalog{}
+	for _, schema := range schemas {
+		// Temporary schemas are per session.
+		if strings.HasPrefix(schema, "pg_") {
+			continue
+		}
+		cat, err := cl.Catalog(ctx, schema)
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, *cat)
+	}
+	return catalogs, nil
+}
+
+func snapshotCatalogs(snap *repository.SchemaSnapshot) ([]client.Catalog, error) {
+	var catalogs []client.Catalog
+	if err := json.Unmarshal(snap.Catalogs, &catalogs); err != nil {
+		return nil, err
+	}
+	return catalogs, nil
+}
+
+// diffCatalogSets compares two sets of schemas, returning the diffs of the
+// schemas that changed. A schema on one side only is compared with an empty
+// one.
+func diffCatalogSets(before, after []client.Catalog) []*client.SchemaDiff {
+	byName := make(map[string][2]*client.Catalog)
+	for i := range before {
+		pair := byName[before[i].Schema]
+		pair[0] = &before[i]
+		byName[before[i].Schema] = pair
+	}
+	for i := range after {
+		pair := byName[after[i].Schema]
+		pair[1] = &after[i]
+		byName[after[i].Schema] = pair
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	diffs := []*client.SchemaDiff{}
+	for _, name := range names {
+		pair := byName[name]
+		for i := range pair {
+			if pair[i] == nil {
+				pair[i] = &client.Catalog{Schema: name}
+			}
+		}
+		if d := client.DiffCatalogs(pair[0], pair[1]); len(d.Changes) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParamValue is a bind parameter value for a query. Value is a decoded JSON
+// value; objects and arrays are sent as JSON text. Type, if set, is the
+// PostgreSQL type the placeholder is cast to, e.g. "int" or "timestamptz".
+type ParamValue struct {
+	Type  string
+	Value any
+}
+
+// paramTypePattern matches a type name, optionally schema-qualified, with
+// modifiers and array brackets, e.g. "numeric(10,2)", "public.mood" or
+// "text[]". Types are spliced into the statement as casts, so anything else
+// is rejected.
+var paramTypePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?( [A-Za-z_][A-Za-z0-9_]*)*(\(\d+(, ?\d+)?\))?(\[\])*$`)
+
+// boundStatement is a script statement rewritten to use bind parameters.
+type boundStatement struct {
+	scriptStatement
+	Source string // the statement as written, before placeholders were rewritten
+	Args   []any
+	edits  []paramEdit
+}
+
+// paramEdit records one placeholder rewrite: the span [at, newEnd) of the
+// rewritten text replaced the original text ending at origEnd (byte offsets).
+type paramEdit struct {
+	at, newEnd int
+	origEnd    int
+}
+
+// bindScript binds params into every statement of a script. Positional
+// placeholders ($1) take the value named by their number ("1"), and named
+// placeholders (:name) are rewritten to positional ones. A :name with no
+// value is left alone, as it may be part of an array slice such as a[1:n].
+// With no params the statements are returned unchanged.
+func bindScript(stmts []scriptStatement, params map[string]ParamValue) ([]boundStatement, error) {
+	for name, p := range params {
+		if p.Type != "" && !paramTypePattern.MatchString(p.Type) {
+			return nil, fmt.Errorf("parameter %s: invalid type %q", name, p.Type)
+		}
+	}
+	bound := make([]boundStatement, len(stmts))
+	for i, st := range stmts {
+		bound[i].scriptStatement = st
+		bound[i].Source = st.Text
+		if len(params) == 0 || st.CopyData != nil {
+			continue
+		}
+		text, args, edits, err := bindStatement(st.Text, params)
+		if err != nil {
+			return nil, err
+		}
+		bound[i].Text, bound[i].Args, bound[i].edits = text, args, edits
+	}
+	return bound, nil
+}
+
+// sourcePosition maps a 1-based character position in the rewritten
+// statement, as reported by the server, back to the statement as written.
+// A position inside a rewritten placeholder maps to its start.
+func (st boundStatement) sourcePosition(pos int) int {
+	if len(st.edits) == 0 || pos <= 0 {
+		return pos
+	}
+	off := runeOffset(st.Text, pos-1)
+	shift := 0
+	for _, e := range st.edits {
+		if off < e.at {
+			break
+		}
+		if off < e.newEnd {
+			off = e.at
+			break
+		}
+		shift = e.origEnd - e.newEnd
+	}
+	return utf8.RuneCountInString(st.Source[:min(off+shift, len(st.Source))]) + 1
+}
+
+// runeOffset returns the byte offset of the n-th (0-based) character of s.
+func runeOffset(s string, n int) int {
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
+
+// bindStatement rewrites the placeholders in one statement and returns its
+// arguments. Placeholders are renumbered densely in order of appearance, so
+// a statement that only uses $2 of a script's parameters still binds every
+// parameter it sends.
+func bindStatement(stmt string, params map[string]ParamValue) (string, []any, []paramEdit, error) {
+	tokens := tokenizeSQL(stmt)
+	slots := make(map[string]int)
+	var args []any
+	var edits []paramEdit
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		var name string
+		end := t.End
+		switch {
+		case t.Kind == tokParam:
+			name = t.Text[1:]
+			if _, ok := params[name]; !ok {
+				return "", nil, nil, fmt.Errorf("no value for parameter %s", t.Text)
+			}
+		case isNamedParam(tokens, i):
+			name = tokens[i+1].Text
+			if _, ok := params[name]; !ok {
+				continue
+			}
+			end = tokens[i+1].End
+			i++
+		default:
+			continue
+		}
+		p := params[name]
+		slot, ok := slots[name]
+		if !ok {
+			v, err := paramArg(p.Value)
+			if err != nil {
+				return "", nil, nil, fmt.Errorf("parameter %s: %w", name, err)
+			}
+			args = append(args, v)
+			slot = len(args)
+			slots[name] = slot
+		}
+		b.WriteString(stmt[last:t.Start])
+		at := b.Len()
+		b.WriteString("$" + strconv.Itoa(slot))
+		if p.Type != "" {
+			b.WriteString("::" + p.Type)
+		}
+		edits = append(edits, paramEdit{at: at, newEnd: b.Len(), origEnd: end})
+		last = end
+	}
+	if len(args) == 0 {
+		return stmt, nil, nil, nil
+	}
+	b.WriteString(stmt[last:])
+	return b.String(), args, edits, nil
+}
+
+// isNamedParam reports whether tokens[i] is the colon of a :name
+// placeholder: a lone colon, not part of a :: cast, immediately followed by
+// a bare identifier.
+func isNamedParam(tokens []sqlToken, i int) bool {
+	t := tokens[i]
+	if t.Text != ":" || i+1 >= len(tokens) {
+		return false
+	}
+	if i > 0 && tokens[i-1].Text == ":" && tokens[i-1].End == t.Start {
+		return false
+	}
+	name := tokens[i+1]
+	if name.Kind != tokWord || name.Start != t.End || name.Text[0] >= '0' && name.Text[0] <= '9' {
+		return false
+	}
+	return true
+}
+
+// paramArg converts a decoded JSON value to a driver argument.
+func paramArg(v any) (any, error) {
+	switch v := v.(type) {
+	case nil, bool, string:
+		return v, nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v), nil
+		}
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
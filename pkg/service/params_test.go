@@ -0,0 +1,186 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindStatement(t *testing.T) {
+	params := map[string]ParamValue{
+		"1":    {Value: "one"},
+		"2":    {Value: float64(2)},
+		"id":   {Value: float64(42)},
+		"name": {Value: "bob", Type: "text"},
+		"tags": {Value: []any{"a", "b"}, Type: "jsonb"},
+	}
+	tests := []struct {
+		name     string
+		stmt     string
+		wantText string
+		wantArgs []any
+		wantErr  string
+	}{
+		{"no placeholders", "select 1", "select 1", nil, ""},
+		{"positional", "select $1, $2", "select $1, $2", []any{"one", int64(2)}, ""},
+		{"positional renumbered densely", "select $2", "select $1", []any{int64(2)}, ""},
+		{"repeated positional", "select $2 + $2", "select $1 + $1", []any{int64(2)}, ""},
+		{"named", "select * from t where id = :id", "select * from t where id = $1", []any{int64(42)}, ""},
+		{"named with type", "select :name", "select $1::text", []any{"bob"}, ""},
+		{"repeated named", "select :id, :id", "select $1, $1", []any{int64(42)}, ""},
+		{"named and positional", "select :id, $1, :id", "select $1, $2, $1", []any{int64(42), "one"}, ""},
+		{"array value sent as JSON", "select :tags", "select $1::jsonb", []any{`["a","b"]`}, ""},
+		{"cast is not a placeholder", "select id::text from t where id = :id", "select id::text from t where id = $1", []any{int64(42)}, ""},
+		{"cast of a named placeholder", "select :id::int", "select $1::int", []any{int64(42)}, ""},
+		{"cast of a name is not a placeholder", "select x::id", "select x::id", nil, ""},
+		{"inside a string", "select ':id', $$:id$$, E':id', \":id\"", "select ':id', $$:id$$, E':id', \":id\"", nil, ""},
+		{"inside comments", "select 1 -- :id\n/* :id /* $1 */ */", "select 1 -- :id\n/* :id /* $1 */ */", nil, ""},
+		{"inside a tagged dollar quote", "select $q$ $1 :id $q$, :id", "select $q$ $1 :id $q$, $1", []any{int64(42)}, ""},
+		{"unknown name left alone", "select a[1:n] from t", "select a[1:n] from t", nil, ""},
+		{"space after colon", "select a[1: id]", "select a[1: id]", nil, ""},
+		{"colon before a number", "select a[1:2]", "select a[1:2]", nil, ""},
+		{"missing positional", "select $3", "", nil, "no value for parameter $3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, args, _, err := bindStatement(tt.stmt, params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("bindStatement(%q) error = %v, want %q", tt.stmt, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bindStatement(%q): %v", tt.stmt, err)
+			}
+			if text != tt.wantText {
+				t.Errorf("bindStatement(%q) text = %q, want %q", tt.stmt, text, tt.wantText)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("bindStatement(%q) args = %#v, want %#v", tt.stmt, args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBindScript(t *testing.T) {
+	copyData := "1\n"
+	stmts := []scriptStatement{
+		{Text: "select :id", Start: 0},
+		{Text: "copy t from stdin", Start: 12, CopyData: &copyData},
+	}
+
+	t.Run("binds each statement", func(t *testing.T) {
+		bound, err := bindScript(stmts, map[string]ParamValue{"id": {Value: float64(1), Type: "int"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bound[0].Text != "select $1::int" || bound[0].Source != "select :id" || bound[0].Start != 0 {
+			t.Errorf("bound[0] = %+v", bound[0])
+		}
+		if bound[1].Text != "copy t from stdin" || bound[1].Args != nil || bound[1].CopyData != &copyData {
+			t.Errorf("COPY statement was rewritten: %+v", bound[1])
+		}
+	})
+
+	t.Run("no params", func(t *testing.T) {
+		bound, err := bindScript(stmts, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bound[0].Text != "select :id" || bound[0].Args != nil {
+			t.Errorf("bound[0] = %+v", bound[0])
+		}
+	})
+
+	for _, typ := range []string{"int", "numeric(10,2)", "numeric(10, 2)", "public.mood", "text[]", "timestamp with time zone", "int[][]"} {
+		t.Run("type "+typ, func(t *testing.T) {
+			if _, err := bindScript(stmts, map[string]ParamValue{"id": {Value: "1", Type: typ}}); err != nil {
+				t.Errorf("type %q rejected: %v", typ, err)
+			}
+		})
+	}
+	for _, typ := range []string{"int; drop table t", "text)", "int -- x", "1int", "a.b.c"} {
+		t.Run("invalid type "+typ, func(t *testing.T) {
+			if _, err := bindScript(stmts, map[string]ParamValue{"id": {Value: "1", Type: typ}}); err == nil {
+				t.Errorf("type %q accepted", typ)
+			}
+		})
+	}
+}
+
+func TestSourcePosition(t *testing.T) {
+	params := map[string]ParamValue{
+		"id":   {Value: float64(1)},
+		"name": {Value: "x", Type: "text"},
+	}
+	bind := func(t *testing.T, source string) boundStatement {
+		t.Helper()
+		bound, err := bindScript([]scriptStatement{{Text: source}}, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bound[0]
+	}
+	// pos finds the 1-based character position of sub in s.
+	pos := func(s, sub string) int {
+		return len([]rune(s[:strings.Index(s, sub)])) + 1
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		at     string // text in the rewritten statement the server points at
+		want   string // text in the source it should map to
+	}{
+		{"before any placeholder", "selec :id from t", "selec", "selec"},
+		{"after a shorter rewrite", "select :name, bogus", "bogus", "bogus"},
+		{"after a longer rewrite", "select :id, bogus", "bogus", "bogus"},
+		{"inside a rewritten placeholder", "select :name", "::text", ":name"},
+		{"after several rewrites", "select :name, :id, :name, bogus", "bogus", "bogus"},
+		{"multibyte text", "select 'é', :name, bogus", "bogus", "bogus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := bind(t, tt.source)
+			got := st.sourcePosition(pos(st.Text, tt.at))
+			if want := pos(tt.source, tt.want); got != want {
+				t.Errorf("sourcePosition in %q -> %q = %d, want %d", st.Text, tt.at, got, want)
+			}
+		})
+	}
+
+	t.Run("unbound statement", func(t *testing.T) {
+		st := boundStatement{scriptStatement: scriptStatement{Text: "select 1"}, Source: "select 1"}
+		if got := st.sourcePosition(5); got != 5 {
+			t.Errorf("sourcePosition = %d, want 5", got)
+		}
+	})
+}
+
+func TestParamArg(t *testing.T) {
+	tests := []struct {
+		in   any
+		want any
+	}{
+		{nil, nil},
+		{true, true},
+		{"s", "s"},
+		{float64(3), int64(3)},
+		{float64(-3), int64(-3)},
+		{1.5, 1.5},
+		{float64(1 << 53), float64(1 << 53)},
+		{map[string]any{"a": float64(1)}, `{"a":1}`},
+		{[]any{float64(1), "x"}, `[1,"x"]`},
+	}
+	for _, tt := range tests {
+		got, err := paramArg(tt.in)
+		if err != nil {
+			t.Errorf("paramArg(%#v): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("paramArg(%#v) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
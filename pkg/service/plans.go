@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/macleodmac/pglet/pkg/client"
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+// storePlan records an explained plan and returns its ID, or 0 if it could
+// not be stored.
+func (s *Service) storePlan(connID string, cl *client.Client, sql string, plan *client.Plan) int {
+	if s.Repo == nil {
+		return 0
+	}
+	id, err := s.Repo.AddPlan(repository.StoredPlan{
+		SQL: sql, Connection: connKey(connID), Database: cl.Database(),
+		Analyzed: plan.Analyzed, Plan: plan.Raw,
+	})
+	if err != nil {
+		slog.Warn("storing plan failed", "err", err)
+		return 0
+	}
+	return id
+}
+
+func (s *Service) ListPlans(pinnedOnly bool) ([]repository.StoredPlan, error) {
+	return s.Repo.ListPlans(pinnedOnly)
+}
+
+// GetPlan returns a stored plan and its parsed tree.
+func (s *Service) GetPlan(id int) (*repository.StoredPlan, *client.Plan, error) {
+	sp, err := s.Repo.GetPlan(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := client.ParsePlan(sp.Plan)
+	if err != nil {
+		return nil, nil, fmt.Errorf("plan %d: %w", id, err)
+	}
+	plan.Analyzed = sp.Analyzed
+	return sp, plan, nil
+}
+
+func (s *Service) PinPlan(id int, pinned bool, label string) (*repository.StoredPlan, error) {
+	return s.Repo.PinPlan(id, pinned, label)
+}
+
+func (s *Service) DeletePlan(id int) error {
+	return s.Repo.DeletePlan(id)
+}
+
+// ComparePlans diffs two stored plans node by node.
+func (s *Service) ComparePlans(beforeID, afterID int) (*client.PlanDiff, error) {
+	_, before, err := s.GetPlan(beforeID)
+	if err != nil {
+		return nil, err
+	}
+	_, after, err := s.GetPlan(afterID)
+	if err != nil {
+		return nil, err
+	}
+	return client.ComparePlans(before, after), nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/macleodmac/pglet/pkg/client"
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+func (s *Service) ListConnectionProfiles() ([]repository.ConnectionProfile, error) {
+	return s.Repo.ListConnectionProfiles()
+}
+
+func (s *Service) GetConnectionProfile(id string) (*repository.ConnectionProfile, error) {
+	return s.Repo.GetConnectionProfile(id)
+}
+
+func (s *Service) CreateConnectionProfile(p repository.ConnectionProfile) (*repository.ConnectionProfile, error) {
+	return s.Repo.CreateConnectionProfile(p)
+}
+
+func (s *Service) UpdateConnectionProfile(p repository.ConnectionProfile, setPassword bool) error {
+	return s.Repo.UpdateConnectionProfile(p, setPassword)
+}
+
+func (s *Service) DeleteConnectionProfile(id string) error {
+	return s.Repo.DeleteConnectionProfile(id)
+}
+
+// ConnectProfile opens a connection using the saved profile and registers it
+// under connID, honouring the profile's read-only setting.
+func (s *Service) ConnectProfile(connID, profileID string) (*client.ConnectionInfo, error) {
+	p, err := s.Repo.GetConnectionProfile(profileID)
+	if err != nil {
+		return nil, err
+	}
+	return s.connect(connID, profileURL(*p), p.ReadOnly)
+}
+
+// profileURL builds a postgres:// connection URL from a profile.
+func profileURL(p repository.ConnectionProfile) string {
+	port := p.Port
+	if port == 0 {
+		port = 5432
+	}
+	u := url.URL{
+		Scheme: "postgres",
+		Host:   net.JoinHostPort(p.Host, strconv.Itoa(port)),
+		Path:   "/" + p.Database,
+	}
+	if p.User != "" {
+		if p.Password != "" {
+			u.User = url.UserPassword(p.User, p.Password)
+		} else {
+			u.User = url.User(p.User)
+		}
+	}
+	sslmode := p.SSLMode
+	if sslmode == "" {
+		sslmode = "disable"
+	}
+	u.RawQuery = url.Values{"sslmode": {sslmode}}.Encode()
+	return u.String()
+}
@@ -2,81 +2,609 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/macleodmac/pglet/pkg/client"
 	"github.com/macleodmac/pglet/pkg/repository"
 )
 
-func (s *Service) RunQuery(ctx context.Context, tabID, query string) (*client.QueryResult, error) {
-	cl, err := s.requireClient()
+// MaxResultRows caps how many rows a single query returns to the UI, whether
+// buffered or streamed, so a careless SELECT cannot exhaust server memory.
+const MaxResultRows = 100000
+
+// QueryOptions are the per-request settings for RunQuery and StreamQuery.
+type QueryOptions struct {
+	// MaxRows limits the rows returned per statement; it is capped at
+	// MaxResultRows.
+	MaxRows int
+	// ConfirmToken confirms a destructive query, as issued in a
+	// *ConfirmationRequired for the same query text.
+	ConfirmToken string
+	// ContinueOnError runs a script's remaining statements after one fails.
+	// By default the rest are skipped.
+	ContinueOnError bool
+	// Params are bind parameters for $1 and :name placeholders, keyed by
+	// number or name (see bindScript).
+	Params map[string]ParamValue
+}
+
+// StatementResult is the outcome of one statement of a script.
+type StatementResult struct {
+	Statement string
+	Result    *client.QueryResult // nil if the statement failed or was skipped
+	Err       error
+	// ErrorPosition is the 1-based character position of the error in the
+	// whole script, or 0 if the server did not report one.
+	ErrorPosition int
+	Skipped       bool // not run because an earlier statement failed
+}
+
+// StreamResult summarises a streamed statement once its rows have been
+// delivered, or reports why it did not complete.
+type StreamResult struct {
+	RowCount      int
+	DurationMs    int64
+	Truncated     bool
+	RowsAffected  int64
+	CommandTag    string
+	Err           error
+	ErrorPosition int
+	Skipped       bool
+}
+
+// StreamCallbacks receive a streamed script's output. stmt is the 0-based
+// index of the statement in the script.
+type StreamCallbacks struct {
+	// Columns is called once per statement before its first row.
+	Columns func(stmt int, columns, columnTypes []string) error
+	Row     func(stmt int, row []any) error
+	// Done is called once per statement, including failed and skipped ones.
+	Done func(stmt int, result *StreamResult) error
+}
+
+// RunQuery executes a script and buffers one result per statement. The
+// script is split on semicolons outside quotes, comments and dollar-quoted
+// bodies, and COPY ... FROM STDIN statements may carry inline data. Each
+// statement is recorded in history. Execution stops at the first failing
+// statement unless opts.ContinueOnError is set.
+//
+// Scripts that drop or truncate objects, or update or delete without a
+// WHERE clause, return a *ConfirmationRequired instead of running unless
+// opts carries a valid confirmation token. If the tab has an open
+// transaction the script runs on its pinned connection (see
+// BeginTransaction).
+func (s *Service) RunQuery(ctx context.Context, connID, tabID, query string, opts QueryOptions) ([]StatementResult, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkReadOnly(cl, query); err != nil {
+		return nil, &QueryError{Err: err}
+	}
+	stmts, err := bindScript(parseScript(query), opts.Params)
+	if err != nil {
+		return nil, &QueryError{Err: err}
+	}
+	if err := s.checkDestructive(ctx, cl, connID, query, opts.Params, opts.ConfirmToken); err != nil {
+		return nil, err
+	}
+
+	sess, finish, err := s.tabSession(ctx, connID, tabID, query, cl)
 	if err != nil {
 		return nil, err
 	}
+	defer finish()
 
+	ctx, done := s.trackQuery(ctx, tabID, cl, sess)
+	defer done()
+
+	results := make([]StatementResult, len(stmts))
+	stop := false
+	for i, st := range stmts {
+		results[i].Statement = st.Source
+		if stop {
+			results[i].Skipped = true
+			continue
+		}
+
+		result, err := runStatement(ctx, sess, st, rowLimit(opts.MaxRows), s.canStopEarly(tabID, st))
+		if err != nil {
+			s.addHistory(connID, cl, st.Source, 0, 0, err)
+			results[i].Err = err
+			results[i].ErrorPosition = scriptErrorPosition(query, st, err)
+			stop = !opts.ContinueOnError || ctx.Err() != nil
+			continue
+		}
+		s.addHistory(connID, cl, st.Source, result.DurationMs, result.RowCount, nil)
+		results[i].Result = result
+	}
+	return results, nil
+}
+
+// runStatement executes a single script statement and buffers its result.
+// With stopEarly a truncated result is cancelled on the server rather than
+// read to the end (see canStopEarly).
+func runStatement(ctx context.Context, sess *client.Session, st boundStatement, maxRows int, stopEarly bool) (*client.QueryResult, error) {
+	if st.CopyData == nil {
+		rows, err := sess.QueryRows(ctx, st.Text, st.Args...)
+		if err != nil {
+			return nil, err
+		}
+		if stopEarly {
+			rows.StopEarly(sess.CancelStatement)
+		}
+		return rows.Collect(maxRows)
+	}
+	start := time.Now()
+	n, err := sess.CopyFrom(ctx, st.Text, *st.CopyData)
+	if err != nil {
+		return nil, err
+	}
+	return &client.QueryResult{
+		Columns: []string{}, ColumnTypes: []string{},
+		DurationMs:   time.Since(start).Milliseconds(),
+		RowsAffected: n, CommandTag: copyTag(n),
+	}, nil
+}
+
+// StreamQuery executes a script like RunQuery but delivers each statement's
+// rows one at a time through cb instead of buffering them. Delivery of a
+// statement's rows stops after opts.MaxRows rows (capped at MaxResultRows)
+// and its result is marked truncated. Statement failures, including ones
+// mid-stream, are reported through cb.Done; errors from the callbacks are
+// returned unwrapped and end the script.
+func (s *Service) StreamQuery(ctx context.Context, connID, tabID, query string, opts QueryOptions, cb StreamCallbacks) error {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkReadOnly(cl, query); err != nil {
+		return &QueryError{Err: err}
+	}
+	stmts, err := bindScript(parseScript(query), opts.Params)
+	if err != nil {
+		return &QueryError{Err: err}
+	}
+	if err := s.checkDestructive(ctx, cl, connID, query, opts.Params, opts.ConfirmToken); err != nil {
+		return err
+	}
+
+	sess, finish, err := s.tabSession(ctx, connID, tabID, query, cl)
+	if err != nil {
+		return err
+	}
+	defer finish()
+
+	ctx, done := s.trackQuery(ctx, tabID, cl, sess)
+	defer done()
+
+	stop := false
+	for i, st := range stmts {
+		if stop {
+			if err := cb.Done(i, &StreamResult{Skipped: true}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err := streamStatement(ctx, sess, i, st, rowLimit(opts.MaxRows), s.canStopEarly(tabID, st), cb)
+		if err != nil {
+			return err
+		}
+		if result.Err != nil {
+			s.addHistory(connID, cl, st.Source, 0, 0, result.Err)
+			result.ErrorPosition = scriptErrorPosition(query, st, result.Err)
+			stop = !opts.ContinueOnError || ctx.Err() != nil
+		} else {
+			s.addHistory(connID, cl, st.Source, result.DurationMs, result.RowCount, nil)
+		}
+		if err := cb.Done(i, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamStatement runs one statement, delivering its columns and rows
+// through cb. Query failures are returned in the result's Err; the error
+// return is reserved for callback failures. With stopEarly a truncated
+// result is cancelled on the server rather than read to the end (see
+// canStopEarly).
+func streamStatement(ctx context.Context, sess *client.Session, i int, st boundStatement, limit int, stopEarly bool, cb StreamCallbacks) (*StreamResult, error) {
+	if st.CopyData != nil {
+		start := time.Now()
+		if err := cb.Columns(i, []string{}, []string{}); err != nil {
+			return nil, err
+		}
+		n, err := sess.CopyFrom(ctx, st.Text, *st.CopyData)
+		if err != nil {
+			return &StreamResult{Err: err}, nil
+		}
+		return &StreamResult{
+			DurationMs:   time.Since(start).Milliseconds(),
+			RowsAffected: n, CommandTag: copyTag(n),
+		}, nil
+	}
+
+	rows, err := sess.QueryRows(ctx, st.Text, st.Args...)
+	if err != nil {
+		return &StreamResult{Err: err}, nil
+	}
+	if stopEarly {
+		rows.StopEarly(sess.CancelStatement)
+	}
+	defer rows.Close()
+
+	if err := cb.Columns(i, rows.Columns, rows.ColumnTypes); err != nil {
+		return nil, err
+	}
+
+	result := &StreamResult{}
+	for rows.Next() {
+		if result.RowCount >= limit {
+			result.Truncated = true
+			break
+		}
+		vals, err := rows.Values()
+		if err != nil {
+			return &StreamResult{Err: err}, nil
+		}
+		if err := cb.Row(i, vals); err != nil {
+			return nil, err
+		}
+		result.RowCount++
+	}
+	if err := rows.Err(); err != nil {
+		return &StreamResult{Err: err}, nil
+	}
+	rows.Close()
+	tag := rows.CommandTag()
+	result.DurationMs = rows.DurationMs()
+	result.RowsAffected = tag.RowsAffected
+	result.CommandTag = tag.String()
+	return result, nil
+}
+
+// canStopEarly reports whether a statement whose result is truncated may be
+// cancelled on the server instead of having its remaining rows drained. It
+// may if it only reads, so cancelling loses nothing, and the tab has no
+// transaction open, which the cancel would abort.
+func (s *Service) canStopEarly(tabID string, st boundStatement) bool {
+	if modifiesData(st.Source) {
+		return false
+	}
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	_, pinned := s.sessions[tabID]
+	return !pinned
+}
+
+// copyTag is the command tag of a COPY FROM STDIN that loaded n rows.
+func copyTag(n int64) string {
+	return client.CommandTag{Tag: "COPY", RowsAffected: n}.String()
+}
+
+// scriptErrorPosition converts the statement-relative error position the
+// server reports into a position in the whole script as written.
+func scriptErrorPosition(script string, st boundStatement, err error) int {
+	pos := client.ErrorPosition(err)
+	if pos == 0 {
+		return 0
+	}
+	return utf8.RuneCountInString(script[:st.Start]) + st.sourcePosition(pos)
+}
+
+// addHistory records one executed statement.
+func (s *Service) addHistory(connID string, cl *client.Client, sql string, durationMs int64, rowCount int, err error) {
+	if s.Repo == nil {
+		return
+	}
+	entry := repository.HistoryEntry{
+		SQL: sql, Connection: connKey(connID), Database: cl.Database(),
+		DurationMs: durationMs, RowCount: rowCount,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	s.Repo.AddHistoryEntry(entry)
+}
+
+// runningQuery is a query in flight in a tab.
+type runningQuery struct {
+	cancel context.CancelFunc
+	cl     *client.Client
+	pid    int // backend running the query, 0 if unknown
+	// signals counts pg_cancel_backend calls in flight. The query's session
+	// is held until they return, so its backend cannot be serving another
+	// query by the time a signal lands.
+	signals sync.WaitGroup
+}
+
+// trackQuery registers a cancellable context for tabID, cancelling any query
+// already running in that tab, and records the backend PID of sess so
+// CancelQuery can signal the server. The returned func must be called when
+// the query finishes and before sess is released; it waits for any cancel
+// signal still being sent, so a cancel never reaches a connection that has
+// gone back to the pool.
+func (s *Service) trackQuery(ctx context.Context, tabID string, cl *client.Client, sess *client.Session) (context.Context, func()) {
 	ctx, cancel := context.WithCancel(ctx)
+	rq := &runningQuery{cancel: cancel, cl: cl}
+	if pid, err := sess.BackendPID(ctx); err == nil {
+		rq.pid = pid
+	}
 
 	s.queryMu.Lock()
 	if prev, ok := s.running[tabID]; ok {
-		prev()
+		prev.cancel()
 	}
-	s.running[tabID] = cancel
+	s.running[tabID] = rq
 	s.queryMu.Unlock()
 
-	defer func() {
+	return ctx, func() {
 		s.queryMu.Lock()
-		delete(s.running, tabID)
+		if s.running[tabID] == rq {
+			delete(s.running, tabID)
+		}
 		s.queryMu.Unlock()
 		cancel()
-	}()
+		rq.signals.Wait()
+	}
+}
 
-	result, err := cl.QueryWithContext(ctx, query)
+// rowLimit clamps a requested row limit to MaxResultRows. Zero or negative
+// means "as many as allowed".
+func rowLimit(maxRows int) int {
+	if maxRows <= 0 || maxRows > MaxResultRows {
+		return MaxResultRows
+	}
+	return maxRows
+}
 
-	entry := repository.HistoryEntry{SQL: query, Database: cl.Database()}
+// ExplainOptions are the per-request settings for ExplainPlan.
+type ExplainOptions struct {
+	// Analyze executes the statement to collect actual rows, timing and
+	// buffer usage.
+	Analyze bool
+	Params  map[string]ParamValue
+	// KeepChanges lets an analyzed statement commit any changes it makes.
+	// By default it runs in a transaction that is rolled back.
+	KeepChanges bool
+	// ConfirmToken confirms a destructive statement run with KeepChanges.
+	ConfirmToken string
+}
+
+// ExplainPlan explains a single statement and returns its parsed plan tree.
+// Like a query, it runs on the tab's connection and can be cancelled with
+// CancelQuery. Invalid input and SQL errors are returned as a *QueryError.
+//
+// EXPLAIN ANALYZE executes the statement, so it runs in a transaction, or a
+// savepoint of the tab's transaction, that is rolled back afterwards: even a
+// SELECT can change data through the functions it calls. With
+// opts.KeepChanges it runs as is, and destructive statements need confirming
+// as in RunQuery.
+//
+// The plan is stored for later comparison, and its stored ID returned; the
+// ID is 0 if it could not be stored.
+func (s *Service) ExplainPlan(ctx context.Context, connID, tabID, query string, opts ExplainOptions) (*client.Plan, int, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
-		entry.Error = err.Error()
-		if s.Repo != nil {
-			s.Repo.AddHistoryEntry(entry)
+		return nil, 0, err
+	}
+	st, err := singleStatement(query, opts.Params)
+	if err != nil {
+		return nil, 0, &QueryError{Err: err}
+	}
+	// EXPLAIN ANALYZE executes the statement, so it is subject to read-only mode.
+	if opts.Analyze {
+		if err := checkReadOnly(cl, query); err != nil {
+			return nil, 0, &QueryError{Err: err}
 		}
-		return nil, &QueryError{Err: err}
+	}
+	if opts.Analyze && opts.KeepChanges {
+		if err := s.checkDestructive(ctx, cl, connID, query, opts.Params, opts.ConfirmToken); err != nil {
+			return nil, 0, err
+		}
+	}
+	explainOpts := client.ExplainOptions{
+		Analyze:  opts.Analyze,
+		Rollback: !opts.KeepChanges,
 	}
 
-	entry.DurationMs = result.DurationMs
-	entry.RowCount = result.RowCount
-	if s.Repo != nil {
-		s.Repo.AddHistoryEntry(entry)
+	sess, finish, err := s.tabSession(ctx, connID, tabID, query, cl)
+	if err != nil {
+		return nil, 0, err
 	}
+	defer finish()
 
-	return result, nil
+	ctx, done := s.trackQuery(ctx, tabID, cl, sess)
+	defer done()
+
+	plan, err := sess.Explain(ctx, st.Text, explainOpts, st.Args...)
+	if err != nil {
+		return nil, 0, &QueryError{Err: err}
+	}
+	return plan, s.storePlan(connID, cl, st.Source, plan), nil
 }
 
-func (s *Service) ExplainQuery(ctx context.Context, query string) (*client.QueryResult, error) {
-	cl, err := s.requireClient()
+// ExplainQuery returns the text plan of query, which must be a single
+// statement. The statement is only planned: options that would execute it,
+// such as ANALYZE, are rejected, as AnalyzeQuery is the way to run one.
+// Invalid input is returned as a *QueryError.
+func (s *Service) ExplainQuery(ctx context.Context, connID, query string) (*client.QueryResult, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	st, err := singleStatement(query, nil)
 	if err != nil {
+		return nil, &QueryError{Err: err}
+	}
+	explain := "EXPLAIN " + st.Text
+	if analyze, _ := explainOptions(tokenizeSQL(explain)[1:]); analyze {
+		return nil, &QueryError{Err: errors.New("EXPLAIN ANALYZE executes the statement; use analyze instead")}
+	}
+	if err := checkReadOnly(cl, explain); err != nil {
 		return nil, err
 	}
-	return cl.QueryWithContext(ctx, "EXPLAIN "+query)
+	return cl.QueryWithContext(ctx, explain)
 }
 
-func (s *Service) AnalyzeQuery(ctx context.Context, query string) (*client.QueryResult, error) {
-	cl, err := s.requireClient()
+// singleStatement parses and binds query, which must be one statement
+// without COPY data, as EXPLAIN takes.
+func singleStatement(query string, params map[string]ParamValue) (boundStatement, error) {
+	stmts, err := bindScript(parseScript(query), params)
 	if err != nil {
-		return nil, err
+		return boundStatement{}, err
+	}
+	if len(stmts) != 1 || stmts[0].CopyData != nil {
+		return boundStatement{}, errors.New("EXPLAIN takes a single statement")
 	}
-	return cl.QueryWithContext(ctx, "EXPLAIN ANALYZE "+query)
+	return stmts[0], nil
 }
 
-func (s *Service) CancelQuery(tabID string) {
+// AnalyzeQuery runs EXPLAIN ANALYZE on query, which must be a single
+// statement, and returns the text plan. The statement runs in a transaction
+// that is rolled back, which the returned bool reports. With keepChanges it
+// runs as is, and destructive statements need confirmToken as in RunQuery.
+// Invalid input is returned as a *QueryError.
+func (s *Service) AnalyzeQuery(ctx context.Context, connID, query string, keepChanges bool, confirmToken string) (*client.QueryResult, bool, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, false, err
+	}
+	st, err := singleStatement(query, nil)
+	if err != nil {
+		return nil, false, &QueryError{Err: err}
+	}
+	// EXPLAIN ANALYZE executes the statement, so it is subject to read-only mode.
+	if err := checkReadOnly(cl, query); err != nil {
+		return nil, false, err
+	}
+	if keepChanges {
+		if err := s.checkDestructive(ctx, cl, connID, query, nil, confirmToken); err != nil {
+			return nil, false, err
+		}
+	}
+	if keepChanges {
+		result, err := cl.QueryWithContext(ctx, "EXPLAIN ANALYZE "+st.Text)
+		return result, false, err
+	}
+
+	sess, err := cl.Session(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer sess.Release()
+	var result *client.QueryResult
+	err = sess.RollbackAfter(ctx, func() error {
+		var err error
+		result, err = sess.QueryWithLimit(ctx, "EXPLAIN ANALYZE "+st.Text, 0)
+		return err
+	})
+	return result, true, err
+}
+
+// modifiesData reports whether any statement in query may change data or
+// schema when executed, i.e. is not a plain read.
+func modifiesData(query string) bool {
+	for _, stmt := range splitStatements(query) {
+		if kind, _ := classifyTokens(tokenizeSQL(stmt)); kind != StatementRead {
+			return true
+		}
+	}
+	return false
+}
+
+// CancelResult reports the outcome of CancelQuery.
+type CancelResult struct {
+	Running          bool // a query was running in the tab
+	PID              int  // backend that was running it, 0 if unknown
+	BackendCancelled bool // the server accepted pg_cancel_backend
+}
+
+// cancelTimeout bounds the pg_cancel_backend call, which needs a free pool
+// connection.
+const cancelTimeout = 5 * time.Second
+
+// CancelQuery stops the query running in tabID. The server is asked to cancel
+// it with pg_cancel_backend so the outcome is confirmed, and the query's
+// context is cancelled as well.
+func (s *Service) CancelQuery(ctx context.Context, tabID string) CancelResult {
+	// Registering the signal under queryMu keeps the query's session from
+	// being released, and its backend reused by another query, until the
+	// signal has been sent (see trackQuery). The signal itself is sent
+	// without the lock, as it waits for a free pool connection.
 	s.queryMu.Lock()
-	if cancel, ok := s.running[tabID]; ok {
-		cancel()
+	rq, ok := s.running[tabID]
+	if ok {
+		rq.signals.Add(1)
 	}
 	s.queryMu.Unlock()
+	if !ok {
+		return CancelResult{}
+	}
+	defer rq.signals.Done()
+
+	result := CancelResult{Running: true, PID: rq.pid}
+	if rq.pid != 0 {
+		ctx, cancel := context.WithTimeout(ctx, cancelTimeout)
+		cancelled, err := rq.cl.CancelBackend(ctx, rq.pid)
+		cancel()
+		if err != nil {
+			slog.Warn("pg_cancel_backend failed", "pid", rq.pid, "err", err)
+		}
+		result.BackendCancelled = cancelled
+	}
+	rq.cancel()
+	return result
+}
+
+// CancelBackend cancels the current query of any backend, e.g. one listed by
+// Activity. It is not allowed on read-only connections.
+func (s *Service) CancelBackend(ctx context.Context, connID string, pid int) (bool, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return false, err
+	}
+	if cl.ReadOnly() {
+		return false, fmt.Errorf("%w: cannot cancel other sessions", ErrReadOnly)
+	}
+	return cl.CancelBackend(ctx, pid)
+}
+
+// TerminateBackend ends the session of any backend. It is not allowed on
+// read-only connections.
+func (s *Service) TerminateBackend(ctx context.Context, connID string, pid int) (bool, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return false, err
+	}
+	if cl.ReadOnly() {
+		return false, fmt.Errorf("%w: cannot terminate other sessions", ErrReadOnly)
+	}
+	return cl.TerminateBackend(ctx, pid)
 }
 
-func (s *Service) ExportQuery(ctx context.Context, query string) (*client.QueryResult, error) {
-	cl, err := s.requireClient()
+// ExportQuery executes query and returns a cursor over its rows so exports can
+// stream without buffering. Destructive statements need confirmToken, as for
+// RunQuery. The caller must Close the returned Rows.
+func (s *Service) ExportQuery(ctx context.Context, connID, query, confirmToken string) (*client.Rows, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
-	return cl.QueryWithContext(ctx, query)
+	if err := checkReadOnly(cl, query); err != nil {
+		return nil, err
+	}
+	if err := s.checkDestructive(ctx, cl, connID, query, nil, confirmToken); err != nil {
+		return nil, err
+	}
+	return cl.QueryRows(ctx, query)
 }
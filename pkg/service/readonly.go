@@ -0,0 +1,250 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/macleodmac/pglet/pkg/client"
+)
+
+// StatementKind is the broad category of a SQL statement.
+type StatementKind int
+
+const (
+	StatementRead    StatementKind = iota // SELECT, SHOW, EXPLAIN without ANALYZE
+	StatementWrite                        // INSERT, UPDATE, DELETE, MERGE, COPY, SELECT INTO
+	StatementDDL                          // CREATE, ALTER, DROP, TRUNCATE, GRANT, maintenance
+	StatementControl                      // transaction control and session settings
+	StatementOther                        // anything not recognised
+)
+
+func (k StatementKind) String() string {
+	switch k {
+	case StatementRead:
+		return "read"
+	case StatementWrite:
+		return "write"
+	case StatementDDL:
+		return "DDL"
+	case StatementControl:
+		return "control"
+	}
+	return "other"
+}
+
+var statementKinds = map[string]StatementKind{
+	"SELECT": StatementRead, "WITH": StatementRead, "VALUES": StatementRead,
+	"TABLE": StatementRead, "SHOW": StatementRead, "DECLARE": StatementRead,
+
+	"INSERT": StatementWrite, "UPDATE": StatementWrite, "DELETE": StatementWrite,
+	"MERGE": StatementWrite, "COPY": StatementWrite, "CALL": StatementWrite,
+	"DO": StatementWrite, "LOCK": StatementWrite, "NOTIFY": StatementWrite,
+
+	"CREATE": StatementDDL, "ALTER": StatementDDL, "DROP": StatementDDL,
+	"TRUNCATE": StatementDDL, "GRANT": StatementDDL, "REVOKE": StatementDDL,
+	"COMMENT": StatementDDL, "REINDEX": StatementDDL, "CLUSTER": StatementDDL,
+	"VACUUM": StatementDDL, "ANALYZE": StatementDDL, "ANALYSE": StatementDDL,
+	"REFRESH": StatementDDL, "SECURITY": StatementDDL, "IMPORT": StatementDDL,
+	"REASSIGN": StatementDDL,
+
+	"BEGIN": StatementControl, "START": StatementControl, "COMMIT": StatementControl,
+	"END": StatementControl, "ROLLBACK": StatementControl, "ABORT": StatementControl,
+	"SAVEPOINT": StatementControl, "RELEASE": StatementControl, "SET": StatementControl,
+	"RESET": StatementControl, "DISCARD": StatementControl, "DEALLOCATE": StatementControl,
+	"FETCH": StatementControl, "MOVE": StatementControl, "CLOSE": StatementControl,
+	"LISTEN": StatementControl, "UNLISTEN": StatementControl,
+}
+
+// writeKeywords mark an otherwise read-only statement as modifying data:
+// data-modifying CTEs, SELECT INTO and row locking clauses.
+var writeKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "INTO": true,
+}
+
+// classifyTokens returns the kind of a single tokenized statement and its
+// leading keyword. EXPLAIN ANALYZE is classified by the statement it executes.
+func classifyTokens(tokens []sqlToken) (StatementKind, string) {
+	// Skip parentheses wrapping a query, e.g. "(SELECT 1) UNION (SELECT 2)".
+	for len(tokens) > 0 && tokens[0].Text == "(" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 || tokens[0].Kind != tokWord {
+		return StatementOther, ""
+	}
+	verb := tokens[0].upper()
+
+	switch verb {
+	case "EXPLAIN":
+		return classifyExplain(tokens[1:])
+	case "PREPARE":
+		for i, t := range tokens {
+			if t.Kind == tokWord && t.upper() == "AS" {
+				return classifyTokens(tokens[i+1:])
+			}
+		}
+		return StatementOther, verb
+	}
+
+	kind, ok := statementKinds[verb]
+	if !ok {
+		return StatementOther, verb
+	}
+	if kind == StatementRead {
+		for _, t := range tokens[1:] {
+			if t.Kind == tokWord && writeKeywords[t.upper()] {
+				return StatementWrite, verb
+			}
+		}
+	}
+	return kind, verb
+}
+
+// classifyExplain classifies the tokens following EXPLAIN. Without ANALYZE
+// the statement is only planned, so it is a read whatever it is.
+func classifyExplain(tokens []sqlToken) (StatementKind, string) {
+	analyze, tokens := explainOptions(tokens)
+	if !analyze {
+		return StatementRead, "EXPLAIN"
+	}
+	return classifyTokens(tokens)
+}
+
+// explainOptions reads the options following EXPLAIN, in either the
+// parenthesised or the bare form. It reports whether they enable ANALYZE
+// and returns the tokens of the explained statement.
+func explainOptions(tokens []sqlToken) (bool, []sqlToken) {
+	analyze := false
+	if len(tokens) > 0 && tokens[0].Text == "(" {
+		depth := 0
+		for i, t := range tokens {
+			switch {
+			case t.Text == "(":
+				depth++
+			case t.Text == ")":
+				depth--
+			case t.Kind == tokWord && (t.upper() == "ANALYZE" || t.upper() == "ANALYSE"):
+				analyze = !isFalseOption(tokens, i+1)
+			}
+			if depth == 0 {
+				tokens = tokens[i+1:]
+				break
+			}
+		}
+	} else {
+	options:
+		for len(tokens) > 0 && tokens[0].Kind == tokWord {
+			switch tokens[0].upper() {
+			case "ANALYZE", "ANALYSE":
+				analyze = true
+			case "VERBOSE":
+			default:
+				break options
+			}
+			tokens = tokens[1:]
+		}
+	}
+	return analyze, tokens
+}
+
+// isFalseOption reports whether the EXPLAIN option value at tokens[i]
+// disables the option.
+func isFalseOption(tokens []sqlToken, i int) bool {
+	if i >= len(tokens) {
+		return false
+	}
+	switch tokens[i].upper() {
+	case "FALSE", "OFF", "0":
+		return true
+	}
+	return false
+}
+
+// liftsReadOnly reports whether a control statement could switch the session
+// or transaction back to read-write, e.g. BEGIN READ WRITE or
+// SET default_transaction_read_only = off.
+func liftsReadOnly(tokens []sqlToken) bool {
+	for _, t := range tokens {
+		if t.Kind != tokWord && t.Kind != tokIdent {
+			continue
+		}
+		switch t.upper() {
+		case "WRITE", "TRANSACTION_READ_ONLY", "DEFAULT_TRANSACTION_READ_ONLY",
+			`"TRANSACTION_READ_ONLY"`, `"DEFAULT_TRANSACTION_READ_ONLY"`:
+			return true
+		}
+	}
+	return false
+}
+
+// unsafeFunctions have effects that default_transaction_read_only does not
+// stop: changing settings (including default_transaction_read_only itself),
+// signalling backends, reloading or resetting server state, and reading or
+// writing server files.
+var unsafeFunctions = map[string]bool{
+	"SET_CONFIG": true, "PG_CANCEL_BACKEND": true, "PG_TERMINATE_BACKEND": true,
+	"PG_RELOAD_CONF": true, "PG_ROTATE_LOGFILE": true, "PG_LOG_BACKEND_MEMORY_CONTEXTS": true,
+	"PG_SWITCH_WAL": true, "PG_CREATE_RESTORE_POINT": true, "PG_PROMOTE": true,
+	"PG_BACKUP_START": true, "PG_BACKUP_STOP": true, "PG_START_BACKUP": true, "PG_STOP_BACKUP": true,
+	"PG_CREATE_PHYSICAL_REPLICATION_SLOT": true, "PG_CREATE_LOGICAL_REPLICATION_SLOT": true,
+	"PG_DROP_REPLICATION_SLOT": true, "PG_COPY_PHYSICAL_REPLICATION_SLOT": true,
+	"PG_COPY_LOGICAL_REPLICATION_SLOT": true, "PG_REPLICATION_SLOT_ADVANCE": true,
+	"PG_LOGICAL_SLOT_GET_CHANGES": true, "PG_LOGICAL_SLOT_GET_BINARY_CHANGES": true,
+	"PG_LOGICAL_EMIT_MESSAGE": true, "PG_REPLICATION_ORIGIN_CREATE": true,
+	"PG_REPLICATION_ORIGIN_DROP": true, "PG_WAL_REPLAY_PAUSE": true, "PG_WAL_REPLAY_RESUME": true,
+	"PG_STAT_RESET": true, "PG_STAT_RESET_SHARED": true, "PG_STAT_RESET_SINGLE_TABLE_COUNTERS": true,
+	"PG_STAT_RESET_SINGLE_FUNCTION_COUNTERS": true, "PG_STAT_RESET_SLRU": true,
+	"PG_STAT_RESET_REPLICATION_SLOT": true, "PG_STAT_RESET_SUBSCRIPTION_STATS": true,
+	"PG_STAT_STATEMENTS_RESET": true, "PG_FILE_WRITE": true, "PG_FILE_RENAME": true,
+	"PG_FILE_UNLINK": true, "PG_FILE_SYNC": true, "LO_IMPORT": true, "LO_EXPORT": true,
+	"DBLINK_EXEC": true, "DBLINK_CONNECT": true, "DBLINK_CONNECT_U": true,
+}
+
+// unsafeCall returns the first function in tokens that is in unsafeFunctions,
+// matched by name whatever its schema or quoting.
+func unsafeCall(tokens []sqlToken) string {
+	for i := 0; i+1 < len(tokens); i++ {
+		t := tokens[i]
+		if tokens[i+1].Text != "(" || (t.Kind != tokWord && t.Kind != tokIdent) {
+			continue
+		}
+		name := t.upper()
+		if t.Kind == tokIdent {
+			name = strings.ReplaceAll(strings.Trim(name, `"`), `""`, `"`)
+		}
+		if unsafeFunctions[name] {
+			return strings.ToLower(name)
+		}
+	}
+	return ""
+}
+
+// checkReadOnly rejects query if cl is read-only and any statement in it is
+// not a plain read or harmless session control. The server enforces
+// default_transaction_read_only as well; this check gives a clear error up
+// front and also covers statements the server would allow, such as
+// switching the session back to read-write or calling set_config.
+func checkReadOnly(cl *client.Client, query string) error {
+	if !cl.ReadOnly() {
+		return nil
+	}
+	for _, stmt := range splitStatements(query) {
+		tokens := tokenizeSQL(stmt)
+		if fn := unsafeCall(tokens); fn != "" {
+			return fmt.Errorf("%w: %s() is not allowed", ErrReadOnly, fn)
+		}
+		kind, verb := classifyTokens(tokens)
+		switch kind {
+		case StatementRead:
+			continue
+		case StatementControl:
+			if !liftsReadOnly(tokens) {
+				continue
+			}
+		}
+		if verb == "" {
+			return fmt.Errorf("%w: statement not allowed", ErrReadOnly)
+		}
+		return fmt.Errorf("%w: %s statements are not allowed", ErrReadOnly, verb)
+	}
+	return nil
+}
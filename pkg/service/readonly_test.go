@@ -0,0 +1,107 @@
+package service
+
+import "testing"
+
+func TestClassifyTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		kind StatementKind
+		verb string
+	}{
+		{"select", "select 1", StatementRead, "SELECT"},
+		{"parenthesised union", "(select 1) union (select 2)", StatementRead, "SELECT"},
+		{"keyword in string", "select 'delete from t'", StatementRead, "SELECT"},
+		{"keyword as quoted identifier", `select "update" from t`, StatementRead, "SELECT"},
+		{"select into", "select * into t2 from t", StatementWrite, "SELECT"},
+		{"row locking", "select * from t for update", StatementWrite, "SELECT"},
+		{"read-only CTE", "with x as (select 1) select * from x", StatementRead, "WITH"},
+		{"deleting CTE", "with d as (delete from t returning *) select * from d", StatementWrite, "WITH"},
+		{"inserting CTE", "with i as (insert into t values (1) returning id) select id from i", StatementWrite, "WITH"},
+		{"CTE before update", "with x as (select 1) update t set a = 1", StatementWrite, "WITH"},
+		{"delete", "delete from t where id = 1", StatementWrite, "DELETE"},
+		{"do block", "do $$ begin delete from t; end $$", StatementWrite, "DO"},
+		{"drop cascade", "drop table t cascade", StatementDDL, "DROP"},
+		{"set", "set search_path = public", StatementControl, "SET"},
+		{"begin", "begin", StatementControl, "BEGIN"},
+		{"unknown", "frobnicate t", StatementOther, "FROBNICATE"},
+		{"not a statement", "'x'", StatementOther, ""},
+
+		{"explain", "explain delete from t", StatementRead, "EXPLAIN"},
+		{"explain analyze", "explain analyze delete from t", StatementWrite, "DELETE"},
+		{"explain analyze verbose", "explain analyze verbose update t set a = 1", StatementWrite, "UPDATE"},
+		{"explain analyse select", "explain analyse select 1", StatementRead, "SELECT"},
+		{"explain options without analyze", "explain (costs off, verbose) delete from t", StatementRead, "EXPLAIN"},
+		{"explain (analyze)", "explain (analyze) delete from t", StatementWrite, "DELETE"},
+		{"explain (analyze true)", "explain (analyze true, buffers) delete from t", StatementWrite, "DELETE"},
+		{"explain (analyze false)", "explain (analyze false) delete from t", StatementRead, "EXPLAIN"},
+		{"explain (analyze off)", "explain (buffers, analyze off) delete from t", StatementRead, "EXPLAIN"},
+		{"explain (analyze 0)", "explain (analyze 0) delete from t", StatementRead, "EXPLAIN"},
+		{"explain analyze deleting CTE", "explain analyze with d as (delete from t returning *) select * from d", StatementWrite, "WITH"},
+
+		{"prepare select", "prepare p (int) as select $1", StatementRead, "SELECT"},
+		{"prepare delete", "prepare p as delete from t", StatementWrite, "DELETE"},
+		{"prepare deleting CTE", "prepare p as with d as (delete from t returning *) select 1", StatementWrite, "WITH"},
+		{"prepare without AS", "prepare p", StatementOther, "PREPARE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, verb := classifyTokens(tokenizeSQL(tt.sql))
+			if kind != tt.kind || verb != tt.verb {
+				t.Errorf("classifyTokens(%q) = %v %q, want %v %q", tt.sql, kind, verb, tt.kind, tt.verb)
+			}
+		})
+	}
+}
+
+func TestUnsafeCall(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{"set_config", "select set_config('default_transaction_read_only', 'off', false)", "set_config"},
+		{"upper case", "SELECT SET_CONFIG('a', 'b', false)", "set_config"},
+		{"schema-qualified", "select pg_catalog.set_config('a', 'b', false)", "set_config"},
+		{"quoted", `select "set_config"('a', 'b', false)`, "set_config"},
+		{"quoted and qualified", `select "pg_catalog"."set_config"('a', 'b', false)`, "set_config"},
+		{"nested in expression", "select coalesce(pg_terminate_backend(pid), false) from pg_stat_activity", "pg_terminate_backend"},
+		{"in a CTE", "with x as (select pg_reload_conf()) select * from x", "pg_reload_conf"},
+		{"safe function", "select current_setting('search_path')", ""},
+		{"column named like a function", "select set_config from t", ""},
+		{"name in string", "select 'set_config(1)'", ""},
+		{"name in comment", "select 1 /* set_config() */", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unsafeCall(tokenizeSQL(tt.sql)); got != tt.want {
+				t.Errorf("unsafeCall(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiftsReadOnly(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want bool
+	}{
+		{"begin", false},
+		{"begin read only", false},
+		{"begin read write", true},
+		{"start transaction isolation level serializable, read write", true},
+		{"set transaction read write", true},
+		{"set session characteristics as transaction read write", true},
+		{"set default_transaction_read_only = off", true},
+		{`set "default_transaction_read_only" to off`, true},
+		{"set transaction_read_only to off", true},
+		{"reset default_transaction_read_only", true},
+		{"set search_path = public", false},
+		{"set application_name = 'write'", false},
+	}
+	for _, tt := range tests {
+		if got := liftsReadOnly(tokenizeSQL(tt.sql)); got != tt.want {
+			t.Errorf("liftsReadOnly(%q) = %v, want %v", tt.sql, got, tt.want)
+		}
+	}
+}
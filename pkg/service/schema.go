@@ -6,90 +6,201 @@ import (
 	"github.com/macleodmac/pglet/pkg/client"
 )
 
-func (s *Service) Schemas() ([]string, error) {
-	cl, err := s.requireClient()
+func (s *Service) Schemas(connID string) ([]string, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.Schemas()
 }
 
-func (s *Service) Objects() (map[string]*client.SchemaGroup, error) {
-	cl, err := s.requireClient()
+func (s *Service) Objects(connID string) (map[string]*client.SchemaGroup, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.Objects()
 }
 
-func (s *Service) TableColumns(table string) ([]client.Column, error) {
-	cl, err := s.requireClient()
+func (s *Service) TableColumns(connID, table string) ([]client.Column, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.TableColumns(table)
 }
 
-func (s *Service) TableRows(ctx context.Context, table string, limit, offset int, sortCol, sortOrd string) (*client.QueryResult, int, error) {
-	cl, err := s.requireClient()
+func (s *Service) TableRows(ctx context.Context, connID, table string, limit, offset int, sortCol, sortOrd string) (*client.QueryResult, int, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, 0, err
 	}
 	return cl.TableRows(ctx, table, limit, offset, sortCol, sortOrd)
 }
 
-func (s *Service) TableInfo(table string) (*client.TableInfo, error) {
-	cl, err := s.requireClient()
+func (s *Service) TableInfo(connID, table string) (*client.TableInfo, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.TableInfo(table)
 }
 
-func (s *Service) TableIndexes(table string) ([]client.TableIndex, error) {
-	cl, err := s.requireClient()
+func (s *Service) TableIndexes(connID, table string) ([]client.TableIndex, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.TableIndexes(table)
 }
 
-func (s *Service) TableConstraints(table string) ([]client.TableConstraint, error) {
-	cl, err := s.requireClient()
+func (s *Service) TableConstraints(connID, table string) ([]client.TableConstraint, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.TableConstraints(table)
 }
 
-func (s *Service) FunctionDefinition(schema, name string) (*client.FunctionDefinition, error) {
-	cl, err := s.requireClient()
+func (s *Service) TableTriggers(ctx context.Context, connID, table string) ([]client.Trigger, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.TableTriggers(ctx, table)
+}
+
+// TablePolicies returns a table's row-level security policies and whether
+// row-level security is enabled and forced on it.
+func (s *Service) TablePolicies(ctx context.Context, connID, table string) ([]client.Policy, bool, bool, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, false, false, err
+	}
+	return cl.TablePolicies(ctx, table)
+}
+
+func (s *Service) PartitionTree(ctx context.Context, connID, table string) (*client.PartitionNode, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.PartitionTree(ctx, table)
+}
+
+func (s *Service) Extension(ctx context.Context, connID, name string) (*client.Extension, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Extension(ctx, name)
+}
+
+func (s *Service) Domain(ctx context.Context, connID, schema, name string) (*client.Domain, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Domain(ctx, schema, name)
+}
+
+func (s *Service) TypeDetail(ctx context.Context, connID, schema, name string) (*client.TypeDetail, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.TypeDetail(ctx, schema, name)
+}
+
+func (s *Service) ForeignTable(ctx context.Context, connID, table string) (*client.ForeignTable, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.ForeignTable(ctx, table)
+}
+
+func (s *Service) Publication(ctx context.Context, connID, name string) (*client.Publication, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Publication(ctx, name)
+}
+
+func (s *Service) Subscription(ctx context.Context, connID, name string) (*client.Subscription, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Subscription(ctx, name)
+}
+
+func (s *Service) FunctionDefinition(connID, schema, name string) (*client.FunctionDefinition, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.FunctionDefinition(schema, name)
 }
 
-func (s *Service) TablesStats() (*client.QueryResult, error) {
-	cl, err := s.requireClient()
+func (s *Service) ObjectDDL(ctx context.Context, connID, kind, schema, name string) (string, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return "", err
+	}
+	return cl.ObjectDDL(ctx, kind, schema, name)
+}
+
+// RelationshipGraph returns the foreign key graph of schema or, if table is
+// set, of the tables within hops foreign keys of it.
+func (s *Service) RelationshipGraph(ctx context.Context, connID, schema, table string, hops int) (*client.RelationshipGraph, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	if table != "" {
+		return cl.NeighbourhoodGraph(ctx, schema, table, hops)
+	}
+	return cl.SchemaGraph(ctx, schema)
+}
+
+func (s *Service) TablesStats(connID string) (*client.QueryResult, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.TablesStats()
 }
 
-func (s *Service) Activity() ([]client.Activity, error) {
-	cl, err := s.requireClient()
+func (s *Service) Activity(connID string) ([]client.Activity, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.Activity()
 }
 
-func (s *Service) ServerSettings() (*client.QueryResult, error) {
-	cl, err := s.requireClient()
+func (s *Service) BlockingTree(ctx context.Context, connID string) ([]*client.BlockingSession, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.BlockingTree(ctx)
+}
+
+func (s *Service) ServerSettings(connID string) (*client.QueryResult, error) {
+	cl, err := s.requireClient(connID)
 	if err != nil {
 		return nil, err
 	}
 	return cl.ServerSettings()
 }
+
+func (s *Service) Advise(ctx context.Context, connID string) (*client.AdvisorReport, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	return cl.Advise(ctx)
+}
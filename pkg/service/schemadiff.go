@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/macleodmac/pglet/pkg/client"
+)
+
+// SchemaSide is one side of a schema diff: the database of an open
+// connection, or another database on the same server.
+type SchemaSide struct {
+	ConnID   string
+	Database string // empty for the connection's current database
+}
+
+// DiffSchemas compares schema in two databases and returns the changes that
+// turn source into target.
+func (s *Service) DiffSchemas(ctx context.Context, schema string, source, target SchemaSide) (*client.SchemaDiff, error) {
+	from, err := s.catalog(ctx, schema, source)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.catalog(ctx, schema, target)
+	if err != nil {
+		return nil, err
+	}
+	return client.DiffCatalogs(from, to), nil
+}
+
+// catalog reads schema from side. A database other than the connection's
+// current one is read over a temporary connection, leaving the open one
+// as it is.
+func (s *Service) catalog(ctx context.Context, schema string, side SchemaSide) (*client.Catalog, error) {
+	cl, err := s.requireClient(side.ConnID)
+	if err != nil {
+		return nil, err
+	}
+	if side.Database != "" && side.Database != cl.Database() {
+		cl, err = cl.SwitchDatabase(side.Database)
+		if err != nil {
+			return nil, err
+		}
+		defer cl.Close()
+	}
+	return cl.Catalog(ctx, schema)
+}
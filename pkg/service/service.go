@@ -2,72 +2,147 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"os"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/macleodmac/pglet/pkg/client"
 	"github.com/macleodmac/pglet/pkg/repository"
 )
 
+// DefaultConnection is the connection ID used when a request does not name one.
+const DefaultConnection = "default"
+
 var (
 	ErrNotConnected = errors.New("not connected to database")
 	ErrNoAPIKey     = errors.New("AI API key not configured, set ANTHROPIC_API_KEY environment variable")
+	ErrReadOnly     = errors.New("connection is read-only")
+	// ErrSnapshotMismatch is returned when comparing snapshots, or a snapshot
+	// and a live connection, that belong to different databases.
+	ErrSnapshotMismatch = errors.New("snapshots are of different databases")
 )
 
 // Service holds all shared state and provides business logic methods.
 // Methods are namespaced by the file they live in (connection.go, schema.go, etc.).
+//
+// Open connections are kept in a registry keyed by connection ID, so several
+// servers or databases can be browsed side by side. Methods that talk to the
+// database take the connection ID as their first argument; an empty ID means
+// DefaultConnection.
 type Service struct {
 	mu      sync.RWMutex
-	client  *client.Client
-	Repo    *repository.Repository
-	Version string
+	clients map[string]*client.Client
+	// snapshots cancels the on-connect schema snapshot of a client still
+	// reading it, so the snapshot stops when the client is swapped out.
+	snapshots map[*client.Client]context.CancelFunc
+	Repo      *repository.Repository
+	Version   string
+
+	// ReadOnly forces every connection into read-only mode (--read-only).
+	ReadOnly bool
+	// MaxConns is the connection pool size of each connection (--max-conns);
+	// zero means client.DefaultMaxConns.
+	MaxConns int
 
 	queryMu sync.Mutex
-	running map[string]context.CancelFunc
+	running map[string]*runningQuery
+
+	// sessions holds connections pinned to tabs with an open transaction,
+	// keyed by tab ID like running.
+	txMu          sync.Mutex
+	sessions      map[string]*tabSession
+	IdleTxTimeout time.Duration
+
+	// confirmKey signs confirmation tokens for destructive statements. It is
+	// regenerated on every start, so tokens do not survive a restart.
+	confirmKey []byte
 }
 
 func New(repo *repository.Repository, version string) *Service {
+	key := make([]byte, 32)
+	rand.Read(key)
 	return &Service{
-		Repo:    repo,
-		Version: version,
-		running: make(map[string]context.CancelFunc),
+		clients:    make(map[string]*client.Client),
+		snapshots:  make(map[*client.Client]context.CancelFunc),
+		Repo:       repo,
+		Version:    version,
+		running:    make(map[string]*runningQuery),
+		sessions:   make(map[string]*tabSession),
+		confirmKey: key,
+
+		IdleTxTimeout: DefaultIdleTxTimeout,
+	}
+}
+
+// connKey normalises an empty connection ID to DefaultConnection.
+func connKey(id string) string {
+	if id == "" {
+		return DefaultConnection
 	}
+	return id
 }
 
-func (s *Service) SetClient(cl *client.Client) {
+func (s *Service) SetClient(id string, cl *client.Client) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.client = cl
+	s.clients[connKey(id)] = cl
 }
 
-func (s *Service) GetClient() *client.Client {
+func (s *Service) GetClient(id string) *client.Client {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.client
+	return s.clients[connKey(id)]
 }
 
-func (s *Service) SwapClient(cl *client.Client) {
+// SwapClient replaces the client registered under id, closing the previous one.
+// A nil client removes the connection from the registry.
+func (s *Service) SwapClient(id string, cl *client.Client) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.client != nil {
-		s.client.Close()
+	key := connKey(id)
+	if prev, ok := s.clients[key]; ok && prev != nil {
+		if cancel, ok := s.snapshots[prev]; ok {
+			cancel()
+			delete(s.snapshots, prev)
+		}
+		s.closeSessions(key)
+		prev.Close()
+	}
+	if cl == nil {
+		delete(s.clients, key)
+		return
+	}
+	s.clients[key] = cl
+}
+
+// ConnectionIDs returns the IDs of all open connections in sorted order.
+func (s *Service) ConnectionIDs() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.clients))
+	for id := range s.clients {
+		ids = append(ids, id)
 	}
-	s.client = cl
+	sort.Strings(ids)
+	return ids
 }
 
-// requireClient returns the current client or ErrNotConnected.
-func (s *Service) requireClient() (*client.Client, error) {
-	cl := s.GetClient()
+// requireClient returns the client registered under id or ErrNotConnected.
+func (s *Service) requireClient(id string) (*client.Client, error) {
+	cl := s.GetClient(id)
 	if cl == nil {
 		return nil, ErrNotConnected
 	}
 	return cl, nil
 }
 
-// AppInfo returns the app version and whether AI is enabled.
-func (s *Service) AppInfo() (version string, aiEnabled bool) {
-	return s.Version, os.Getenv("ANTHROPIC_API_KEY") != ""
+// AppInfo returns the app version, whether AI is enabled and whether the
+// server was started in read-only mode.
+func (s *Service) AppInfo() (version string, aiEnabled, readOnly bool) {
+	return s.Version, os.Getenv("ANTHROPIC_API_KEY") != "", s.ReadOnly
 }
 
 // QueryError wraps a query execution error. The handler uses this to return
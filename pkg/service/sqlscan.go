@@ -0,0 +1,264 @@
+package service
+
+import "strings"
+
+type tokenKind int
+
+const (
+	tokWord      tokenKind = iota // keyword or bare identifier, including numbers
+	tokIdent                      // "quoted identifier"
+	tokString                     // 'literal', E'literal' or $tag$literal$tag$
+	tokParam                      // $1
+	tokSemicolon                  // ;
+	tokOther                      // any other punctuation
+)
+
+// sqlToken is a lexical token of a SQL script. Whitespace and comments are
+// dropped. Start and End are byte offsets into the scanned text.
+type sqlToken struct {
+	Kind  tokenKind
+	Text  string
+	Start int
+	End   int
+}
+
+// upper returns the token text upper-cased, for keyword comparison.
+func (t sqlToken) upper() string {
+	return strings.ToUpper(t.Text)
+}
+
+// sqlScanner reads tokens from SQL text one at a time. It understands the
+// lexical structure needed to find statement boundaries safely: single- and
+// double-quoted strings, E-prefixed escape strings, dollar-quoted strings, and
+// line and (nested) block comments. An unterminated literal or comment runs
+// to the end of the input.
+type sqlScanner struct {
+	src string
+	pos int
+}
+
+// next returns the next token, or false at the end of the input.
+func (sc *sqlScanner) next() (sqlToken, bool) {
+	sql, n := sc.src, len(sc.src)
+	i := sc.pos
+	token := func(kind tokenKind, start, end int) (sqlToken, bool) {
+		sc.pos = end
+		return sqlToken{Kind: kind, Text: sql[start:end], Start: start, End: end}, true
+	}
+
+	for i < n {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+
+		case c == '-' && i+1 < n && sql[i+1] == '-':
+			for i < n && sql[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && i+1 < n && sql[i+1] == '*':
+			depth := 0
+			for i < n {
+				if sql[i] == '/' && i+1 < n && sql[i+1] == '*' {
+					depth++
+					i += 2
+				} else if sql[i] == '*' && i+1 < n && sql[i+1] == '/' {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+
+		case c == '\'':
+			return token(tokString, i, skipQuoted(sql, i, '\'', false))
+
+		case (c == 'E' || c == 'e') && i+1 < n && sql[i+1] == '\'':
+			return token(tokString, i, skipQuoted(sql, i+1, '\'', true))
+
+		case c == '"':
+			return token(tokIdent, i, skipQuoted(sql, i, '"', false))
+
+		case c == '$':
+			if i+1 < n && sql[i+1] >= '0' && sql[i+1] <= '9' {
+				end := i + 1
+				for end < n && sql[end] >= '0' && sql[end] <= '9' {
+					end++
+				}
+				return token(tokParam, i, end)
+			}
+			if tag, ok := dollarTag(sql, i); ok {
+				end := strings.Index(sql[i+len(tag):], tag)
+				if end < 0 {
+					return token(tokString, i, n)
+				}
+				return token(tokString, i, i+len(tag)+end+len(tag))
+			}
+			return token(tokOther, i, i+1)
+
+		case c == ';':
+			return token(tokSemicolon, i, i+1)
+
+		case isWordByte(c):
+			end := i
+			for end < n && (isWordByte(sql[end]) || sql[end] == '$') {
+				end++
+			}
+			return token(tokWord, i, end)
+
+		default:
+			return token(tokOther, i, i+1)
+		}
+	}
+	sc.pos = n
+	return sqlToken{}, false
+}
+
+// tokenizeSQL splits sql into tokens, dropping whitespace and comments.
+func tokenizeSQL(sql string) []sqlToken {
+	var tokens []sqlToken
+	sc := sqlScanner{src: sql}
+	for {
+		t, ok := sc.next()
+		if !ok {
+			return tokens
+		}
+		tokens = append(tokens, t)
+	}
+}
+
+// skipQuoted returns the index just past the quoted section starting at
+// sql[i] == quote. Doubled quotes are escapes; with backslash set, so are
+// backslash sequences.
+func skipQuoted(sql string, i int, quote byte, backslash bool) int {
+	i++
+	for i < len(sql) {
+		switch {
+		case backslash && sql[i] == '\\':
+			i += 2
+		case sql[i] == quote:
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i += 2
+				continue
+			}
+			return i + 1
+		default:
+			i++
+		}
+	}
+	return len(sql)
+}
+
+// dollarTag returns the opening dollar-quote tag ($$ or $name$) at sql[i].
+func dollarTag(sql string, i int) (string, bool) {
+	j := i + 1
+	for j < len(sql) && sql[j] != '$' {
+		c := sql[j]
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80 || j > i+1 && c >= '0' && c <= '9') {
+			return "", false
+		}
+		j++
+	}
+	if j >= len(sql) {
+		return "", false
+	}
+	return sql[i : j+1], true
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// scriptStatement is one statement of a script.
+type scriptStatement struct {
+	Text  string
+	Start int // byte offset of Text in the script
+
+	// CopyData holds the rows following a COPY ... FROM STDIN statement,
+	// psql style: from the next line up to a line containing only \.
+	CopyData *string
+}
+
+// parseScript splits a script into its non-empty statements, without
+// trailing semicolons. Inline COPY data is attached to its statement rather
+// than parsed as SQL.
+func parseScript(sql string) []scriptStatement {
+	var stmts []scriptStatement
+	var cur []sqlToken
+	sc := sqlScanner{src: sql}
+	for {
+		t, ok := sc.next()
+		if ok && t.Kind != tokSemicolon {
+			cur = append(cur, t)
+			continue
+		}
+		if len(cur) > 0 {
+			st := scriptStatement{Text: sql[cur[0].Start:cur[len(cur)-1].End], Start: cur[0].Start}
+			if isCopyFromStdin(cur) {
+				data := sc.copyData()
+				st.CopyData = &data
+			}
+			stmts = append(stmts, st)
+			cur = nil
+		}
+		if !ok {
+			return stmts
+		}
+	}
+}
+
+// splitStatements returns the text of each statement in a script.
+func splitStatements(sql string) []string {
+	var stmts []string
+	for _, st := range parseScript(sql) {
+		stmts = append(stmts, st.Text)
+	}
+	return stmts
+}
+
+// isCopyFromStdin reports whether tokens are a COPY ... FROM STDIN statement.
+func isCopyFromStdin(tokens []sqlToken) bool {
+	if len(tokens) == 0 || tokens[0].upper() != "COPY" {
+		return false
+	}
+	for i := 1; i+1 < len(tokens); i++ {
+		if tokens[i].upper() == "FROM" && tokens[i+1].upper() == "STDIN" {
+			return true
+		}
+	}
+	return false
+}
+
+// copyData consumes and returns the inline data of a COPY FROM STDIN
+// statement whose terminating semicolon was just read. The data starts on
+// the following line and ends before a line containing only \. or at the
+// end of the input.
+func (sc *sqlScanner) copyData() string {
+	nl := strings.IndexByte(sc.src[sc.pos:], '\n')
+	if nl < 0 {
+		sc.pos = len(sc.src)
+		return ""
+	}
+	start := sc.pos + nl + 1
+	for i := start; i < len(sc.src); {
+		end := strings.IndexByte(sc.src[i:], '\n')
+		next := len(sc.src)
+		if end >= 0 {
+			next = i + end + 1
+			end += i
+		} else {
+			end = len(sc.src)
+		}
+		if strings.TrimSuffix(sc.src[i:end], "\r") == `\.` {
+			sc.pos = next
+			return sc.src[start:i]
+		}
+		i = next
+	}
+	sc.pos = len(sc.src)
+	return sc.src[start:]
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestTokenizeSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{"words and punctuation", "select a, b from t;", []string{"select", "a", ",", "b", "from", "t", ";"}},
+		{"single-quoted string", "select 'a;b'", []string{"select", "'a;b'"}},
+		{"doubled quote", "select 'it''s;'", []string{"select", "'it''s;'"}},
+		{"escape string", `select E'a\';b'`, []string{"select", `E'a\';b'`}},
+		{"lower-case escape string", `select e'\\'`, []string{"select", `e'\\'`}},
+		{"backslash in standard string", `select 'a\' ; 'b'`, []string{"select", `'a\'`, ";", "'b'"}},
+		{"quoted identifier", `select "a;""b"`, []string{"select", `"a;""b"`}},
+		{"dollar quote", "select $$a;'b$$", []string{"select", "$$a;'b$$"}},
+		{"tagged dollar quote", "select $fn$ $$;$$ $fn$;", []string{"select", "$fn$ $$;$$ $fn$", ";"}},
+		{"unterminated dollar quote", "select $x$a;", []string{"select", "$x$a;"}},
+		{"positional parameter", "select $1, $23", []string{"select", "$1", ",", "$23"}},
+		{"dollar in identifier", "select a$b", []string{"select", "a$b"}},
+		{"line comment", "select 1 -- a;b\n;", []string{"select", "1", ";"}},
+		{"block comment", "select /* a;b */ 1", []string{"select", "1"}},
+		{"nested block comment", "select /* a /* b; */ c; */ 1", []string{"select", "1"}},
+		{"unterminated block comment", "select 1 /* ;", []string{"select", "1"}},
+		{"cast", "select a::int", []string{"select", "a", ":", ":", "int"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, tok := range tokenizeSQL(tt.sql) {
+				got = append(got, tok.Text)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tokenizeSQL(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeSQLOffsets(t *testing.T) {
+	sql := "select /* c */ 'x'"
+	for _, tok := range tokenizeSQL(sql) {
+		if sql[tok.Start:tok.End] != tok.Text {
+			t.Errorf("token %q: sql[%d:%d] = %q", tok.Text, tok.Start, tok.End, sql[tok.Start:tok.End])
+		}
+	}
+}
+
+func TestParseScript(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name string
+		sql  string
+		want []scriptStatement
+	}{
+		{"empty", "", nil},
+		{"only semicolons and comments", " ; -- x\n ; /* y */", nil},
+		{
+			"single statement without semicolon",
+			"  select 1",
+			[]scriptStatement{{Text: "select 1", Start: 2}},
+		},
+		{
+			"offsets",
+			"select 1;\n  select 2 ;\nselect 3",
+			[]scriptStatement{
+				{Text: "select 1", Start: 0},
+				{Text: "select 2", Start: 12},
+				{Text: "select 3", Start: 23},
+			},
+		},
+		{
+			"leading comment is not part of the statement",
+			"-- first\nselect 1; /* second */ select 2",
+			[]scriptStatement{
+				{Text: "select 1", Start: 9},
+				{Text: "select 2", Start: 32},
+			},
+		},
+		{
+			"semicolons inside literals and comments",
+			"select 'a;b', \"c;d\", $$e;f$$ /* g; /* h; */ */; select E'\\';'",
+			[]scriptStatement{
+				{Text: "select 'a;b', \"c;d\", $$e;f$$", Start: 0},
+				{Text: "select E'\\';'", Start: 48},
+			},
+		},
+		{
+			"function body",
+			"create function f() returns int as $body$ begin return 1; end $body$ language plpgsql; select f()",
+			[]scriptStatement{
+				{Text: "create function f() returns int as $body$ begin return 1; end $body$ language plpgsql", Start: 0},
+				{Text: "select f()", Start: 87},
+			},
+		},
+		{
+			"copy from stdin",
+			"copy t (a, b) from stdin;\n1\tx;y\n2\t'z\n\\.\nselect 1",
+			[]scriptStatement{
+				{Text: "copy t (a, b) from stdin", Start: 0, CopyData: str("1\tx;y\n2\t'z\n")},
+				{Text: "select 1", Start: 40},
+			},
+		},
+		{
+			"copy data with CRLF terminator",
+			"COPY t FROM STDIN WITH (FORMAT csv);\r\n1,2\r\n\\.\r\nselect 1",
+			[]scriptStatement{
+				{Text: "COPY t FROM STDIN WITH (FORMAT csv)", Start: 0, CopyData: str("1,2\r\n")},
+				{Text: "select 1", Start: 47},
+			},
+		},
+		{
+			"copy data without terminator runs to the end",
+			"copy t from stdin;\n1\n2\n",
+			[]scriptStatement{{Text: "copy t from stdin", Start: 0, CopyData: str("1\n2\n")}},
+		},
+		{
+			"copy at end of input has no data",
+			"copy t from stdin;",
+			[]scriptStatement{{Text: "copy t from stdin", Start: 0, CopyData: str("")}},
+		},
+		{
+			"copy to stdout has no data",
+			"copy t to stdout;\nselect 1",
+			[]scriptStatement{
+				{Text: "copy t to stdout", Start: 0},
+				{Text: "select 1", Start: 18},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseScript(tt.sql)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseScript(%q)\n got %s\nwant %s", tt.sql, fmtStatements(got), fmtStatements(tt.want))
+			}
+			for _, st := range got {
+				if tt.sql[st.Start:st.Start+len(st.Text)] != st.Text {
+					t.Errorf("statement %q does not start at offset %d", st.Text, st.Start)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	got := splitStatements("select 1; ; select ';'")
+	want := []string{"select 1", "select ';'"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("splitStatements = %q, want %q", got, want)
+	}
+}
+
+func fmtStatements(stmts []scriptStatement) string {
+	s := "["
+	for i, st := range stmts {
+		if i > 0 {
+			s += ", "
+		}
+		data := "<nil>"
+		if st.CopyData != nil {
+			data = strconv.Quote(*st.CopyData)
+		}
+		s += fmt.Sprintf("{%q @%d copy=%s}", st.Text, st.Start, data)
+	}
+	return s + "]"
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/macleodmac/pglet/pkg/client"
+	"github.com/macleodmac/pglet/pkg/repository"
+)
+
+// TopStatements returns the pg_stat_statements counters of the current
+// database ranked by order, at most limit of them (all if limit <= 0).
+func (s *Service) TopStatements(ctx context.Context, connID string, order client.StatementOrder, limit int) ([]client.StatementStat, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := cl.StatementStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rankStatements(stats, order, limit), nil
+}
+
+func rankStatements(stats []client.StatementStat, order client.StatementOrder, limit int) []client.StatementStat {
+	client.SortStatements(stats, order)
+	if limit > 0 && len(stats) > limit {
+		stats = stats[:limit]
+	}
+	if stats == nil {
+		stats = []client.StatementStat{}
+	}
+	return stats
+}
+
+// ResetStatementStats discards the pg_stat_statements counters. It is not
+// allowed on read-only connections.
+func (s *Service) ResetStatementStats(ctx context.Context, connID string) error {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return err
+	}
+	if cl.ReadOnly() {
+		return fmt.Errorf("%w: cannot reset statement statistics", ErrReadOnly)
+	}
+	return cl.ResetStatementStats(ctx)
+}
+
+// TakeStatementSnapshot stores the current pg_stat_statements counters of
+// the connection's database.
+func (s *Service) TakeStatementSnapshot(ctx context.Context, connID, label string) (*repository.StatementSnapshot, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := cl.StatementStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snap := repository.StatementSnapshot{
+		Connection: connKey(connID), Database: cl.Database(), Label: label,
+		Statements: make([]repository.StatementCounters, len(stats)),
+	}
+	for i, st := range stats {
+		snap.Statements[i] = repository.StatementCounters{
+			QueryID: st.QueryID, User: st.User, Query: st.Query,
+			Calls: st.Calls, TotalMs: st.TotalMs, Rows: st.Rows,
+			SharedBlksHit: st.SharedBlksHit, SharedBlksRead: st.SharedBlksRead,
+		}
+	}
+	created, err := s.Repo.AddStatementSnapshot(snap)
+	if err != nil {
+		return nil, err
+	}
+	created.Statements = nil
+	return created, nil
+}
+
+func (s *Service) ListStatementSnapshots() ([]repository.StatementSnapshot, error) {
+	return s.Repo.ListStatementSnapshots()
+}
+
+func (s *Service) DeleteStatementSnapshot(id int) error {
+	return s.Repo.DeleteStatementSnapshot(id)
+}
+
+// StatementDelta is the activity between two statement snapshots.
+type StatementDelta struct {
+	From *repository.StatementSnapshot
+	To   *repository.StatementSnapshot // nil when compared with the live counters
+	// Statements are the counter deltas of the statements called in between.
+	Statements []client.StatementStat
+}
+
+// CompareStatementSnapshots returns the change in counters from snapshot
+// fromID to snapshot toID, or to the connection's current counters if toID
+// is nil, ranked by order. Both sides must be of the same connection and
+// database, or ErrSnapshotMismatch is returned.
+func (s *Service) CompareStatementSnapshots(ctx context.Context, connID string, fromID int, toID *int, order client.StatementOrder, limit int) (*StatementDelta, error) {
+	from, err := s.Repo.GetStatementSnapshot(fromID)
+	if err != nil {
+		return nil, err
+	}
+	delta := &StatementDelta{From: from}
+	var after []client.StatementStat
+	connection, database := "", ""
+	if toID != nil {
+		delta.To, err = s.Repo.GetStatementSnapshot(*toID)
+		if err != nil {
+			return nil, err
+		}
+		connection, database = delta.To.Connection, delta.To.Database
+		after = snapshotStats(delta.To)
+	} else {
+		cl, err := s.requireClient(connID)
+		if err != nil {
+			return nil, err
+		}
+		if after, err = cl.StatementStats(ctx); err != nil {
+			return nil, err
+		}
+		connection, database = connKey(connID), cl.Database()
+	}
+	if from.Connection != connection || from.Database != database {
+		return nil, fmt.Errorf("%w: %s/%s and %s/%s", ErrSnapshotMismatch,
+			from.Connection, from.Database, connection, database)
+	}
+
+	delta.Statements = rankStatements(client.StatementDeltas(snapshotStats(from), after), order, limit)
+	from.Statements = nil
+	if delta.To != nil {
+		delta.To.Statements = nil
+	}
+	return delta, nil
+}
+
+func snapshotStats(snap *repository.StatementSnapshot) []client.StatementStat {
+	stats := make([]client.StatementStat, len(snap.Statements))
+	for i, c := range snap.Statements {
+		stats[i] = client.StatementStat{
+			QueryID: c.QueryID, User: c.User, Query: c.Query,
+			Calls: c.Calls, TotalMs: c.TotalMs, Rows: c.Rows,
+			SharedBlksHit: c.SharedBlksHit, SharedBlksRead: c.SharedBlksRead,
+		}
+	}
+	return stats
+}
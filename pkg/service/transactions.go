@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/macleodmac/pglet/pkg/client"
+)
+
+// DefaultIdleTxTimeout is how long a tab's transaction may sit idle before it
+// is rolled back automatically.
+const DefaultIdleTxTimeout = 5 * time.Minute
+
+// statusProbeTimeout bounds the transaction status check after each query.
+const statusProbeTimeout = 5 * time.Second
+
+var (
+	ErrTxActive     = errors.New("a transaction is already open in this tab")
+	ErrNoTx         = errors.New("no transaction is open in this tab")
+	ErrTxConnection = errors.New("this tab has an open transaction on another connection")
+	ErrTxAborted    = errors.New("transaction was aborted by an earlier error and has been rolled back")
+	ErrTxBusy       = errors.New("a query is still running in this tab's transaction")
+)
+
+// TxInfo describes the transaction state of a tab.
+type TxInfo struct {
+	TabID      string
+	Connection string
+	Status     client.TxStatus
+	StartedAt  time.Time // zero when idle
+	ExpiresAt  time.Time // zero when idle or while a query is running
+}
+
+// tabSession is a connection pinned to a tab for the life of a transaction.
+type tabSession struct {
+	sess      *client.Session
+	connID    string
+	status    client.TxStatus
+	startedAt time.Time
+	expiresAt time.Time
+	busy      bool
+	timer     *time.Timer
+}
+
+// BeginTransaction pins a connection to tabID and opens a transaction on it.
+// Queries run in the tab use that connection until the transaction ends.
+func (s *Service) BeginTransaction(ctx context.Context, connID, tabID string) (*TxInfo, error) {
+	cl, err := s.requireClient(connID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.txMu.Lock()
+	_, open := s.sessions[tabID]
+	s.txMu.Unlock()
+	if open {
+		return nil, ErrTxActive
+	}
+
+	sess, err := cl.PinnedSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := sess.Exec(ctx, "BEGIN"); err != nil {
+		sess.Close()
+		return nil, err
+	}
+
+	ts := &tabSession{sess: sess, connID: connKey(connID), status: client.TxActive, startedAt: time.Now().UTC()}
+	s.txMu.Lock()
+	if _, open := s.sessions[tabID]; open {
+		s.txMu.Unlock()
+		sess.Close()
+		return nil, ErrTxActive
+	}
+	s.sessions[tabID] = ts
+	s.armTimeout(tabID, ts)
+	info := ts.info(tabID)
+	s.txMu.Unlock()
+	return &info, nil
+}
+
+// CommitTransaction commits the tab's transaction and releases its
+// connection. A transaction aborted by an error is rolled back instead and
+// ErrTxAborted is returned.
+func (s *Service) CommitTransaction(ctx context.Context, tabID string) (*TxInfo, error) {
+	return s.endTransaction(ctx, tabID, "COMMIT")
+}
+
+// RollbackTransaction rolls back the tab's transaction and releases its
+// connection.
+func (s *Service) RollbackTransaction(ctx context.Context, tabID string) (*TxInfo, error) {
+	return s.endTransaction(ctx, tabID, "ROLLBACK")
+}
+
+func (s *Service) endTransaction(ctx context.Context, tabID, stmt string) (*TxInfo, error) {
+	s.txMu.Lock()
+	ts, ok := s.sessions[tabID]
+	switch {
+	case !ok:
+		s.txMu.Unlock()
+		return nil, ErrNoTx
+	case ts.busy:
+		s.txMu.Unlock()
+		return nil, ErrTxBusy
+	}
+	s.dropSession(tabID, ts)
+	s.txMu.Unlock()
+
+	err := ts.sess.Exec(ctx, stmt)
+	ts.sess.Close()
+	if err != nil {
+		return nil, err
+	}
+	info := &TxInfo{TabID: tabID, Connection: ts.connID, Status: client.TxIdle}
+	if stmt == "COMMIT" && ts.status == client.TxFailed {
+		return info, ErrTxAborted
+	}
+	return info, nil
+}
+
+// TransactionStatus reports the transaction state of tabID.
+func (s *Service) TransactionStatus(tabID string) TxInfo {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	if ts, ok := s.sessions[tabID]; ok {
+		return ts.info(tabID)
+	}
+	return TxInfo{TabID: tabID, Status: client.TxIdle}
+}
+
+// tabSession picks the connection a tab's query runs on: its pinned session
+// if it has an open transaction, a newly pinned session if the query itself
+// starts one, and a connection borrowed from the pool for this query
+// otherwise. Running on a known connection lets CancelQuery signal its
+// backend. The returned func must be called once the query has finished; it
+// returns a borrowed connection, or records a pinned session's new status and
+// releases it if the transaction has ended.
+func (s *Service) tabSession(ctx context.Context, connID, tabID, query string, cl *client.Client) (*client.Session, func(), error) {
+	s.txMu.Lock()
+	ts, ok := s.sessions[tabID]
+	if ok {
+		if ts.connID != connKey(connID) {
+			s.txMu.Unlock()
+			return nil, nil, ErrTxConnection
+		}
+		if ts.busy {
+			s.txMu.Unlock()
+			return nil, nil, ErrTxBusy
+		}
+		ts.busy = true
+		ts.expiresAt = time.Time{}
+		ts.timer.Stop()
+	}
+	s.txMu.Unlock()
+
+	if !ok {
+		if !startsTransaction(query) {
+			sess, err := cl.Session(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return sess, func() { sess.Release() }, nil
+		}
+		sess, err := cl.PinnedSession(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		ts = &tabSession{sess: sess, connID: connKey(connID), startedAt: time.Now().UTC(), busy: true}
+		s.txMu.Lock()
+		if _, open := s.sessions[tabID]; open {
+			// Raced with a BeginTransaction for the same tab.
+			s.txMu.Unlock()
+			sess.Close()
+			return nil, nil, ErrTxActive
+		}
+		s.sessions[tabID] = ts
+		s.txMu.Unlock()
+	}
+
+	return ts.sess, func() { s.afterTabQuery(tabID, ts) }, nil
+}
+
+// afterTabQuery refreshes the session's transaction status and either
+// re-arms the idle timeout or releases the session.
+func (s *Service) afterTabQuery(tabID string, ts *tabSession) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusProbeTimeout)
+	status, err := ts.sess.Status(ctx)
+	cancel()
+
+	s.txMu.Lock()
+	if s.sessions[tabID] != ts {
+		// Ended or replaced while the query ran.
+		s.txMu.Unlock()
+		return
+	}
+	ts.busy = false
+	if err != nil || status == client.TxIdle {
+		s.dropSession(tabID, ts)
+		s.txMu.Unlock()
+		ts.sess.Close()
+		return
+	}
+	ts.status = status
+	s.armTimeout(tabID, ts)
+	s.txMu.Unlock()
+}
+
+// armTimeout schedules an automatic rollback once the session has been idle
+// for IdleTxTimeout. Caller must hold txMu.
+func (s *Service) armTimeout(tabID string, ts *tabSession) {
+	ts.expiresAt = time.Now().Add(s.IdleTxTimeout).UTC()
+	if ts.timer != nil {
+		ts.timer.Reset(s.IdleTxTimeout)
+		return
+	}
+	ts.timer = time.AfterFunc(s.IdleTxTimeout, func() {
+		s.txMu.Lock()
+		if s.sessions[tabID] != ts || ts.busy {
+			s.txMu.Unlock()
+			return
+		}
+		s.dropSession(tabID, ts)
+		s.txMu.Unlock()
+		slog.Info("rolled back idle transaction", "tab", tabID, "connection", ts.connID)
+		ts.sess.Close()
+	})
+}
+
+// dropSession removes a session from the registry. The caller closes it
+// after releasing txMu. Caller must hold txMu.
+func (s *Service) dropSession(tabID string, ts *tabSession) {
+	if ts.timer != nil {
+		ts.timer.Stop()
+	}
+	delete(s.sessions, tabID)
+}
+
+// closeSessions rolls back and releases every session on connID, before its
+// client is closed or replaced.
+func (s *Service) closeSessions(connID string) {
+	var closing []*tabSession
+	s.txMu.Lock()
+	for tabID, ts := range s.sessions {
+		if ts.connID == connID {
+			s.dropSession(tabID, ts)
+			closing = append(closing, ts)
+		}
+	}
+	s.txMu.Unlock()
+	for _, ts := range closing {
+		ts.sess.Close()
+	}
+}
+
+func (ts *tabSession) info(tabID string) TxInfo {
+	status := ts.status
+	if status == "" {
+		status = client.TxActive
+	}
+	return TxInfo{
+		TabID: tabID, Connection: ts.connID, Status: status,
+		StartedAt: ts.startedAt, ExpiresAt: ts.expiresAt,
+	}
+}
+
+// startsTransaction reports whether any statement in query opens a
+// transaction block.
+func startsTransaction(query string) bool {
+	for _, stmt := range splitStatements(query) {
+		tokens := tokenizeSQL(stmt)
+		if len(tokens) == 0 {
+			continue
+		}
+		switch tokens[0].upper() {
+		case "BEGIN", "START":
+			return true
+		}
+	}
+	return false
+}